@@ -5,20 +5,24 @@ import (
 	"os"
 
 	"github.com/mgutz/ansi"
-
-	"github.com/luhring/reach/reach"
 )
 
-func doAssertReachable(analysis reach.Analysis) {
-	if analysis.PassesAssertReachable() {
+// An assertable result (i.e. a reach.Analysis or a reach.Matrix) can be checked with the assertion flags.
+type assertable interface {
+	PassesAssertReachable() bool
+	PassesAssertNotReachable() bool
+}
+
+func doAssertReachable(result assertable) {
+	if result.PassesAssertReachable() {
 		exitSuccessfulAssertion("source is able to reach destination")
 	} else {
 		exitFailedAssertion("one or more forward or return paths of network traffic is obstructed")
 	}
 }
 
-func doAssertNotReachable(analysis reach.Analysis) {
-	if analysis.PassesAssertNotReachable() {
+func doAssertNotReachable(result assertable) {
+	if result.PassesAssertNotReachable() {
 		exitSuccessfulAssertion("source is unable to reach destination")
 	} else {
 		exitFailedAssertion("source is able to send network traffic to destination")
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luhring/reach/reach/aws/api"
+	"github.com/luhring/reach/reach/output"
+	"github.com/luhring/reach/reach/policy"
+)
+
+const checkOutputFlag = "output"
+
+var checkOutputPath string
+
+var checkCmd = &cobra.Command{
+	Use:   "check <policy-file>",
+	Short: "check a policy file of network reachability expectations",
+	Long: `check a policy file of network reachability expectations
+Each expectation in the policy file states whether some traffic must or must not be allowed from one set of subjects to another. reach exits 2 if any expectation isn't met.
+See https://github.com/luhring/reach for the policy file format.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+
+		if _, err := output.LookupReport(outputFormat()); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+
+		formatter, err := output.LookupReport(outputFormat())
+		if err != nil {
+			exitWithError(err)
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		p, err := policy.Read(f, path)
+		_ = f.Close()
+		if err != nil {
+			exitWithError(err)
+		}
+
+		var apiOptions []api.Option
+		if prefetchVPCs {
+			apiOptions = append(apiOptions, api.WithVPCPrefetch())
+		}
+
+		provider, err := newResourceProvider(snapshotPath, apiOptions...)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		report := policy.Evaluate(p, provider)
+
+		out := os.Stdout
+		if checkOutputPath != "" {
+			out, err = os.Create(checkOutputPath)
+			if err != nil {
+				exitWithError(err)
+			}
+		}
+
+		if err := formatter.FormatReport(out, report); err != nil {
+			exitWithError(err)
+		}
+
+		if checkOutputPath != "" {
+			if err := out.Close(); err != nil {
+				exitWithError(err)
+			}
+		}
+
+		if !report.Passed() {
+			passed, failed, errored := report.Counts()
+			exitFailedAssertion(fmt.Sprintf("%d of %d expectations not met", failed+errored, passed+failed+errored))
+		}
+	},
+}
+
+func init() {
+	checkCmd.Flags().StringVar(&format, formatFlag, "", "report format: "+strings.Join(output.ReportNames(), ", ")+" (default \"text\")")
+	checkCmd.Flags().StringVarP(&checkOutputPath, checkOutputFlag, "o", "", "write the report to a file instead of stdout (e.g. for a CI system to pick up)")
+	checkCmd.Flags().StringVar(&snapshotPath, snapshotFlag, "", "analyze AWS resources from a snapshot file (see 'reach snapshot save') instead of the AWS API")
+	checkCmd.Flags().BoolVar(&prefetchVPCs, prefetchVPCsFlag, false, "describe all resources in each relevant VPC up front, using fewer AWS API requests in large accounts")
+	rootCmd.AddCommand(checkCmd)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/analyzer"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/api"
+	"github.com/luhring/reach/reach/output"
+)
+
+const diffBeforeFlag = "before"
+const diffAfterFlag = "after"
+
+var diffBeforePath string
+var diffAfterPath string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <source> <destination> --before <snapshot-file> [--after <snapshot-file>]",
+	Short: "compare the network traffic allowed from source to destination in two configurations",
+	Long: `compare the network traffic allowed from source to destination in two configurations
+The "before" configuration is read from a snapshot file (see 'reach snapshot save'). The "after" configuration is read from another snapshot file, or, if --after isn't specified, from the AWS API.
+For each network vector, reach shows the traffic that was gained (+) or lost (-), and the factors and rules that account for the change.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+
+		if diffBeforePath == "" {
+			return errors.New("requires --" + diffBeforeFlag)
+		}
+
+		if _, err := output.LookupDiff(outputFormat()); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		query, err := trafficQuery()
+		if err != nil {
+			exitWithError(err)
+		}
+
+		before, err := analyzeConfiguration(diffBeforePath, args[0], args[1], query)
+		if err != nil {
+			exitWithError(fmt.Errorf("unable to analyze before configuration: %v", err))
+		}
+
+		after, err := analyzeConfiguration(diffAfterPath, args[0], args[1], query)
+		if err != nil {
+			exitWithError(fmt.Errorf("unable to analyze after configuration: %v", err))
+		}
+
+		diff, err := reach.NewAnalysisDiff(before, after)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err := aws.AttributeRuleDiffs(diff, before, after); err != nil {
+			exitWithError(err)
+		}
+
+		name := outputFormat()
+
+		formatter, err := output.LookupDiff(name)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if name == defaultFormat {
+			afterDescription := "live AWS resources"
+			if diffAfterPath != "" {
+				afterDescription = diffAfterPath
+			}
+
+			fmt.Printf("source: %s\ndestination: %s\nbefore: %s\nafter: %s\n\n", args[0], args[1], diffBeforePath, afterDescription)
+			printTrafficQuery(query)
+		}
+
+		if err := formatter.FormatDiff(os.Stdout, diff); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+// analyzeConfiguration analyzes the traffic from source to destination using the AWS resources from the snapshot file at snapshotPath, or from the AWS API (honoring --prefetch-vpcs) if snapshotPath is empty.
+func analyzeConfiguration(snapshotPath, sourceIdentifier, destinationIdentifier string, query *reach.TrafficContent) (*reach.Analysis, error) {
+	var apiOptions []api.Option
+	if prefetchVPCs {
+		apiOptions = append(apiOptions, api.WithVPCPrefetch())
+	}
+
+	provider, err := newResourceProvider(snapshotPath, apiOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := aws.NewSubject(sourceIdentifier, provider)
+	if err != nil {
+		return nil, err
+	}
+	source.SetRoleToSource()
+
+	destination, err := aws.NewSubject(destinationIdentifier, provider)
+	if err != nil {
+		return nil, err
+	}
+	destination.SetRoleToDestination()
+
+	options := []analyzer.Option{analyzer.WithProvider(provider)}
+	if query != nil {
+		options = append(options, analyzer.WithTrafficQuery(*query))
+	}
+
+	return analyzer.New(options...).Analyze(source, destination)
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffBeforePath, diffBeforeFlag, "", "snapshot file of the configuration before the change")
+	diffCmd.Flags().StringVar(&diffAfterPath, diffAfterFlag, "", "snapshot file of the configuration after the change (defaults to the live AWS resources)")
+	diffCmd.Flags().StringVar(&format, formatFlag, "", "output format: "+strings.Join(output.DiffNames(), ", ")+" (default \"text\")")
+	diffCmd.Flags().BoolVar(&outputJSON, jsonFlag, false, "output the differences as JSON (same as --format json)")
+	diffCmd.Flags().BoolVar(&prefetchVPCs, prefetchVPCsFlag, false, "describe all resources in each relevant VPC up front, using fewer AWS API requests in large accounts")
+	diffCmd.Flags().StringVar(&protocol, protocolFlag, "", "consider only traffic of this IP protocol (e.g. 'tcp', 'udp', 'icmp' or a protocol number)")
+	diffCmd.Flags().StringArrayVar(&ports, portFlag, nil, "consider only this port or port range (e.g. '5432' or '8000-8100') of the --protocol; repeatable")
+	diffCmd.Flags().StringVar(&traffic, trafficFlag, "", "consider only this traffic (e.g. 'tcp/443,udp/53,icmp' or 'tcp:22,443; icmp:echo-request')")
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,22 @@
+package cmd
+
+// defaultFormat is the name of the output format used if no other format is selected.
+const defaultFormat = "text"
+
+// outputFormat returns the name of the output format selected by --format, or else by one of the older display flags (--json, --explain, --vectors or --csv).
+func outputFormat() string {
+	switch {
+	case format != "":
+		return format
+	case outputJSON:
+		return "json"
+	case explain:
+		return "explain"
+	case showVectors:
+		return "vectors"
+	case outputCSV:
+		return "csv"
+	default:
+		return defaultFormat
+	}
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/analyzer"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/output"
+)
+
+// A subjectGroup is the set of subjects that an identifier refers to.
+type subjectGroup struct {
+	identifier string
+	subjects   []*reach.Subject
+}
+
+// expandSubjects returns the subjects for all of the given identifiers, each of which can refer to more than one subject (see aws.NewSubjects), along with the subjects for each identifier. A subject referred to by more than one identifier is only included once.
+func expandSubjects(identifiers []string, provider aws.ResourceProvider) ([]*reach.Subject, []subjectGroup, error) {
+	var result []*reach.Subject
+	var groups []subjectGroup
+
+	for _, identifier := range identifiers {
+		subjects, err := aws.NewSubjects(identifier, provider)
+		if err != nil {
+			return nil, nil, err
+		}
+		groups = append(groups, subjectGroup{identifier, subjects})
+
+	subjects:
+		for _, subject := range subjects {
+			for _, existing := range result {
+				if reach.SameSubject(existing, subject) {
+					continue subjects
+				}
+			}
+
+			result = append(result, subject)
+		}
+	}
+
+	return result, groups, nil
+}
+
+// printSubjectGroups lists the subjects that each identifier refers to.
+func printSubjectGroups(heading string, groups []subjectGroup, provider aws.ResourceProvider) {
+	fmt.Printf("%s:\n", heading)
+
+	for _, group := range groups {
+		fmt.Printf("  %s:\n", group.identifier)
+
+		for _, subject := range group.subjects {
+			fmt.Printf("    - %s\n", aws.SubjectName(subject, provider))
+		}
+	}
+
+	fmt.Println()
+}
+
+// runMatrix analyzes every pairing of the sources and destinations, and displays the results as a reachability matrix.
+func runMatrix(a *analyzer.Analyzer, provider aws.ResourceProvider, sources, destinations []*reach.Subject, sourceGroups, destinationGroups []subjectGroup) {
+	matrix, err := a.AnalyzeMatrix(sources, destinations)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	name := outputFormat()
+
+	formatter, err := output.Lookup(name)
+	if err != nil {
+		exitWithError(err)
+	}
+
+	if name == defaultFormat {
+		printSubjectGroups("sources", sourceGroups, provider)
+		printSubjectGroups("destinations", destinationGroups, provider)
+	}
+
+	if err := formatter.FormatMatrix(os.Stdout, matrix); err != nil {
+		exitWithError(err)
+	}
+
+	if assertReachable {
+		doAssertReachable(*matrix)
+	}
+
+	if assertNotReachable {
+		doAssertNotReachable(*matrix)
+	}
+}
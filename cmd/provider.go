@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/api"
+	"github.com/luhring/reach/reach/aws/snapshot"
+)
+
+// newResourceProvider returns a provider that reads AWS resources from the snapshot file at snapshotPath, or from the AWS API (configured using apiOptions) if snapshotPath is empty.
+func newResourceProvider(snapshotPath string, apiOptions ...api.Option) (aws.ResourceProvider, error) {
+	if snapshotPath == "" {
+		provider, err := api.NewResourceProvider(apiOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		return provider, nil
+	}
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	s, err := snapshot.Read(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.NewResourceProvider(s), nil
+}
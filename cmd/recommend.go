@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/api"
+	"github.com/luhring/reach/reach/policy"
+)
+
+var recommendOutputJSON bool
+
+var recommendCmd = &cobra.Command{
+	Use:   "recommend <policy-file>",
+	Short: "recommend tighter security group rules for the flows a policy file requires",
+	Long: `recommend tighter security group rules for the flows a policy file requires
+Each "reachable" expectation in the policy file is a required flow. reach reports the security group rules that apply to the required flows but allow more traffic (or more sources or destinations) than they need, and suggests replacement rules that allow only what's required.
+See https://github.com/luhring/reach for the policy file format.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+
+		f, err := os.Open(path)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		p, err := policy.Read(f, path)
+		_ = f.Close()
+		if err != nil {
+			exitWithError(err)
+		}
+
+		var apiOptions []api.Option
+		if prefetchVPCs {
+			apiOptions = append(apiOptions, api.WithVPCPrefetch())
+		}
+
+		provider, err := newResourceProvider(snapshotPath, apiOptions...)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		flows, err := policy.RequiredFlows(p, provider)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		recommendations, err := aws.Recommend(flows)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if recommendOutputJSON {
+			if recommendations == nil {
+				recommendations = []aws.RuleRecommendation{}
+			}
+
+			output, err := json.MarshalIndent(recommendations, "", "  ")
+			if err != nil {
+				exitWithError(err)
+			}
+			fmt.Println(string(output))
+			return
+		}
+
+		fmt.Printf("required flows: %d (only the security group rules that apply to them are considered)\n\n", len(flows))
+
+		if len(recommendations) == 0 {
+			fmt.Println("every security group rule allows only what the required flows need")
+			return
+		}
+
+		for _, recommendation := range recommendations {
+			fmt.Println(recommendation.String())
+		}
+	},
+}
+
+func init() {
+	recommendCmd.Flags().BoolVar(&recommendOutputJSON, jsonFlag, false, "output the recommendations as JSON")
+	recommendCmd.Flags().StringVar(&snapshotPath, snapshotFlag, "", "analyze AWS resources from a snapshot file (see 'reach snapshot save') instead of the AWS API")
+	recommendCmd.Flags().BoolVar(&prefetchVPCs, prefetchVPCsFlag, false, "describe all resources in each relevant VPC up front, using fewer AWS API requests in large accounts")
+	rootCmd.AddCommand(recommendCmd)
+}
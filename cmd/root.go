@@ -3,14 +3,14 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/luhring/reach/reach/analyzer"
-	"github.com/luhring/reach/reach/aws"
 	"github.com/luhring/reach/reach/aws/api"
-	"github.com/luhring/reach/reach/explainer"
+	"github.com/luhring/reach/reach/output"
 )
 
 const explainFlag = "explain"
@@ -18,23 +18,53 @@ const vectorsFlag = "vectors"
 const jsonFlag = "json"
 const assertReachableFlag = "assert-reachable"
 const assertNotReachableFlag = "assert-not-reachable"
+const snapshotFlag = "snapshot"
+const prefetchVPCsFlag = "prefetch-vpcs"
+const fromFlag = "from"
+const toFlag = "to"
+const csvFlag = "csv"
+const protocolFlag = "protocol"
+const portFlag = "port"
+const trafficFlag = "traffic"
+const formatFlag = "format"
 
 var explain bool
 var showVectors bool
 var outputJSON bool
 var assertReachable bool
 var assertNotReachable bool
+var snapshotPath string
+var prefetchVPCs bool
+var fromIdentifiers []string
+var toIdentifiers []string
+var outputCSV bool
+var protocol string
+var ports []string
+var traffic string
+var format string
 
 var rootCmd = &cobra.Command{
-	Use:   "reach",
+	Use:   "reach [source destination]",
 	Short: "reach examines network reachability issues in AWS",
 	Long: `reach examines network reachability issues in AWS
 See https://github.com/luhring/reach for documentation.`,
 	Args: func(cmd *cobra.Command, args []string) error {
-		if len(args) < 2 {
+		if len(fromIdentifiers) > 0 || len(toIdentifiers) > 0 {
+			if len(args) > 0 {
+				return fmt.Errorf("cannot use arguments together with --%s and --%s", fromFlag, toFlag)
+			}
+
+			if len(fromIdentifiers) == 0 || len(toIdentifiers) == 0 {
+				return fmt.Errorf("requires both --%s and --%s", fromFlag, toFlag)
+			}
+		} else if len(args) < 2 {
 			return errors.New("requires at least two arguments")
 		}
 
+		if _, err := output.Lookup(outputFormat()); err != nil {
+			return err
+		}
+
 		if assertReachable && assertNotReachable {
 			return errors.New("cannot assert both reachable and not reachable at the same time")
 		}
@@ -42,73 +72,63 @@ See https://github.com/luhring/reach for documentation.`,
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
-		sourceIdentifier := args[0]
-		destinationIdentifier := args[1]
+		sourceIdentifiers, destinationIdentifiers := fromIdentifiers, toIdentifiers
+		if len(args) >= 2 {
+			sourceIdentifiers, destinationIdentifiers = args[:1], args[1:2]
+		}
+
+		query, err := trafficQuery()
+		if err != nil {
+			exitWithError(err)
+		}
 
-		var provider aws.ResourceProvider = api.NewResourceProvider()
+		var apiOptions []api.Option
+		if prefetchVPCs {
+			apiOptions = append(apiOptions, api.WithVPCPrefetch())
+		}
 
-		source, err := aws.NewSubject(sourceIdentifier, provider)
+		provider, err := newResourceProvider(snapshotPath, apiOptions...)
 		if err != nil {
 			exitWithError(err)
 		}
-		source.SetRoleToSource()
 
-		destination, err := aws.NewSubject(destinationIdentifier, provider)
+		sources, sourceGroups, err := expandSubjects(sourceIdentifiers, provider)
 		if err != nil {
 			exitWithError(err)
 		}
-		destination.SetRoleToDestination()
 
-		if !outputJSON && !explain && !showVectors {
-			fmt.Printf("source: %s\ndestination: %s\n\n", source.ID, destination.ID)
+		destinations, destinationGroups, err := expandSubjects(destinationIdentifiers, provider)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		analyzerOptions := []analyzer.Option{analyzer.WithProvider(provider)}
+		if query != nil {
+			analyzerOptions = append(analyzerOptions, analyzer.WithTrafficQuery(*query))
 		}
+		a := analyzer.New(analyzerOptions...)
+
+		if len(sources) > 1 || len(destinations) > 1 {
+			runMatrix(a, provider, sources, destinations, sourceGroups, destinationGroups)
+			return
+		}
+
+		source, destination := sources[0], destinations[0]
+		source.SetRoleToSource()
+		destination.SetRoleToDestination()
 
-		a := analyzer.New()
 		analysis, err := a.Analyze(source, destination)
 		if err != nil {
 			exitWithError(err)
 		}
 
-		mergedTraffic, err := analysis.MergedTraffic()
+		formatter, err := output.Lookup(outputFormat())
 		if err != nil {
 			exitWithError(err)
 		}
 
-		if outputJSON {
-			fmt.Println(analysis.ToJSON())
-		} else if explain {
-			ex := explainer.New(*analysis)
-			fmt.Print(ex.Explain())
-		} else if showVectors {
-			var vectorOutputs []string
-
-			for _, v := range analysis.NetworkVectors {
-				vectorOutputs = append(vectorOutputs, v.String())
-			}
-
-			fmt.Print(strings.Join(vectorOutputs, "\n"))
-		} else {
-			fmt.Print("network traffic allowed from source to destination:" + "\n")
-			fmt.Print(mergedTraffic.ColorStringWithSymbols())
-
-			if len(analysis.NetworkVectors) > 1 { // handling this case with care; this view isn't optimized for multi-vector output!
-				printMergedResultsWarning()
-				warnIfAnyVectorHasRestrictedReturnTraffic(analysis.NetworkVectors)
-			} else {
-				// calculate merged return traffic
-				mergedReturnTraffic, err := analysis.MergedReturnTraffic()
-				if err != nil {
-					exitWithError(err)
-				}
-
-				restrictedProtocols := mergedTraffic.ProtocolsWithRestrictedReturnPath(mergedReturnTraffic)
-				if len(restrictedProtocols) > 0 {
-					found, warnings := explainer.WarningsFromRestrictedReturnPath(restrictedProtocols)
-					if found {
-						fmt.Print("\n" + warnings + "\n")
-					}
-				}
-			}
+		if err := formatter.FormatAnalysis(os.Stdout, analysis); err != nil {
+			exitWithError(err)
 		}
 
 		if assertReachable {
@@ -129,9 +149,18 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.Flags().BoolVar(&explain, explainFlag, false, "explain how the configuration was analyzed")
-	rootCmd.Flags().BoolVar(&showVectors, vectorsFlag, false, "show allowed traffic in terms of network vectors")
-	rootCmd.Flags().BoolVar(&outputJSON, jsonFlag, false, "output full analysis as JSON (overrides other display flags)")
-	rootCmd.Flags().BoolVar(&assertReachable, assertReachableFlag, false, "exit non-zero if no traffic is allowed from source to destination")
+	rootCmd.Flags().StringVar(&format, formatFlag, "", "output format: "+strings.Join(output.Names(), ", ")+" (default \"text\")")
+	rootCmd.Flags().BoolVar(&explain, explainFlag, false, "explain how the configuration was analyzed (same as --format explain)")
+	rootCmd.Flags().BoolVar(&showVectors, vectorsFlag, false, "show allowed traffic in terms of network vectors (same as --format vectors)")
+	rootCmd.Flags().BoolVar(&outputJSON, jsonFlag, false, "output full analysis as JSON (same as --format json)")
+	rootCmd.Flags().BoolVar(&assertReachable, assertReachableFlag, false, "exit non-zero if no traffic (or not all of the traffic specified by --protocol, --port or --traffic) is allowed from source to destination")
 	rootCmd.Flags().BoolVar(&assertNotReachable, assertNotReachableFlag, false, "exit non-zero if any traffic can reach destination from source")
+	rootCmd.Flags().StringArrayVar(&fromIdentifiers, fromFlag, nil, "source subject, tag selector (e.g. 'tag:Role=web') or name glob (e.g. 'web-*'); repeatable")
+	rootCmd.Flags().StringArrayVar(&toIdentifiers, toFlag, nil, "destination subject, tag selector or name glob; repeatable")
+	rootCmd.Flags().BoolVar(&outputCSV, csvFlag, false, "output as CSV (same as --format csv)")
+	rootCmd.Flags().StringVar(&protocol, protocolFlag, "", "consider only traffic of this IP protocol (e.g. 'tcp', 'udp', 'icmp' or a protocol number)")
+	rootCmd.Flags().StringArrayVar(&ports, portFlag, nil, "consider only this port or port range (e.g. '5432' or '8000-8100') of the --protocol; repeatable")
+	rootCmd.Flags().StringVar(&traffic, trafficFlag, "", "consider only this traffic (e.g. 'tcp/443,udp/53,icmp' or 'tcp:22,443; icmp:echo-request')")
+	rootCmd.Flags().StringVar(&snapshotPath, snapshotFlag, "", "analyze AWS resources from a snapshot file (see 'reach snapshot save') instead of the AWS API")
+	rootCmd.Flags().BoolVar(&prefetchVPCs, prefetchVPCsFlag, false, "describe all resources in each relevant VPC up front, using fewer AWS API requests in large accounts")
 }
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/luhring/reach/reach/aws/api"
+	"github.com/luhring/reach/reach/aws/snapshot"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "work with snapshots of AWS resources",
+	Long: `work with snapshots of AWS resources
+A snapshot records the AWS resources that reach uses for analysis, so that an analysis can be run later (using the --snapshot flag) without access to AWS.`,
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:   "save <file>",
+	Short: "save a snapshot of all EC2 instances, load balancers, databases, network interfaces, VPC endpoints and AWS services and their network configuration",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := args[0]
+
+		provider, err := api.NewResourceProvider(api.WithVPCPrefetch()) // a snapshot covers every instance, so it's cheaper to describe each VPC's resources all at once
+		if err != nil {
+			exitWithError(err)
+		}
+
+		s, err := snapshot.New(provider)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		f, err := os.Create(path)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err := s.Write(f); err != nil {
+			_ = f.Close()
+			exitWithError(err)
+		}
+
+		if err := f.Close(); err != nil {
+			exitWithError(err)
+		}
+
+		fmt.Printf("saved snapshot to %s\n", path)
+	},
+}
+
+func init() {
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	rootCmd.AddCommand(snapshotCmd)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+)
+
+// trafficQuery returns the traffic specified by the --protocol, --port and --traffic flags, or nil if none of them were used, in which case all traffic is considered.
+func trafficQuery() (*reach.TrafficContent, error) {
+	var queries []reach.TrafficContent
+
+	if len(ports) > 0 && protocol == "" {
+		return nil, fmt.Errorf("--%s requires --%s", portFlag, protocolFlag)
+	}
+
+	if protocol != "" {
+		query, err := reach.NewTrafficQuery(protocol, ports)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+
+	if traffic != "" {
+		query, err := reach.ParseTrafficQuery(traffic)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+	}
+
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	query, err := reach.NewTrafficContentFromMergingMultiple(queries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &query, nil
+}
+
+// printTrafficQuery notes which traffic the analysis is limited to, if any.
+func printTrafficQuery(query *reach.TrafficContent) {
+	if query == nil {
+		return
+	}
+
+	fmt.Printf("considering only: %s\n\n", query.Summary())
+}
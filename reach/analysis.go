@@ -2,7 +2,6 @@ package reach
 
 import (
 	"encoding/json"
-	"log"
 )
 
 // Analysis is the central structure of a Reach analysis. It describes what subjects were analyzed, what resources were retrieved, and a collection of network vectors between all source-to-destination pairings of subjects.
@@ -10,6 +9,7 @@ type Analysis struct {
 	Subjects       []*Subject
 	Resources      *ResourceCollection
 	NetworkVectors []NetworkVector
+	TrafficQuery   *TrafficContent `json:"TrafficQuery,omitempty"` // if set, the only traffic considered by the analysis
 }
 
 // NewAnalysis simply creates a new Analysis struct.
@@ -21,13 +21,13 @@ func NewAnalysis(subjects []*Subject, resources *ResourceCollection, networkVect
 	}
 }
 
-// ToJSON outputs the Analysis as a JSON string.
-func (a *Analysis) ToJSON() string {
+// ToJSON outputs the Analysis as a JSON string, using the versioned representation described by AnalysisDocument.
+func (a *Analysis) ToJSON() (string, error) {
 	b, err := json.MarshalIndent(a, "", "  ")
 	if err != nil {
-		log.Fatal(err)
+		return "", err
 	}
-	return string(b)
+	return string(b), nil
 }
 
 // MergedTraffic gets the TrafficContent results of each of the analysis's network vectors and returns them as a merged TrafficContent.
@@ -66,8 +66,24 @@ func (a *Analysis) MergedReturnTraffic() (TrafficContent, error) {
 	return result, nil
 }
 
-// PassesAssertReachable determines if the analysis implies the source can reach the destination over at least one protocol whose return path is unobstructed.
+// PassesAssertReachable determines if the analysis implies the source can reach the destination over at least one protocol whose return path is unobstructed. If the analysis has a traffic query, all of the query's traffic must be able to reach the destination.
 func (a Analysis) PassesAssertReachable() bool {
+	if a.TrafficQuery != nil {
+		forwardTraffic, err := a.MergedTraffic()
+		if err != nil {
+			return false
+		}
+
+		if a.TrafficQuery.All() && !forwardTraffic.All() {
+			return false
+		}
+
+		missingTraffic, err := a.TrafficQuery.Subtract(forwardTraffic)
+		if err != nil || !missingTraffic.None() {
+			return false
+		}
+	}
+
 	forwardTrafficCanReach := false
 
 	// For each vector, see if there is an obstructed path
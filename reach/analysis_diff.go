@@ -0,0 +1,396 @@
+package reach
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mgutz/ansi"
+
+	"github.com/luhring/reach/reach/helper"
+)
+
+// An AnalysisDiff describes how the network traffic allowed between the same subjects differs from one analysis (e.g. of a snapshot taken before a configuration change) to another (e.g. of the live configuration).
+type AnalysisDiff struct {
+	Vectors      []VectorDiff
+	TrafficQuery *TrafficContent `json:"TrafficQuery,omitempty"` // if set, the only forward traffic considered by the diff (see Analysis.TrafficQuery)
+}
+
+// A VectorDiff describes how the traffic allowed along one network vector changed, and which of the vector's factors caused the change. Network vectors are matched across the two analyses by their source and destination network points. A network vector that only exists in one of the analyses is compared with a vector that allows no traffic.
+type VectorDiff struct {
+	Source       NetworkPoint
+	Destination  NetworkPoint
+	Gained       TrafficContent
+	Lost         TrafficContent
+	ReturnGained TrafficContent
+	ReturnLost   TrafficContent
+	Factors      []FactorDiff
+	OnlyInBefore bool `json:"OnlyInBefore,omitempty"`
+	OnlyInAfter  bool `json:"OnlyInAfter,omitempty"`
+}
+
+// A FactorDiff describes how the traffic allowed by one factor changed. Factors are matched across the two analyses by the side of the network vector they apply to, their kind and their resource.
+type FactorDiff struct {
+	Role         SubjectRole
+	Kind         string
+	Resource     ResourceReference
+	Gained       TrafficContent
+	Lost         TrafficContent
+	ReturnGained TrafficContent
+	ReturnLost   TrafficContent
+	Rules        []RuleDiff `json:"Rules,omitempty"`
+	Before       *Factor    `json:"-"`
+	After        *Factor    `json:"-"`
+}
+
+// A RuleDiff describes how the traffic that a single rule (e.g. a security group rule) contributed to a factor changed. Rule diffs are specific to the domain of the factor (see aws.AttributeRuleDiffs).
+type RuleDiff struct {
+	Description string
+	Gained      TrafficContent
+	Lost        TrafficContent
+}
+
+// NewAnalysisDiff compares the before and after analyses, which should be analyses of the same subjects with the same traffic query, and returns the network vectors whose traffic or factors changed. If the analyses have a traffic query, changes to forward traffic outside of the query are ignored, for both network vectors and factors. As with the analyses themselves, return traffic isn't compared with the query.
+func NewAnalysisDiff(before, after *Analysis) (*AnalysisDiff, error) {
+	diff := &AnalysisDiff{TrafficQuery: after.TrafficQuery}
+	if diff.TrafficQuery == nil {
+		diff.TrafficQuery = before.TrafficQuery
+	}
+
+	afterVectors := make(map[string]*NetworkVector)
+	var afterKeys []string
+
+	for i := range after.NetworkVectors {
+		v := &after.NetworkVectors[i]
+		key := vectorKey(*v)
+		afterVectors[key] = v
+		afterKeys = append(afterKeys, key)
+	}
+
+	matched := make(map[string]bool)
+
+	for i := range before.NetworkVectors {
+		v := &before.NetworkVectors[i]
+		key := vectorKey(*v)
+		matched[key] = true
+
+		vd, err := diff.newVectorDiff(v, afterVectors[key])
+		if err != nil {
+			return nil, err
+		}
+
+		if vd.Changed() {
+			diff.Vectors = append(diff.Vectors, vd)
+		}
+	}
+
+	for _, key := range afterKeys {
+		if matched[key] {
+			continue
+		}
+
+		vd, err := diff.newVectorDiff(nil, afterVectors[key])
+		if err != nil {
+			return nil, err
+		}
+
+		if vd.Changed() {
+			diff.Vectors = append(diff.Vectors, vd)
+		}
+	}
+
+	return diff, nil
+}
+
+// ScopeToQuery returns the part of the given forward traffic that's within the diff's traffic query, or all of the given traffic if the diff doesn't have a traffic query.
+func (d AnalysisDiff) ScopeToQuery(traffic TrafficContent) (TrafficContent, error) {
+	if d.TrafficQuery == nil {
+		return traffic, nil
+	}
+
+	return traffic.Intersect(*d.TrafficQuery)
+}
+
+// Changed returns a boolean indicating whether or not any network vector changed.
+func (d AnalysisDiff) Changed() bool {
+	return len(d.Vectors) > 0
+}
+
+// Changed returns a boolean indicating whether or not the vector's traffic or any of its factors changed.
+func (vd VectorDiff) Changed() bool {
+	return vd.TrafficChanged() || len(vd.Factors) > 0
+}
+
+// TrafficChanged returns a boolean indicating whether or not the traffic allowed along the vector (in either direction) changed.
+func (vd VectorDiff) TrafficChanged() bool {
+	return !vd.Gained.None() || !vd.Lost.None() || !vd.ReturnGained.None() || !vd.ReturnLost.None()
+}
+
+// String returns the text representation of the AnalysisDiff, listing the traffic gained (+) and lost (-) for each changed network vector, and the factors (and their rules) that account for the change.
+func (d AnalysisDiff) String() string {
+	if !d.Changed() {
+		return "no changes in network traffic allowed from source to destination\n"
+	}
+
+	var sections []string
+
+	for _, vd := range d.Vectors {
+		sections = append(sections, vd.String())
+	}
+
+	return strings.Join(sections, "\n")
+}
+
+// String returns the text representation of the VectorDiff.
+func (vd VectorDiff) String() string {
+	output := fmt.Sprintf("* source network point: %s\n* destination network point: %s\n", vd.Source.String(), vd.Destination.String())
+
+	switch {
+	case vd.OnlyInBefore:
+		output += "* network vector no longer exists\n"
+	case vd.OnlyInAfter:
+		output += "* network vector is new\n"
+	}
+
+	output += "\n"
+
+	if vd.TrafficChanged() {
+		output += "network traffic allowed from source to destination:\n"
+		output += helper.Indent(changesString(vd.Gained, vd.Lost), 2)
+
+		if !vd.ReturnGained.None() || !vd.ReturnLost.None() {
+			output += "network traffic allowed to return from destination to source:\n"
+			output += helper.Indent(changesString(vd.ReturnGained, vd.ReturnLost), 2)
+		}
+	} else {
+		output += "no change in network traffic allowed (other factors still determine the result)\n"
+	}
+
+	if len(vd.Factors) > 0 {
+		output += "\ncaused by changes to:\n"
+
+		for _, fd := range vd.Factors {
+			output += helper.Indent(fd.String(), 2)
+		}
+	}
+
+	return output
+}
+
+// String returns the text representation of the FactorDiff.
+func (fd FactorDiff) String() string {
+	output := fmt.Sprintf("- %s (%s, %s %s)\n", fd.Kind, fd.Role, fd.Resource.Kind, fd.Resource.ID)
+
+	changes := changesString(fd.Gained, fd.Lost)
+	if !fd.ReturnGained.None() || !fd.ReturnLost.None() {
+		changes += "return traffic:\n" + helper.Indent(changesString(fd.ReturnGained, fd.ReturnLost), 2)
+	}
+	output += helper.Indent(changes, 4)
+
+	for _, rule := range fd.Rules {
+		output += helper.Indent(fmt.Sprintf("- %s\n", rule.Description), 4)
+		output += helper.Indent(changesString(rule.Gained, rule.Lost), 6)
+	}
+
+	return output
+}
+
+// changesString lists gained traffic (prefixed with "+") and lost traffic (prefixed with "-"), one protocol per line.
+func changesString(gained, lost TrafficContent) string {
+	output := ""
+
+	if !gained.None() {
+		for _, line := range strings.Split(gained.Summary(), "; ") {
+			output += ansi.Color("+ "+line, "green") + "\n"
+		}
+	}
+
+	if !lost.None() {
+		for _, line := range strings.Split(lost.Summary(), "; ") {
+			output += ansi.Color("- "+line, "red") + "\n"
+		}
+	}
+
+	if output == "" {
+		output = "(no change)\n"
+	}
+
+	return output
+}
+
+func (d AnalysisDiff) newVectorDiff(before, after *NetworkVector) (VectorDiff, error) {
+	vd := VectorDiff{
+		OnlyInBefore: after == nil,
+		OnlyInAfter:  before == nil,
+	}
+
+	var err error
+
+	if after != nil {
+		vd.Source, vd.Destination = after.Source, after.Destination
+	} else {
+		vd.Source, vd.Destination = before.Source, before.Destination
+	}
+
+	vd.Gained, vd.Lost, err = d.forwardTrafficChanges(vectorTraffic(before), vectorTraffic(after))
+	if err != nil {
+		return VectorDiff{}, err
+	}
+
+	vd.ReturnGained, vd.ReturnLost, err = trafficChanges(vectorReturnTraffic(before), vectorReturnTraffic(after))
+	if err != nil {
+		return VectorDiff{}, err
+	}
+
+	var beforeSource, beforeDestination, afterSource, afterDestination []Factor
+	if before != nil {
+		beforeSource, beforeDestination = before.Source.Factors, before.Destination.Factors
+	}
+	if after != nil {
+		afterSource, afterDestination = after.Source.Factors, after.Destination.Factors
+	}
+
+	sourceFactors, err := d.factorDiffs(SubjectRoleSource, beforeSource, afterSource)
+	if err != nil {
+		return VectorDiff{}, err
+	}
+
+	destinationFactors, err := d.factorDiffs(SubjectRoleDestination, beforeDestination, afterDestination)
+	if err != nil {
+		return VectorDiff{}, err
+	}
+
+	vd.Factors = append(sourceFactors, destinationFactors...)
+
+	return vd, nil
+}
+
+// factorDiffs returns a FactorDiff for each factor of a network point whose traffic changed. A factor that only exists on one side is compared with a factor that allows no traffic.
+func (d AnalysisDiff) factorDiffs(role SubjectRole, before, after []Factor) ([]FactorDiff, error) {
+	var diffs []FactorDiff
+
+	afterFactors := make(map[string]*Factor)
+	for i := range after {
+		afterFactors[factorKey(after[i])] = &after[i]
+	}
+
+	matched := make(map[string]bool)
+
+	appendIfChanged := func(b, a *Factor) error {
+		fd := FactorDiff{
+			Role:   role,
+			Before: b,
+			After:  a,
+		}
+
+		if a != nil {
+			fd.Kind, fd.Resource = a.Kind, a.Resource
+		} else {
+			fd.Kind, fd.Resource = b.Kind, b.Resource
+		}
+
+		var err error
+
+		fd.Gained, fd.Lost, err = d.forwardTrafficChanges(factorTraffic(b), factorTraffic(a))
+		if err != nil {
+			return err
+		}
+
+		fd.ReturnGained, fd.ReturnLost, err = trafficChanges(factorReturnTraffic(b), factorReturnTraffic(a))
+		if err != nil {
+			return err
+		}
+
+		if !fd.Gained.None() || !fd.Lost.None() || !fd.ReturnGained.None() || !fd.ReturnLost.None() {
+			diffs = append(diffs, fd)
+		}
+
+		return nil
+	}
+
+	for i := range before {
+		key := factorKey(before[i])
+		matched[key] = true
+
+		if err := appendIfChanged(&before[i], afterFactors[key]); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range after {
+		if matched[factorKey(after[i])] {
+			continue
+		}
+
+		if err := appendIfChanged(nil, &after[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return diffs, nil
+}
+
+// forwardTrafficChanges returns the changes in forward traffic (see trafficChanges) that are within the diff's traffic query.
+func (d AnalysisDiff) forwardTrafficChanges(before, after TrafficContent) (gained, lost TrafficContent, err error) {
+	before, err = d.ScopeToQuery(before)
+	if err != nil {
+		return TrafficContent{}, TrafficContent{}, err
+	}
+
+	after, err = d.ScopeToQuery(after)
+	if err != nil {
+		return TrafficContent{}, TrafficContent{}, err
+	}
+
+	return trafficChanges(before, after)
+}
+
+// trafficChanges returns the traffic that's in after but not in before (gained), and the traffic that's in before but not in after (lost).
+func trafficChanges(before, after TrafficContent) (gained, lost TrafficContent, err error) {
+	gained, err = after.Subtract(before)
+	if err != nil {
+		return TrafficContent{}, TrafficContent{}, err
+	}
+
+	lost, err = before.Subtract(after)
+	if err != nil {
+		return TrafficContent{}, TrafficContent{}, err
+	}
+
+	return gained, lost, nil
+}
+
+func vectorKey(v NetworkVector) string {
+	return v.Source.String() + " => " + v.Destination.String()
+}
+
+func factorKey(f Factor) string {
+	return fmt.Sprintf("%s/%s/%s/%s", f.Kind, f.Resource.Domain, f.Resource.Kind, f.Resource.ID)
+}
+
+func vectorTraffic(v *NetworkVector) TrafficContent {
+	if v == nil || v.Traffic == nil {
+		return NewTrafficContentForNoTraffic()
+	}
+	return *v.Traffic
+}
+
+func vectorReturnTraffic(v *NetworkVector) TrafficContent {
+	if v == nil || v.ReturnTraffic == nil {
+		return NewTrafficContentForNoTraffic()
+	}
+	return *v.ReturnTraffic
+}
+
+func factorTraffic(f *Factor) TrafficContent {
+	if f == nil {
+		return NewTrafficContentForNoTraffic()
+	}
+	return f.Traffic
+}
+
+func factorReturnTraffic(f *Factor) TrafficContent {
+	if f == nil {
+		return NewTrafficContentForNoTraffic()
+	}
+	return f.ReturnTraffic
+}
@@ -0,0 +1,198 @@
+package reach
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestNewAnalysisDiff(t *testing.T) {
+	tcp := func(port uint16) TrafficContent {
+		ports, err := set.NewPortSetFromRange(port, port)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return NewTrafficContentForPorts(ProtocolTCP, ports)
+	}
+
+	factor := func(kind, id string, traffic TrafficContent) Factor {
+		return Factor{
+			Kind:          kind,
+			Resource:      ResourceReference{Domain: "AWS", Kind: kind, ID: id},
+			Traffic:       traffic,
+			ReturnTraffic: NewTrafficContentForAllTraffic(),
+		}
+	}
+
+	vector := func(sourceIP, destinationIP string, traffic TrafficContent, destinationFactors ...Factor) NetworkVector {
+		returnTraffic := NewTrafficContentForAllTraffic()
+		return NetworkVector{
+			Source:        NetworkPoint{IPAddress: net.ParseIP(sourceIP)},
+			Destination:   NetworkPoint{IPAddress: net.ParseIP(destinationIP), Factors: destinationFactors},
+			Traffic:       &traffic,
+			ReturnTraffic: &returnTraffic,
+		}
+	}
+
+	analysis := func(vectors ...NetworkVector) *Analysis {
+		return &Analysis{NetworkVectors: vectors}
+	}
+
+	parse := func(query string) TrafficContent {
+		traffic, err := ParseTrafficQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return traffic
+	}
+
+	https := vector("10.0.1.10", "10.0.1.20", tcp(443), factor("SecurityGroupRules", "sg-db", tcp(443)))
+	ssh := vector("10.0.1.10", "10.0.1.20", tcp(22), factor("SecurityGroupRules", "sg-db", tcp(22)))
+	other := vector("10.0.1.10", "10.0.1.30", tcp(5432), factor("SecurityGroupRules", "sg-other", tcp(5432)))
+	httpsAndSSH := vector("10.0.1.10", "10.0.1.20", parse("tcp/22,tcp/443"), factor("SecurityGroupRules", "sg-db", parse("tcp/22,tcp/443")))
+	withNACL := vector("10.0.1.10", "10.0.1.20", tcp(443),
+		factor("SecurityGroupRules", "sg-db", tcp(443)),
+		factor("NetworkACLRules", "acl-db", tcp(22)),
+	)
+
+	cases := []struct {
+		name     string
+		before   *Analysis
+		after    *Analysis
+		query    string // applied to both analyses, if not empty
+		expected []string
+	}{
+		{
+			"unchanged",
+			analysis(https, other),
+			analysis(https, other),
+			"",
+			nil,
+		},
+		{
+			"traffic changed",
+			analysis(https),
+			analysis(ssh),
+			"",
+			[]string{
+				"10.0.1.10 => 10.0.1.20: +TCP 22 -TCP 443",
+				"  destination SecurityGroupRules sg-db: +TCP 22 -TCP 443",
+			},
+		},
+		{
+			"vector only in before",
+			analysis(https, other),
+			analysis(https),
+			"",
+			[]string{
+				"10.0.1.10 => 10.0.1.30 (only in before): +(none) -TCP 5432",
+				"  destination SecurityGroupRules sg-other (only in before): +(none) -TCP 5432",
+			},
+		},
+		{
+			"vector only in after",
+			analysis(https),
+			analysis(https, other),
+			"",
+			[]string{
+				"10.0.1.10 => 10.0.1.30 (only in after): +TCP 5432 -(none)",
+				"  destination SecurityGroupRules sg-other (only in after): +TCP 5432 -(none)",
+			},
+		},
+		{
+			"factor only in after",
+			analysis(https),
+			analysis(withNACL),
+			"",
+			[]string{
+				"10.0.1.10 => 10.0.1.20: +(none) -(none)",
+				"  destination NetworkACLRules acl-db (only in after): +TCP 22 -(none)",
+			},
+		},
+		{
+			"factor only in before",
+			analysis(withNACL),
+			analysis(https),
+			"",
+			[]string{
+				"10.0.1.10 => 10.0.1.20: +(none) -(none)",
+				"  destination NetworkACLRules acl-db (only in before): +(none) -TCP 22",
+			},
+		},
+		{
+			"change outside of traffic query",
+			analysis(https),
+			analysis(httpsAndSSH),
+			"tcp/443",
+			nil,
+		},
+		{
+			"change within traffic query",
+			analysis(https),
+			analysis(httpsAndSSH),
+			"tcp/22",
+			[]string{
+				"10.0.1.10 => 10.0.1.20: +TCP 22 -(none)",
+				"  destination SecurityGroupRules sg-db: +TCP 22 -(none)",
+			},
+		},
+		{
+			"change partly within traffic query",
+			analysis(https),
+			analysis(ssh),
+			"tcp/22,udp/53",
+			[]string{
+				"10.0.1.10 => 10.0.1.20: +TCP 22 -(none)",
+				"  destination SecurityGroupRules sg-db: +TCP 22 -(none)",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.query != "" {
+				query := parse(tc.query)
+				before, after := *tc.before, *tc.after
+				before.TrafficQuery, after.TrafficQuery = &query, &query
+				tc.before, tc.after = &before, &after
+			}
+
+			diff, err := NewAnalysisDiff(tc.before, tc.after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual []string
+
+			for _, vd := range diff.Vectors {
+				actual = append(actual, fmt.Sprintf("%s => %s%s: +%s -%s", vd.Source, vd.Destination, onlyInDescription(vd.OnlyInBefore, vd.OnlyInAfter), vd.Gained.Summary(), vd.Lost.Summary()))
+
+				for _, fd := range vd.Factors {
+					actual = append(actual, fmt.Sprintf("  %s %s %s%s: +%s -%s", fd.Role, fd.Kind, fd.Resource.ID, onlyInDescription(fd.After == nil, fd.Before == nil), fd.Gained.Summary(), fd.Lost.Summary()))
+				}
+			}
+
+			if expected, actual := strings.Join(tc.expected, "\n"), strings.Join(actual, "\n"); expected != actual {
+				DiffErrorf(t, "diff", expected, actual)
+			}
+
+			if changed := diff.Changed(); changed != (len(tc.expected) > 0) {
+				t.Errorf("expected Changed() to be %v, but got %v", len(tc.expected) > 0, changed)
+			}
+		})
+	}
+}
+
+func onlyInDescription(onlyInBefore, onlyInAfter bool) string {
+	switch {
+	case onlyInBefore:
+		return " (only in before)"
+	case onlyInAfter:
+		return " (only in after)"
+	default:
+		return ""
+	}
+}
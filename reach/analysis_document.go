@@ -0,0 +1,386 @@
+package reach
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+)
+
+// AnalysisSchemaVersion is the version of the JSON representation of an Analysis (see AnalysisDocument). The version changes whenever a field is removed or its meaning changes, so that consumers can rely on the fields of a given version. Adding a field doesn't change the version.
+const AnalysisSchemaVersion = 1
+
+// An AnalysisDocument is the JSON representation of an Analysis, described by the JSON Schema in schema/analysis.v1.schema.json. Unlike the Analysis itself, each of its types is defined for the purpose of being serialized, so the representation doesn't change when the internals of Reach do.
+type AnalysisDocument struct {
+	SchemaVersion  int                         `json:"schemaVersion"`
+	Subjects       []SubjectDocument           `json:"subjects"`
+	Resources      []ResourceReferenceDocument `json:"resources"`
+	NetworkVectors []NetworkVectorDocument     `json:"networkVectors"`
+	TrafficQuery   *TrafficContent             `json:"trafficQuery,omitempty"`
+}
+
+// A SubjectDocument is the JSON representation of a Subject.
+type SubjectDocument struct {
+	Domain string      `json:"domain"`
+	Kind   string      `json:"kind"`
+	ID     string      `json:"id"`
+	Role   SubjectRole `json:"role"`
+}
+
+// A ResourceReferenceDocument is the JSON representation of a ResourceReference. The document lists the analysis's resources by reference only, since the properties of each kind of resource are internal to its domain and can change at any time.
+type ResourceReferenceDocument struct {
+	Domain string `json:"domain"`
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+}
+
+// A NetworkVectorDocument is the JSON representation of a NetworkVector. The traffic fields are omitted if the network vector wasn't analyzed.
+type NetworkVectorDocument struct {
+	ID                        string               `json:"id"`
+	Source                    NetworkPointDocument `json:"source"`
+	Destination               NetworkPointDocument `json:"destination"`
+	TranslatedSourceIPAddress string               `json:"translatedSourceIPAddress,omitempty"`
+	Traffic                   *TrafficContent      `json:"traffic,omitempty"`
+	ReturnTraffic             *TrafficContent      `json:"returnTraffic,omitempty"`
+}
+
+// A NetworkPointDocument is the JSON representation of a NetworkPoint. The lineage is ordered from the resource closest to the IP address (e.g. a network interface) to the outermost resource (e.g. an EC2 instance).
+type NetworkPointDocument struct {
+	IPAddress string                      `json:"ipAddress"`
+	Range     *IPRangeDocument            `json:"range,omitempty"`
+	Lineage   []ResourceReferenceDocument `json:"lineage"`
+	Factors   []FactorDocument            `json:"factors"`
+}
+
+// An IPRangeDocument is the JSON representation of an IPRange.
+type IPRangeDocument struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+}
+
+// A FactorDocument is the JSON representation of a Factor. The factor's kind-specific properties are represented by its attributes and components (see FactorDetails).
+type FactorDocument struct {
+	Kind          string                    `json:"kind"`
+	Resource      ResourceReferenceDocument `json:"resource"`
+	Traffic       TrafficContent            `json:"traffic"`
+	ReturnTraffic TrafficContent            `json:"returnTraffic"`
+	Attributes    map[string]string         `json:"attributes,omitempty"`
+	Components    []FactorComponentDocument `json:"components,omitempty"`
+}
+
+// A FactorComponentDocument is the JSON representation of a FactorComponent.
+type FactorComponentDocument struct {
+	Resource    ResourceReferenceDocument `json:"resource"`
+	Description string                    `json:"description"`
+	Traffic     TrafficContent            `json:"traffic"`
+}
+
+// FactorDetailer is implemented by the kind-specific properties of a factor (see Factor.Properties), to describe them in the JSON representation of an analysis.
+type FactorDetailer interface {
+	FactorDetails() FactorDetails
+}
+
+// FactorDetails describes the kind-specific properties of a factor: attributes, such as the state of a resource, and components, such as the individual rules that contribute traffic to the factor. The properties of a factor read from JSON (see AnalysisFromJSON) are its FactorDetails.
+type FactorDetails struct {
+	Attributes map[string]string
+	Components []FactorComponent
+}
+
+// FactorDetails returns the details themselves, so that the properties of a factor read from JSON can be written out again.
+func (d FactorDetails) FactorDetails() FactorDetails {
+	return d
+}
+
+// A FactorComponent is one part of a factor, such as a single security group rule, along with the traffic it contributes to the factor.
+type FactorComponent struct {
+	Resource    ResourceReference
+	Description string
+	Traffic     TrafficContent
+}
+
+// Document returns the AnalysisDocument that represents the analysis. Resources are ordered by domain, kind and ID.
+func (a Analysis) Document() AnalysisDocument {
+	doc := AnalysisDocument{
+		SchemaVersion:  AnalysisSchemaVersion,
+		Subjects:       []SubjectDocument{},
+		Resources:      []ResourceReferenceDocument{},
+		NetworkVectors: []NetworkVectorDocument{},
+		TrafficQuery:   a.TrafficQuery,
+	}
+
+	for _, s := range a.Subjects {
+		doc.Subjects = append(doc.Subjects, SubjectDocument{
+			Domain: s.Domain,
+			Kind:   s.Kind,
+			ID:     s.ID,
+			Role:   s.Role,
+		})
+	}
+
+	if a.Resources != nil {
+		entries := a.Resources.entries()
+		sort.Slice(entries, func(i, j int) bool {
+			return entries[i].ref.String() < entries[j].ref.String()
+		})
+
+		for _, e := range entries {
+			doc.Resources = append(doc.Resources, newResourceReferenceDocument(e.ref))
+		}
+	}
+
+	for _, v := range a.NetworkVectors {
+		vd := NetworkVectorDocument{
+			ID:            v.ID,
+			Source:        newNetworkPointDocument(v.Source),
+			Destination:   newNetworkPointDocument(v.Destination),
+			Traffic:       v.Traffic,
+			ReturnTraffic: v.ReturnTraffic,
+		}
+
+		if v.TranslatedSourceIPAddress != nil {
+			vd.TranslatedSourceIPAddress = v.TranslatedSourceIPAddress.String()
+		}
+
+		doc.NetworkVectors = append(doc.NetworkVectors, vd)
+	}
+
+	return doc
+}
+
+// MarshalJSON returns the JSON representation of the Analysis, which is its AnalysisDocument.
+func (a Analysis) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Document())
+}
+
+// UnmarshalJSON parses the JSON representation of an Analysis (see AnalysisFromJSON).
+func (a *Analysis) UnmarshalJSON(data []byte) error {
+	var doc AnalysisDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("unable to parse analysis: %v", err)
+	}
+
+	analysis, err := NewAnalysisFromDocument(doc)
+	if err != nil {
+		return err
+	}
+
+	*a = *analysis
+	return nil
+}
+
+// AnalysisFromJSON parses the JSON representation of an Analysis, as produced by Analysis.ToJSON.
+//
+// The resources of a parsed analysis don't have properties, and the properties of each factor are its FactorDetails, so a parsed analysis can be reported on and compared, but not explained.
+func AnalysisFromJSON(data []byte) (*Analysis, error) {
+	var a Analysis
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+// NewAnalysisFromDocument creates the Analysis that the AnalysisDocument represents. It returns an error if the document has a different schema version than AnalysisSchemaVersion.
+func NewAnalysisFromDocument(doc AnalysisDocument) (*Analysis, error) {
+	if doc.SchemaVersion != AnalysisSchemaVersion {
+		return nil, fmt.Errorf("unable to parse analysis: unsupported schema version %d (expected %d)", doc.SchemaVersion, AnalysisSchemaVersion)
+	}
+
+	var subjects []*Subject
+	for _, s := range doc.Subjects {
+		subjects = append(subjects, &Subject{
+			Domain: s.Domain,
+			Kind:   s.Kind,
+			ID:     s.ID,
+			Role:   s.Role,
+		})
+	}
+
+	rc := NewResourceCollection()
+	for _, r := range doc.Resources {
+		ref := r.reference()
+		rc.Put(ref, Resource{
+			Kind: ref.Kind,
+		})
+	}
+
+	var vectors []NetworkVector
+	for _, vd := range doc.NetworkVectors {
+		source, err := vd.Source.networkPoint()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse analysis: network vector %s: %v", vd.ID, err)
+		}
+
+		destination, err := vd.Destination.networkPoint()
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse analysis: network vector %s: %v", vd.ID, err)
+		}
+
+		v := NetworkVector{
+			ID:            vd.ID,
+			Source:        source,
+			Destination:   destination,
+			Traffic:       vd.Traffic,
+			ReturnTraffic: vd.ReturnTraffic,
+		}
+
+		if vd.TranslatedSourceIPAddress != "" {
+			v.TranslatedSourceIPAddress, err = parseIP(vd.TranslatedSourceIPAddress)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse analysis: network vector %s: %v", vd.ID, err)
+			}
+		}
+
+		vectors = append(vectors, v)
+	}
+
+	analysis := NewAnalysis(subjects, rc, vectors)
+	analysis.TrafficQuery = doc.TrafficQuery
+
+	return analysis, nil
+}
+
+func newResourceReferenceDocument(ref ResourceReference) ResourceReferenceDocument {
+	return ResourceReferenceDocument{
+		Domain: ref.Domain,
+		Kind:   ref.Kind,
+		ID:     ref.ID,
+	}
+}
+
+func (d ResourceReferenceDocument) reference() ResourceReference {
+	return ResourceReference{
+		Domain: d.Domain,
+		Kind:   d.Kind,
+		ID:     d.ID,
+	}
+}
+
+func newNetworkPointDocument(point NetworkPoint) NetworkPointDocument {
+	d := NetworkPointDocument{
+		Lineage: []ResourceReferenceDocument{},
+		Factors: []FactorDocument{},
+	}
+
+	if point.IPAddress != nil {
+		d.IPAddress = point.IPAddress.String()
+	}
+
+	if point.Range != nil {
+		d.Range = &IPRangeDocument{
+			First: point.Range.First.String(),
+			Last:  point.Range.Last.String(),
+		}
+	}
+
+	for _, ref := range point.Lineage {
+		d.Lineage = append(d.Lineage, newResourceReferenceDocument(ref))
+	}
+
+	for _, factor := range point.Factors {
+		d.Factors = append(d.Factors, newFactorDocument(factor))
+	}
+
+	return d
+}
+
+func (d NetworkPointDocument) networkPoint() (NetworkPoint, error) {
+	var point NetworkPoint
+
+	if d.IPAddress != "" {
+		ip, err := parseIP(d.IPAddress)
+		if err != nil {
+			return NetworkPoint{}, err
+		}
+		point.IPAddress = ip
+	}
+
+	if d.Range != nil {
+		first, err := parseIP(d.Range.First)
+		if err != nil {
+			return NetworkPoint{}, err
+		}
+
+		last, err := parseIP(d.Range.Last)
+		if err != nil {
+			return NetworkPoint{}, err
+		}
+
+		r := NewIPRange(first, last)
+		point.Range = &r
+	}
+
+	for _, ref := range d.Lineage {
+		point.Lineage = append(point.Lineage, ref.reference())
+	}
+
+	for _, fd := range d.Factors {
+		point.Factors = append(point.Factors, fd.factor())
+	}
+
+	return point, nil
+}
+
+func newFactorDocument(factor Factor) FactorDocument {
+	d := FactorDocument{
+		Kind:          factor.Kind,
+		Resource:      newResourceReferenceDocument(factor.Resource),
+		Traffic:       factor.Traffic,
+		ReturnTraffic: factor.ReturnTraffic,
+	}
+
+	if detailer, ok := factor.Properties.(FactorDetailer); ok {
+		details := detailer.FactorDetails()
+
+		if len(details.Attributes) > 0 {
+			d.Attributes = details.Attributes
+		}
+
+		for _, c := range details.Components {
+			d.Components = append(d.Components, FactorComponentDocument{
+				Resource:    newResourceReferenceDocument(c.Resource),
+				Description: c.Description,
+				Traffic:     c.Traffic,
+			})
+		}
+	}
+
+	return d
+}
+
+func (d FactorDocument) factor() Factor {
+	factor := Factor{
+		Kind:          d.Kind,
+		Resource:      d.Resource.reference(),
+		Traffic:       d.Traffic,
+		ReturnTraffic: d.ReturnTraffic,
+	}
+
+	if len(d.Attributes) == 0 && len(d.Components) == 0 {
+		return factor
+	}
+
+	details := FactorDetails{
+		Attributes: d.Attributes,
+	}
+
+	for _, c := range d.Components {
+		details.Components = append(details.Components, FactorComponent{
+			Resource:    c.Resource.reference(),
+			Description: c.Description,
+			Traffic:     c.Traffic,
+		})
+	}
+
+	factor.Properties = details
+	return factor
+}
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: '%s'", s)
+	}
+
+	return ip, nil
+}
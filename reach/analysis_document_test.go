@@ -0,0 +1,230 @@
+package reach
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+const testResourceDomain = "test"
+
+// A testWidget is the type of the properties of the "Widget" resources of the test domain, which aren't part of the JSON representation.
+type testWidget struct {
+	Name string `json:"name"`
+}
+
+func TestAnalysisJSON(t *testing.T) {
+	ports, err := set.NewPortSetFromRange(22, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ssh := NewTrafficContentForPorts(ProtocolTCP, ports)
+	all := NewTrafficContentForAllTraffic()
+
+	widget := ResourceReference{Domain: testResourceDomain, Kind: "Widget", ID: "w-app"}
+	firewall := ResourceReference{Domain: testResourceDomain, Kind: "Firewall", ID: "fw-db"}
+
+	resources := NewResourceCollection()
+	resources.Put(widget, Resource{Kind: widget.Kind, Properties: testWidget{Name: "app"}})
+
+	subnetRange := NewIPRange(net.ParseIP("10.0.2.0"), net.ParseIP("10.0.2.255"))
+
+	analysis := &Analysis{
+		Subjects: []*Subject{
+			{Domain: testResourceDomain, Kind: "Widget", ID: "w-app", Role: SubjectRoleSource},
+			{Domain: testResourceDomain, Kind: "Subnet", ID: "subnet-db", Role: SubjectRoleDestination},
+		},
+		Resources: resources,
+		NetworkVectors: []NetworkVector{
+			{
+				ID: "vector-1",
+				Source: NetworkPoint{
+					IPAddress: net.ParseIP("10.0.1.10"),
+					Lineage:   []ResourceReference{widget},
+				},
+				Destination: NetworkPoint{
+					Range: &subnetRange,
+					Factors: []Factor{
+						{
+							Kind:          "FirewallRules",
+							Resource:      firewall,
+							Traffic:       ssh,
+							ReturnTraffic: all,
+							Properties: FactorDetails{
+								Attributes: map[string]string{"state": "enabled"},
+								Components: []FactorComponent{
+									{Resource: firewall, Description: "rule #1 for 10.0.0.0/16", Traffic: ssh},
+								},
+							},
+						},
+					},
+				},
+				TranslatedSourceIPAddress: net.ParseIP("203.0.113.10"),
+				Traffic:                   &ssh,
+				ReturnTraffic:             &all,
+			},
+		},
+		TrafficQuery: &ssh,
+	}
+
+	analysisJSON, err := analysis.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := AnalysisFromJSON([]byte(analysisJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roundTripped, err := parsed.ToJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundTripped != analysisJSON {
+		DiffErrorf(t, "JSON after round trip", analysisJSON, roundTripped)
+	}
+
+	if r := parsed.Resources.Get(widget); r == nil {
+		t.Error("expected parsed analysis to include widget w-app")
+	} else if r.Properties != nil {
+		t.Errorf("expected parsed widget to have no properties, but got %#v", r.Properties)
+	}
+
+	if !parsed.PassesAssertReachable() {
+		t.Error("expected parsed analysis to pass assert reachable")
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal([]byte(analysisJSON), &document); err != nil {
+		t.Fatal(err)
+	}
+
+	if version := document["schemaVersion"]; version != float64(AnalysisSchemaVersion) {
+		t.Errorf("expected schemaVersion %d, but got %v", AnalysisSchemaVersion, version)
+	}
+
+	resourcesJSON, _ := json.Marshal(document["resources"])
+	expectedResources := `[{"domain":"test","id":"w-app","kind":"Widget"}]`
+	if string(resourcesJSON) != expectedResources {
+		DiffErrorf(t, "resources", expectedResources, string(resourcesJSON))
+	}
+
+	destinationFactors := document["networkVectors"].([]interface{})[0].(map[string]interface{})["destination"].(map[string]interface{})["factors"].([]interface{})
+	components, _ := json.Marshal(destinationFactors[0].(map[string]interface{})["components"])
+	expectedComponents := `[{"description":"rule #1 for 10.0.0.0/16","resource":{"domain":"test","id":"fw-db","kind":"Firewall"},"traffic":{"TCP":["22"]}}]`
+	if string(components) != expectedComponents {
+		DiffErrorf(t, "factor components", expectedComponents, string(components))
+	}
+
+	schemaFile, err := ioutil.ReadFile("../schema/analysis.v1.schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal(schemaFile, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	checkSchemaProperties(t, "analysis", document, schema, schema)
+}
+
+func TestAnalysisFromJSONErrors(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         string
+		expectedError string
+	}{
+		{
+			"future schema version",
+			`{"schemaVersion": 2, "subjects": [], "resources": [], "networkVectors": []}`,
+			"unsupported schema version 2 (expected 1)",
+		},
+		{
+			"missing schema version",
+			`{"subjects": [], "resources": [], "networkVectors": []}`,
+			"unsupported schema version 0 (expected 1)",
+		},
+		{
+			"truncated JSON",
+			`{"schemaVersion": 1, "subjects": [`,
+			"unexpected end of JSON input",
+		},
+		{
+			"not an object",
+			`["schemaVersion", 1]`,
+			"unable to parse analysis: json: cannot unmarshal array",
+		},
+		{
+			"schema version of the wrong type",
+			`{"schemaVersion": "1"}`,
+			"unable to parse analysis: json: cannot unmarshal string",
+		},
+		{
+			"invalid IP address",
+			`{"schemaVersion": 1, "networkVectors": [{"id": "vector-1", "source": {"ipAddress": "10.0.1", "lineage": [], "factors": []}, "destination": {"ipAddress": "10.0.1.20", "lineage": [], "factors": []}}]}`,
+			"network vector vector-1: invalid IP address: '10.0.1'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis, err := AnalysisFromJSON([]byte(tc.input))
+			if err == nil {
+				t.Fatalf("expected an error, but got analysis: %+v", analysis)
+			}
+
+			if !strings.Contains(err.Error(), tc.expectedError) {
+				t.Errorf("expected error to contain %q, but got: %v", tc.expectedError, err)
+			}
+		})
+	}
+}
+
+// checkSchemaProperties verifies that every property of the JSON value v is described by the (draft-07) JSON Schema definition, and that v has all of the definition's required properties. It follows references to other definitions and checks arrays and objects recursively.
+func checkSchemaProperties(t *testing.T, path string, v interface{}, definition, schema map[string]interface{}) {
+	t.Helper()
+
+	if ref, ok := definition["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		definition = schema["definitions"].(map[string]interface{})[name].(map[string]interface{})
+	}
+
+	switch value := v.(type) {
+	case []interface{}:
+		if items, ok := definition["items"].(map[string]interface{}); ok {
+			for i, item := range value {
+				checkSchemaProperties(t, fmt.Sprintf("%s[%d]", path, i), item, items, schema)
+			}
+		}
+	case map[string]interface{}:
+		properties, ok := definition["properties"].(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		for key, child := range value {
+			childDefinition, described := properties[key].(map[string]interface{})
+			if !described {
+				t.Errorf("%s has property '%s', which isn't described by the schema", path, key)
+				continue
+			}
+
+			checkSchemaProperties(t, path+"."+key, child, childDefinition, schema)
+		}
+
+		if required, ok := definition["required"].([]interface{}); ok {
+			for _, key := range required {
+				if _, exists := value[key.(string)]; !exists {
+					t.Errorf("%s is missing required property '%s'", path, key)
+				}
+			}
+		}
+	}
+}
@@ -2,7 +2,8 @@ package analyzer
 
 import (
 	"fmt"
-	"log"
+	"net"
+	"runtime"
 
 	"github.com/luhring/reach/reach"
 	"github.com/luhring/reach/reach/aws"
@@ -11,65 +12,205 @@ import (
 
 // Analyzer performs Reach's central network traffic analysis.
 type Analyzer struct {
-	resourceCollection *reach.ResourceCollection
+	resourceCollection  *reach.ResourceCollection
+	provider            aws.ResourceProvider
+	newVectorDiscoverer VectorDiscovererConstructor
+	newVectorAnalyzer   VectorAnalyzerConstructor
+	trafficQuery        *reach.TrafficContent
 }
 
-// New creates a new Analyzer that has a new resource collection.
-func New() *Analyzer {
-	rc := reach.NewResourceCollection()
-	return &Analyzer{
-		resourceCollection: rc,
+// A VectorDiscovererConstructor creates a VectorDiscoverer that discovers network vectors using the resources in the given collection.
+type VectorDiscovererConstructor func(rc *reach.ResourceCollection) reach.VectorDiscoverer
+
+// A VectorAnalyzerConstructor creates a VectorAnalyzer that analyzes network vectors using the resources in the given collection.
+type VectorAnalyzerConstructor func(rc *reach.ResourceCollection) reach.VectorAnalyzer
+
+// An Option configures an Analyzer.
+type Option func(a *Analyzer)
+
+// WithProvider configures the Analyzer to retrieve AWS resources using the given provider. Without this option, the Analyzer uses the AWS API.
+func WithProvider(provider aws.ResourceProvider) Option {
+	return func(a *Analyzer) {
+		a.provider = provider
+	}
+}
+
+// WithVectorDiscoverer configures the Analyzer to discover network vectors using a VectorDiscoverer created by the given constructor. Without this option, the Analyzer uses the AWS-specific VectorDiscoverer.
+func WithVectorDiscoverer(newVectorDiscoverer VectorDiscovererConstructor) Option {
+	return func(a *Analyzer) {
+		a.newVectorDiscoverer = newVectorDiscoverer
+	}
+}
+
+// WithVectorAnalyzer configures the Analyzer to analyze network vectors using a VectorAnalyzer created by the given constructor. Without this option, the Analyzer uses the AWS-specific VectorAnalyzer.
+func WithVectorAnalyzer(newVectorAnalyzer VectorAnalyzerConstructor) Option {
+	return func(a *Analyzer) {
+		a.newVectorAnalyzer = newVectorAnalyzer
+	}
+}
+
+// WithTrafficQuery configures the Analyzer to consider only the given traffic, so that each network vector's forward traffic is intersected with the query. The return traffic isn't intersected with the query, since its ports are the source's ephemeral ports rather than the queried destination ports.
+func WithTrafficQuery(query reach.TrafficContent) Option {
+	return func(a *Analyzer) {
+		a.trafficQuery = &query
+	}
+}
+
+// New creates a new Analyzer that has a new resource collection, configured using any given options.
+func New(options ...Option) *Analyzer {
+	a := &Analyzer{
+		resourceCollection: reach.NewResourceCollection(),
+		newVectorDiscoverer: func(rc *reach.ResourceCollection) reach.VectorDiscoverer {
+			return aws.NewVectorDiscoverer(rc)
+		},
+		newVectorAnalyzer: func(rc *reach.ResourceCollection) reach.VectorAnalyzer {
+			return aws.NewVectorAnalyzer(rc)
+		},
 	}
+
+	for _, option := range options {
+		option(a)
+	}
+
+	return a
 }
 
 func (a *Analyzer) buildResourceCollection(subjects []*reach.Subject, provider aws.ResourceProvider) error { // TODO: Allow passing any number of providers of various domains
-	for _, subject := range subjects {
-		if subject.Role != reach.SubjectRoleNone {
-			switch subject.Domain {
-			case aws.ResourceDomainAWS:
-				switch subject.Kind {
-				case aws.SubjectKindEC2Instance:
-					id := subject.ID
-
-					ec2Instance, err := provider.EC2Instance(id)
-					if err != nil {
-						log.Fatalf("couldn't get resource: %v", err)
-					}
-					a.resourceCollection.Put(reach.ResourceReference{
-						Domain: aws.ResourceDomainAWS,
-						Kind:   aws.ResourceKindEC2Instance,
-						ID:     ec2Instance.ID,
-					}, ec2Instance.ToResource())
-
-					dependencies, err := ec2Instance.Dependencies(provider)
-					if err != nil {
-						return err
-					}
-					a.resourceCollection.Merge(dependencies)
-				default:
-					return fmt.Errorf("unsupported subject kind: '%s'", subject.Kind)
+	return reach.ForEachConcurrently(len(subjects), workers(), func(i int) error {
+		subject := subjects[i]
+
+		if subject.Role == reach.SubjectRoleNone {
+			return nil
+		}
+
+		switch subject.Domain {
+		case aws.ResourceDomainAWS:
+			switch subject.Kind {
+			case aws.SubjectKindEC2Instance:
+				id := subject.ID
+
+				ec2Instance, err := provider.EC2Instance(id)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(reach.ResourceReference{
+					Domain: aws.ResourceDomainAWS,
+					Kind:   aws.ResourceKindEC2Instance,
+					ID:     ec2Instance.ID,
+				}, ec2Instance.ToResource())
+
+				dependencies, err := ec2Instance.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindLoadBalancer:
+				lb, err := provider.LoadBalancer(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(lb.ToResourceReference(), lb.ToResource())
+
+				dependencies, err := lb.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindRDSInstance:
+				db, err := provider.RDSInstance(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(db.ToResourceReference(), db.ToResource())
+
+				dependencies, err := db.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindRDSCluster:
+				cluster, err := provider.RDSCluster(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(cluster.ToResourceReference(), cluster.ToResource())
+
+				dependencies, err := cluster.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindVPCEndpoint:
+				vpce, err := provider.VPCEndpoint(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(vpce.ToResourceReference(), vpce.ToResource())
+
+				dependencies, err := vpce.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindElasticNetworkInterface:
+				eni, err := provider.ElasticNetworkInterface(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(eni.ToResourceReference(), eni.ToResource())
+
+				dependencies, err := eni.Dependencies(provider)
+				if err != nil {
+					return err
 				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindAWSService:
+				service, err := provider.AWSService(subject.ID)
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				a.resourceCollection.Put(service.ToResourceReference(), service.ToResource())
+			case aws.SubjectKindIPAddress:
+				eni, err := provider.ElasticNetworkInterfaceForIPAddress(net.ParseIP(subject.ID))
+				if err != nil {
+					return fmt.Errorf("couldn't get resource: %v", err)
+				}
+				if eni == nil {
+					return nil // the IP address is outside of AWS
+				}
+				a.resourceCollection.Put(eni.ToResourceReference(), eni.ToResource())
+
+				dependencies, err := eni.Dependencies(provider)
+				if err != nil {
+					return err
+				}
+				a.resourceCollection.Merge(dependencies)
+			case aws.SubjectKindIPNetwork, aws.SubjectKindInternet:
+				return nil // the network's ranges are determined from the resources of the other subjects
 			default:
-				return fmt.Errorf("unsupported subject domain: '%s'", subject.Domain)
+				return fmt.Errorf("unsupported subject kind: '%s'", subject.Kind)
 			}
+		default:
+			return fmt.Errorf("unsupported subject domain: '%s'", subject.Domain)
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 // Analyze performs a full analysis of allowed network traffic among the specified subjects.
 func (a *Analyzer) Analyze(subjects ...*reach.Subject) (*reach.Analysis, error) {
-	// TODO: Eventually, this dependency wiring should depend on a passed in config.
-	var provider aws.ResourceProvider = api.NewResourceProvider()
+	err := a.ensureProvider()
+	if err != nil {
+		return nil, err
+	}
 
-	err := a.buildResourceCollection(subjects, provider)
+	err = a.buildResourceCollection(subjects, a.provider)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Eventually, this dependency wiring should depend on a passed in config.
-	var vectorDiscoverer reach.VectorDiscoverer = aws.NewVectorDiscoverer(a.resourceCollection)
+	vectorDiscoverer := a.newVectorDiscoverer(a.resourceCollection)
 
 	networkVectors, err := vectorDiscoverer.Discover(subjects)
 	if err != nil {
@@ -78,32 +219,76 @@ func (a *Analyzer) Analyze(subjects ...*reach.Subject) (*reach.Analysis, error)
 
 	processedNetworkVectors := make([]reach.NetworkVector, len(networkVectors))
 
-	// TODO: Eventually, this dependency wiring should depend on a passed in config.
-	var vectorAnalyzer reach.VectorAnalyzer = aws.NewVectorAnalyzer(a.resourceCollection)
+	vectorAnalyzer := a.newVectorAnalyzer(a.resourceCollection)
 
-	for i, v := range networkVectors {
-		factors, processedVector, err := vectorAnalyzer.Factors(v)
+	// Each vector is analyzed independently, so the vectors are analyzed in parallel.
+	err = reach.ForEachConcurrently(len(networkVectors), workers(), func(i int) error {
+		processedVector, err := analyzeVector(vectorAnalyzer, networkVectors[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		trafficContents := reach.TrafficContentsFromFactors(factors)
-		trafficContent, err := reach.NewTrafficContentFromIntersectingMultiple(trafficContents)
-		if err != nil {
-			return nil, err
+		if a.trafficQuery != nil {
+			traffic, err := processedVector.Traffic.Intersect(*a.trafficQuery)
+			if err != nil {
+				return err
+			}
+			processedVector.Traffic = &traffic
 		}
 
-		returnTrafficContents := reach.ReturnTrafficContentsFromFactors(factors)
-		returnTrafficContent, err := reach.NewTrafficContentFromIntersectingMultiple(returnTrafficContents)
-		if err != nil {
-			return nil, err
-		}
+		processedNetworkVectors[i] = processedVector
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		processedVector.Traffic = &trafficContent
-		processedVector.ReturnTraffic = &returnTrafficContent
+	analysis := reach.NewAnalysis(subjects, a.resourceCollection, processedNetworkVectors)
+	analysis.TrafficQuery = a.trafficQuery
 
-		processedNetworkVectors[i] = processedVector
+	return analysis, nil
+}
+
+// ensureProvider sets up the Analyzer to use the AWS API if it wasn't configured with a provider.
+func (a *Analyzer) ensureProvider() error {
+	if a.provider != nil {
+		return nil
+	}
+
+	provider, err := api.NewResourceProvider()
+	if err != nil {
+		return err
+	}
+	a.provider = provider
+
+	return nil
+}
+
+func analyzeVector(vectorAnalyzer reach.VectorAnalyzer, v reach.NetworkVector) (reach.NetworkVector, error) {
+	factors, processedVector, err := vectorAnalyzer.Factors(v)
+	if err != nil {
+		return reach.NetworkVector{}, err
 	}
 
-	return reach.NewAnalysis(subjects, a.resourceCollection, processedNetworkVectors), nil
+	trafficContents := reach.TrafficContentsFromFactors(factors)
+	trafficContent, err := reach.NewTrafficContentFromIntersectingMultiple(trafficContents)
+	if err != nil {
+		return reach.NetworkVector{}, err
+	}
+
+	returnTrafficContents := reach.ReturnTrafficContentsFromFactors(factors)
+	returnTrafficContent, err := reach.NewTrafficContentFromIntersectingMultiple(returnTrafficContents)
+	if err != nil {
+		return reach.NetworkVector{}, err
+	}
+
+	processedVector.Traffic = &trafficContent
+	processedVector.ReturnTraffic = &returnTrafficContent
+
+	return processedVector, nil
+}
+
+// workers returns the number of goroutines the Analyzer uses for work that can be done in parallel.
+func workers() int {
+	return runtime.GOMAXPROCS(0)
 }
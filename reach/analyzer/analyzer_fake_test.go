@@ -0,0 +1,1123 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/fake"
+	"github.com/luhring/reach/reach/explainer"
+	"github.com/luhring/reach/reach/set"
+)
+
+// A providerBuilder describes AWS resources (as with fake.VPC and fake.Network) and serves them via an in-memory provider.
+type providerBuilder interface {
+	Provider() (aws.ResourceProvider, error)
+}
+
+// analyzeWithFakeProvider analyzes the traffic from the source to the destination (each identified as with aws.NewSubject) using the resources described by the builder.
+func analyzeWithFakeProvider(t *testing.T, builder providerBuilder, source, destination string, options ...Option) *reach.Analysis {
+	t.Helper()
+
+	provider, err := builder.Provider()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sourceSubject, err := aws.NewSubject(source, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sourceSubject.SetRoleToSource()
+
+	destinationSubject, err := aws.NewSubject(destination, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destinationSubject.SetRoleToDestination()
+
+	analysis, err := New(append([]Option{WithProvider(provider)}, options...)...).Analyze(sourceSubject, destinationSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return analysis
+}
+
+// TestAnalyzeWithFakeProvider reproduces the scenarios from TestAnalyze using an in-memory provider instead of resources deployed to AWS, along with scenarios that span several VPCs. Each scenario analyzes the traffic from the subject named "source" to the subject named "destination" (e.g. an instance, a load balancer or a database).
+func TestAnalyzeWithFakeProvider(t *testing.T) {
+	const (
+		subnet1 = "subnet-1"
+		subnet2 = "subnet-2"
+	)
+
+	anywhere := fake.CIDR("0.0.0.0/0")
+	noRules := fake.SecurityGroup("sg-no-rules")
+
+	// withSecurityGroups adds every security group used by the Terraform-based scenarios.
+	withSecurityGroups := func(vpc *fake.VPC, subnetCIDR string) *fake.VPC {
+		return vpc.
+			SecurityGroup("sg-no-rules").
+			SecurityGroup("sg-inbound-allow-all", fake.Inbound(reach.NewTrafficContentForAllTraffic(), anywhere)).
+			SecurityGroup("sg-inbound-allow-esp", fake.Inbound(trafficESP(), anywhere)).
+			SecurityGroup("sg-inbound-allow-https-from-ip", fake.Inbound(trafficHTTPS(), fake.CIDR(subnetCIDR))).
+			SecurityGroup("sg-inbound-allow-postgres-from-sg-no-rules", fake.Inbound(trafficPostgres(), noRules)).
+			SecurityGroup("sg-inbound-allow-ssh", fake.Inbound(trafficSSH(), anywhere)).
+			SecurityGroup("sg-inbound-allow-udp-dns-from-sg-no-rules", fake.Inbound(trafficDNS(), noRules)).
+			SecurityGroup("sg-outbound-allow-all", fake.Outbound(reach.NewTrafficContentForAllTraffic(), anywhere)).
+			SecurityGroup("sg-outbound-allow-all-tcp", fake.Outbound(trafficTCP(), anywhere)).
+			SecurityGroup("sg-outbound-allow-all-udp-to-sg-no-rules", fake.Outbound(trafficUDP(), noRules)).
+			SecurityGroup("sg-outbound-allow-esp", fake.Outbound(trafficESP(), anywhere)).
+			SecurityGroup("sg-outbound-allow-https-to-ip", fake.Outbound(trafficHTTPS(), fake.CIDR(subnetCIDR))).
+			SecurityGroup("sg-outbound-allow-postgres-to-sg-no-rules", fake.Outbound(trafficPostgres(), noRules))
+	}
+
+	singleSubnet := func() *fake.VPC {
+		return withSecurityGroups(fake.NewVPC("10.0.0.0/16").Subnet(subnet1, "10.0.1.0/24"), "10.0.1.0/24")
+	}
+
+	subnetPair := func() *fake.VPC {
+		return withSecurityGroups(fake.NewVPC("10.0.0.0/16").Subnet(subnet1, "10.0.1.0/24").Subnet(subnet2, "10.0.2.0/24"), "10.0.1.0/24")
+	}
+
+	const (
+		sourceAccount      = "111111111111"
+		destinationAccount = "222222222222"
+	)
+
+	// peeredVPCs returns a network where the source VPC routes traffic for the destination VPC to pcx-1, and vice versa, and where the destination instance allows Postgres from the rule target. The peering connection links the source VPC with the destination VPC, or, if peeredWithOther is set, with a third VPC.
+	peeredVPCs := func(status string, peeredWithOther bool, destinationRuleTarget fake.RuleTarget) *fake.Network {
+		sourceVPC := fake.NewVPC("10.0.0.0/16").
+			Owner(sourceAccount).
+			Subnet("subnet-source", "10.0.1.0/24").
+			RouteTable("rtb-source", []string{"subnet-source"}, fake.RouteToVPCPeeringConnection("10.1.0.0/16", "pcx-1")).
+			SecurityGroup("sg-source", fake.Outbound(trafficPostgres(), fake.CIDR("10.1.0.0/16"))).
+			Instance("source", "subnet-source", "sg-source")
+
+		destinationVPC := fake.NewVPC("10.1.0.0/16").
+			Owner(destinationAccount).
+			Subnet("subnet-destination", "10.1.1.0/24").
+			RouteTable("rtb-destination", []string{"subnet-destination"}, fake.RouteToVPCPeeringConnection("10.0.0.0/16", "pcx-1")).
+			SecurityGroup("sg-destination", fake.Inbound(trafficPostgres(), destinationRuleTarget)).
+			Instance("destination", "subnet-destination", "sg-destination")
+
+		otherVPC := fake.NewVPC("10.2.0.0/16").
+			Owner(destinationAccount).
+			Subnet("subnet-other", "10.2.1.0/24")
+
+		peer := destinationVPC
+		if peeredWithOther {
+			peer = otherVPC
+		}
+
+		return fake.NewNetwork(sourceVPC, destinationVPC, otherVPC).VPCPeeringConnection("pcx-1", sourceVPC, peer, status)
+	}
+
+	// transitGatewayVPCs returns a network where the source and destination VPCs route traffic for each other to tgw-1, and where the source VPC is attached to tgw-1. The destination VPC is attached to tgw-1 too, if destinationAttached is set.
+	transitGatewayVPCs := func(destinationAttached bool) *fake.Network {
+		sourceVPC := fake.NewVPC("10.0.0.0/16").
+			Subnet("subnet-source", "10.0.1.0/24").
+			RouteTable("rtb-source", []string{"subnet-source"}, fake.RouteToTransitGateway("10.1.0.0/16", "tgw-1")).
+			SecurityGroup("sg-source", fake.Outbound(trafficPostgres(), fake.CIDR("10.1.0.0/16"))).
+			Instance("source", "subnet-source", "sg-source")
+
+		destinationVPC := fake.NewVPC("10.1.0.0/16").
+			Subnet("subnet-destination", "10.1.1.0/24").
+			RouteTable("rtb-destination", []string{"subnet-destination"}, fake.RouteToTransitGateway("10.0.0.0/16", "tgw-1")).
+			SecurityGroup("sg-destination", fake.Inbound(trafficPostgres(), fake.CIDR("10.0.1.0/24"))).
+			Instance("destination", "subnet-destination", "sg-destination")
+
+		network := fake.NewNetwork(sourceVPC, destinationVPC).
+			TransitGateway("tgw-1").
+			TransitGatewayVPCAttachment("tgw-attach-source", "tgw-1", sourceVPC)
+
+		if destinationAttached {
+			network = network.TransitGatewayVPCAttachment("tgw-attach-destination", "tgw-1", destinationVPC)
+		}
+
+		return network
+	}
+
+	fromSourceCIDR := fake.CIDR("10.0.1.0/24")
+
+	// withLoadBalancerSecurityGroup adds a security group for Application Load Balancers that allows all traffic in both directions.
+	withLoadBalancerSecurityGroup := func(vpc *fake.VPC) *fake.VPC {
+		return vpc.SecurityGroup("sg-alb",
+			fake.Inbound(reach.NewTrafficContentForAllTraffic(), anywhere),
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), anywhere),
+		)
+	}
+
+	cases := []struct {
+		name                   string
+		network                providerBuilder
+		expectedForwardTraffic reach.TrafficContent
+		expectedReturnTraffic  reach.TrafficContent
+	}{
+		{
+			"same subnet: no security group rules",
+			singleSubnet().
+				Instance("source", subnet1, "sg-no-rules").
+				Instance("destination", subnet1, "sg-no-rules"),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same subnet: multiple protocols",
+			singleSubnet().
+				Instance("source", subnet1, "sg-no-rules", "sg-outbound-allow-all-tcp", "sg-outbound-allow-all-udp-to-sg-no-rules", "sg-outbound-allow-esp").
+				Instance("destination", subnet1, "sg-no-rules", "sg-inbound-allow-udp-dns-from-sg-no-rules", "sg-inbound-allow-ssh", "sg-inbound-allow-esp"),
+			trafficAssorted(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same subnet: UDP DNS via SG reference",
+			singleSubnet().
+				Instance("source", subnet1, "sg-no-rules", "sg-outbound-allow-all-udp-to-sg-no-rules").
+				Instance("destination", subnet1, "sg-no-rules", "sg-inbound-allow-udp-dns-from-sg-no-rules"),
+			trafficDNS(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same subnet: HTTPS via two-way IP match",
+			singleSubnet().
+				Instance("source", subnet1, "sg-outbound-allow-https-to-ip").
+				Instance("destination", subnet1, "sg-inbound-allow-https-from-ip"),
+			trafficHTTPS(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same subnet: SSH",
+			singleSubnet().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				Instance("destination", subnet1, "sg-inbound-allow-ssh"),
+			trafficSSH(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same subnet: all traffic",
+			singleSubnet().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				Instance("destination", subnet1, "sg-inbound-allow-all"),
+			reach.NewTrafficContentForAllTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same VPC: all traffic",
+			subnetPair().
+				NetworkACL("acl-all-traffic", []string{subnet1, subnet2},
+					fake.AllowInbound(100, reach.NewTrafficContentForAllTraffic(), "0.0.0.0/0"),
+					fake.AllowOutbound(100, reach.NewTrafficContentForAllTraffic(), "0.0.0.0/0"),
+				).
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				Instance("destination", subnet2, "sg-inbound-allow-all"),
+			reach.NewTrafficContentForAllTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"same VPC: no NACL allow rules",
+			subnetPair().
+				NetworkACL("acl-no-traffic", []string{subnet1, subnet2}).
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				Instance("destination", subnet2, "sg-inbound-allow-all"),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"same VPC: NACL rules don't match SG rules",
+			subnetPair().
+				NetworkACL("acl-all-tcp", []string{subnet1, subnet2},
+					fake.AllowInbound(100, trafficTCP(), "0.0.0.0/0"),
+					fake.AllowOutbound(100, trafficTCP(), "0.0.0.0/0"),
+				).
+				Instance("source", subnet1, "sg-outbound-allow-esp").
+				Instance("destination", subnet2, "sg-inbound-allow-all"),
+			reach.NewTrafficContentForNoTraffic(),
+			trafficTCP(),
+		},
+		{
+			"same VPC: Postgres with tightened rules",
+			subnetPair().
+				NetworkACL("acl-source-tightened-postgres", []string{subnet1},
+					fake.AllowOutbound(100, trafficPostgres(), "10.0.2.0/24"),
+					fake.AllowInbound(100, trafficTCP(), "10.0.2.0/24"),
+				).
+				NetworkACL("acl-destination-tightened-postgres", []string{subnet2},
+					fake.AllowOutbound(100, trafficTCP(), "10.0.1.0/24"),
+					fake.AllowInbound(100, trafficPostgres(), "10.0.1.0/24"),
+				).
+				Instance("source", subnet1, "sg-no-rules", "sg-outbound-allow-postgres-to-sg-no-rules").
+				Instance("destination", subnet2, "sg-no-rules", "sg-inbound-allow-postgres-from-sg-no-rules"),
+			trafficPostgres(),
+			trafficTCP(),
+		},
+		{
+			"VPC peering: active peering connection",
+			peeredVPCs(aws.VPCPeeringConnectionStatusActive, false, fromSourceCIDR),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"VPC peering: pending peering connection",
+			peeredVPCs("pending-acceptance", false, fromSourceCIDR),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"VPC peering: deleted peering connection",
+			peeredVPCs("deleted", false, fromSourceCIDR),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"VPC peering: peering connection between a different pair of VPCs",
+			peeredVPCs(aws.VPCPeeringConnectionStatusActive, true, fromSourceCIDR),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"VPC peering: security group reference in the peer account",
+			peeredVPCs(aws.VPCPeeringConnectionStatusActive, false, fake.SecurityGroupInAccount("sg-source", sourceAccount)),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"VPC peering: security group reference in a mismatched account",
+			peeredVPCs(aws.VPCPeeringConnectionStatusActive, false, fake.SecurityGroupInAccount("sg-source", "333333333333")),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"transit gateway: both VPCs attached",
+			transitGatewayVPCs(true),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"transit gateway: blackhole route to the destination",
+			transitGatewayVPCs(true).TransitGatewayBlackholeRoute("tgw-1", "10.1.1.0/24"),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"transit gateway: destination VPC not attached",
+			transitGatewayVPCs(false),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"load balancer: ALB to registered target",
+			withLoadBalancerSecurityGroup(singleSubnet()).
+				Instance("destination", subnet1, "sg-inbound-allow-all").
+				LoadBalancer("source", aws.LoadBalancerTypeApplication, []string{subnet1}, "sg-alb").
+				Listener("source", "HTTPS", 443).
+				TargetGroup("web", "source", "HTTP", 8080, "destination").
+				HealthCheckPort("web", 8081),
+			trafficTCPPorts(8080, 8081),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"load balancer: instance to ALB",
+			withLoadBalancerSecurityGroup(singleSubnet()).
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				LoadBalancer("destination", aws.LoadBalancerTypeApplication, []string{subnet1}, "sg-alb").
+				Listener("destination", "HTTPS", 443),
+			trafficHTTPS(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"load balancer: instance to NLB, which has no security groups",
+			singleSubnet().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				LoadBalancer("destination", aws.LoadBalancerTypeNetwork, []string{subnet1}).
+				Listener("destination", "TCP", 5432),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"load balancer: NLB to registered target",
+			singleSubnet().
+				Instance("destination", subnet1, "sg-inbound-allow-all").
+				LoadBalancer("source", aws.LoadBalancerTypeNetwork, []string{subnet1}).
+				Listener("source", "TCP", 5432).
+				TargetGroup("db", "source", "TCP", 5432, "destination"),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"database: instance to RDS instance",
+			subnetPair().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				RDSInstance("destination", "postgres", subnet2, 5432, "sg-inbound-allow-all"),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"database: security group doesn't allow DB port",
+			subnetPair().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				RDSInstance("destination", "postgres", subnet2, 5432, "sg-inbound-allow-ssh"),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+		{
+			"database: stopped RDS instance",
+			subnetPair().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				RDSInstance("destination", "postgres", subnet2, 5432, "sg-inbound-allow-all").
+				RDSInstanceStatus("destination", "stopped"),
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"database: instance to every member of an Aurora cluster",
+			subnetPair().
+				Instance("source", subnet1, "sg-outbound-allow-all").
+				RDSInstance("destination-1", "aurora-postgresql", subnet1, 5432, "sg-inbound-allow-all").
+				RDSInstance("destination-2", "aurora-postgresql", subnet2, 5432, "sg-inbound-allow-all").
+				RDSCluster("destination", "destination-1", "destination-2"),
+			trafficPostgres(),
+			reach.NewTrafficContentForAllTraffic(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := analyzeWithFakeProvider(t, tc.network, "source", "destination")
+
+			for _, v := range analysis.NetworkVectors {
+				if forwardTraffic := v.Traffic; forwardTraffic.String() != tc.expectedForwardTraffic.String() {
+					reach.DiffErrorf(t, "forward traffic", tc.expectedForwardTraffic, forwardTraffic)
+				}
+
+				if returnTraffic := v.ReturnTraffic; returnTraffic.String() != tc.expectedReturnTraffic.String() {
+					reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, returnTraffic)
+				}
+			}
+		})
+	}
+}
+
+func trafficUDP() reach.TrafficContent {
+	return reach.NewTrafficContentForPorts(reach.ProtocolUDP, set.NewFullPortSet())
+}
+
+func trafficTCPPorts(ports ...uint16) reach.TrafficContent {
+	var segments []reach.TrafficContent
+	for _, port := range ports {
+		ps, err := set.NewPortSetFromRange(port, port)
+		if err != nil {
+			panic(err)
+		}
+		segments = append(segments, reach.NewTrafficContentForPorts(reach.ProtocolTCP, ps))
+	}
+
+	tc, err := reach.NewTrafficContentFromMergingMultiple(segments)
+	if err != nil {
+		panic(err)
+	}
+
+	return tc
+}
+
+// TestAnalyzeIPSubjectsWithFakeProvider analyzes the traffic from an IP address or CIDR block to the instance named "destination", whose security group refers to CIDR blocks and to a prefix list.
+func TestAnalyzeIPSubjectsWithFakeProvider(t *testing.T) {
+	const subnet1 = "subnet-1"
+
+	vpc := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnet1, "10.0.1.0/24").
+		PrefixList("pl-corp", "corporate-networks",
+			fake.Entry("198.18.0.0/24", "office"),
+			fake.Entry("192.168.5.0/24", "vpn"),
+		).
+		SecurityGroup("sg-web",
+			fake.Inbound(trafficHTTPS(), fake.CIDR("203.0.113.0/24")),
+			fake.Inbound(trafficSSH(), fake.CIDR("10.20.5.0/24")),
+			fake.Inbound(trafficHTTPS(), fake.PrefixList("pl-corp")),
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		Instance("destination", subnet1, "sg-web").
+		Instance("client", subnet1, "sg-web")
+
+	cases := []struct {
+		name            string
+		source          string
+		expectedPoints  []string
+		expectedTraffic []reach.TrafficContent
+		expectedEntry   string // a prefix list entry that the explanation should show
+	}{
+		{
+			"external IP address matched by security group rule",
+			"203.0.113.10",
+			[]string{"203.0.113.10"},
+			[]reach.TrafficContent{trafficHTTPS()},
+			"",
+		},
+		{
+			"external IP address not matched by any rule",
+			"198.51.100.1",
+			[]string{"198.51.100.1"},
+			[]reach.TrafficContent{reach.NewTrafficContentForNoTraffic()},
+			"",
+		},
+		{
+			"IP address of a network interface in the VPC",
+			"10.0.1.11",
+			[]string{"eni-client -> 10.0.1.11"},
+			[]reach.TrafficContent{reach.NewTrafficContentForNoTraffic()},
+			"",
+		},
+		{
+			"CIDR block split into uniform ranges",
+			"10.20.0.0/16",
+			[]string{"10.20.0.0-10.20.4.255", "10.20.5.0/24", "10.20.6.0-10.20.255.255"},
+			[]reach.TrafficContent{reach.NewTrafficContentForNoTraffic(), trafficSSH(), reach.NewTrafficContentForNoTraffic()},
+			"",
+		},
+		{
+			"IP address in prefix list entry",
+			"198.18.0.10",
+			[]string{"198.18.0.10"},
+			[]reach.TrafficContent{trafficHTTPS()},
+			`entry "198.18.0.0/24 (office)"`,
+		},
+		{
+			"CIDR block split at prefix list entry boundaries",
+			"192.168.0.0/16",
+			[]string{"192.168.0.0-192.168.4.255", "192.168.5.0/24", "192.168.6.0-192.168.255.255"},
+			[]reach.TrafficContent{reach.NewTrafficContentForNoTraffic(), trafficHTTPS(), reach.NewTrafficContentForNoTraffic()},
+			`entry "192.168.5.0/24 (vpn)"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := analyzeWithFakeProvider(t, vpc, tc.source, "destination")
+
+			if len(analysis.NetworkVectors) != len(tc.expectedPoints) {
+				t.Fatalf("expected %d network vectors, but got %d", len(tc.expectedPoints), len(analysis.NetworkVectors))
+			}
+
+			for i, v := range analysis.NetworkVectors {
+				if point := v.Source.String(); point != tc.expectedPoints[i] {
+					reach.DiffErrorf(t, "source network point", tc.expectedPoints[i], point)
+				}
+
+				if v.Traffic.String() != tc.expectedTraffic[i].String() {
+					reach.DiffErrorf(t, "forward traffic", tc.expectedTraffic[i], v.Traffic)
+				}
+			}
+
+			if tc.expectedEntry == "" {
+				return
+			}
+
+			explanation, err := explainer.New(*analysis).Explain()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.Contains(explanation, tc.expectedEntry) {
+				t.Errorf("expected explanation to show the matching prefix list entry (%s), but got:\n%s", tc.expectedEntry, explanation)
+			}
+		})
+	}
+}
+
+// TestAnalyzeNetworkVectorsWithFakeProvider analyzes each network vector between subjects that cover several network points, network points outside of AWS (the internet, directly or through a NAT gateway, VPC endpoints and AWS services), or network interfaces that are subjects on their own.
+func TestAnalyzeNetworkVectorsWithFakeProvider(t *testing.T) {
+	const (
+		subnetPublic    = "subnet-public"
+		subnetPrivate   = "subnet-private"
+		subnetApp       = "subnet-app"
+		subnetEndpoints = "subnet-endpoints"
+		natEIP          = "54.0.0.100"
+		s3              = "com.amazonaws.us-east-1.s3"
+	)
+
+	allTraffic := reach.NewTrafficContentForAllTraffic()
+	noTraffic := reach.NewTrafficContentForNoTraffic()
+	natTraffic := trafficNATGateway()
+
+	gatewayVPC := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnetPublic, "10.0.1.0/24").
+		Subnet(subnetPrivate, "10.0.2.0/24").
+		InternetGateway("igw-1").
+		EgressOnlyInternetGateway("eigw-1").
+		RouteTable("rtb-public", []string{subnetPublic},
+			fake.RouteToInternetGateway("0.0.0.0/0", "igw-1"),
+			fake.RouteToInternetGateway("::/0", "igw-1"),
+		).
+		RouteTable("rtb-private", []string{subnetPrivate},
+			fake.RouteToEgressOnlyInternetGateway("::/0", "eigw-1"),
+		).
+		SecurityGroup("sg-web",
+			fake.Inbound(trafficHTTPS(), fake.CIDR("0.0.0.0/0")),
+			fake.Inbound(trafficHTTPS(), fake.CIDR("::/0")),
+			fake.Inbound(trafficSSH(), fake.CIDR("10.0.0.0/8")),
+			fake.Outbound(allTraffic, fake.CIDR("0.0.0.0/0")),
+			fake.Outbound(allTraffic, fake.CIDR("::/0")),
+		).
+		Instance("web", subnetPublic, "sg-web").
+		PublicIPv4Address("eni-web", "54.0.0.10").
+		IPv6Address("eni-web", "2600:1f18::10").
+		Instance("internal", subnetPublic, "sg-web").
+		Instance("worker", subnetPrivate, "sg-web").
+		PublicIPv4Address("eni-worker", "54.0.0.20").
+		IPv6Address("eni-worker", "2600:1f18::20")
+
+	// natVPC returns a VPC where the private subnet routes traffic for the internet to a NAT gateway in the public subnet, which uses the specified routes.
+	natVPC := func(publicRoutes ...fake.Route) *fake.VPC {
+		return fake.NewVPC("10.0.0.0/16").
+			Subnet(subnetPublic, "10.0.1.0/24").
+			Subnet(subnetPrivate, "10.0.2.0/24").
+			InternetGateway("igw-1").
+			NATGateway("nat-1", subnetPublic, natEIP).
+			RouteTable("rtb-public", []string{subnetPublic}, publicRoutes...).
+			RouteTable("rtb-private", []string{subnetPrivate},
+				fake.RouteToNATGateway("0.0.0.0/0", "nat-1"),
+			).
+			SecurityGroup("sg-worker",
+				fake.Inbound(trafficHTTPS(), fake.CIDR("0.0.0.0/0")),
+				fake.Outbound(allTraffic, fake.CIDR("0.0.0.0/0")),
+			).
+			SecurityGroup("sg-web",
+				fake.Inbound(trafficHTTPS(), fake.CIDR(natEIP+"/32")),
+			).
+			Instance("worker", subnetPrivate, "sg-worker").
+			Instance("web", subnetPublic, "sg-web").
+			PublicIPv4Address("eni-web", "54.0.0.10")
+	}
+
+	routeToInternet := fake.RouteToInternetGateway("0.0.0.0/0", "igw-1")
+
+	// endpointsVPC returns a VPC with a gateway endpoint for S3 and interface endpoints for SSM (which allows HTTPS from the VPC) and KMS (which doesn't).
+	endpointsVPC := func() *fake.VPC {
+		return fake.NewVPC("10.0.0.0/16").
+			Subnet(subnetApp, "10.0.1.0/24").
+			Subnet(subnetEndpoints, "10.0.2.0/24").
+			RouteTable("rtb-private", []string{subnetApp, subnetEndpoints}).
+			AWSService(s3, "52.216.0.0/15", "54.231.0.0/16").
+			GatewayVPCEndpoint("vpce-s3", s3, "rtb-private").
+			VPCEndpointPolicy("vpce-s3", `{"Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`).
+			SecurityGroup("sg-app", fake.Outbound(allTraffic, fake.CIDR("0.0.0.0/0"))).
+			SecurityGroup("sg-endpoint", fake.Inbound(trafficHTTPS(), fake.CIDR("10.0.0.0/16"))).
+			SecurityGroup("sg-endpoint-closed", fake.Inbound(trafficHTTPS(), fake.CIDR("192.168.0.0/16"))).
+			InterfaceVPCEndpoint("vpce-ssm", "com.amazonaws.us-east-1.ssm", []string{subnetEndpoints}, "sg-endpoint").
+			InterfaceVPCEndpoint("vpce-closed", "com.amazonaws.us-east-1.kms", []string{subnetEndpoints}, "sg-endpoint-closed").
+			Instance("app", subnetApp, "sg-app")
+	}
+
+	type expectedVector struct {
+		source, destination string
+		traffic             reach.TrafficContent
+		translatedSourceIP  string
+	}
+
+	cases := []struct {
+		name        string
+		network     providerBuilder
+		source      string
+		destination string
+		expected    []expectedVector
+	}{
+		{
+			"internet: internet to instance in public subnet",
+			gatewayVPC,
+			"internet",
+			"web",
+			[]expectedVector{
+				{"0.0.0.0/0", "10.0.1.10", trafficHTTPS(), ""},
+				{"2000::/3", "2600:1f18::10", trafficHTTPS(), ""},
+			},
+		},
+		{
+			"internet: internet to instance without a public IP address",
+			gatewayVPC,
+			"internet",
+			"internal",
+			[]expectedVector{
+				{"0.0.0.0/0", "10.0.1.11", noTraffic, ""},
+			},
+		},
+		{
+			"internet: internet to instance behind an egress-only internet gateway",
+			gatewayVPC,
+			"internet",
+			"worker",
+			[]expectedVector{
+				{"0.0.0.0/0", "10.0.2.10", trafficHTTPS(), ""}, // arrives, but can't return without a route
+				{"2000::/3", "2600:1f18::20", noTraffic, ""},
+			},
+		},
+		{
+			"internet: instance in public subnet to internet",
+			gatewayVPC,
+			"web",
+			"internet",
+			[]expectedVector{
+				{"10.0.1.10", "0.0.0.0/0", allTraffic, ""},
+				{"2600:1f18::10", "2000::/3", allTraffic, ""},
+			},
+		},
+		{
+			"internet: instance without a public IP address to internet",
+			gatewayVPC,
+			"internal",
+			"internet",
+			[]expectedVector{
+				{"10.0.1.11", "0.0.0.0/0", noTraffic, ""},
+			},
+		},
+		{
+			"internet: instance behind an egress-only internet gateway to internet",
+			gatewayVPC,
+			"worker",
+			"internet",
+			[]expectedVector{
+				{"10.0.2.10", "0.0.0.0/0", noTraffic, ""},
+				{"2600:1f18::20", "2000::/3", allTraffic, ""},
+			},
+		},
+		{
+			"network interface: load balancer's network interface to instance",
+			fake.NewVPC("10.0.0.0/16").
+				Subnet(subnetPublic, "10.0.1.0/24").
+				Subnet(subnetPrivate, "10.0.2.0/24").
+				SecurityGroup("sg-lb", fake.Outbound(allTraffic, fake.CIDR("0.0.0.0/0"))).
+				SecurityGroup("sg-app", fake.Inbound(trafficTCPPorts(8080), fake.SecurityGroup("sg-lb"))).
+				LoadBalancer("internal", "application", []string{subnetPublic}, "sg-lb").
+				Instance("app", subnetPrivate, "sg-app"),
+			"eni-internal-" + subnetPublic,
+			"app",
+			[]expectedVector{
+				{"10.0.1.10", "10.0.2.10", trafficTCPPorts(8080), ""},
+			},
+		},
+		{
+			"NAT gateway: instance in private subnet to internet",
+			natVPC(routeToInternet),
+			"worker",
+			"internet",
+			[]expectedVector{
+				{"10.0.2.10", "0.0.0.0/0", natTraffic, natEIP},
+			},
+		},
+		{
+			"NAT gateway: no route from the NAT gateway's subnet to the internet",
+			natVPC(),
+			"worker",
+			"internet",
+			[]expectedVector{
+				{"10.0.2.10", "0.0.0.0/0", noTraffic, natEIP},
+			},
+		},
+		{
+			"NAT gateway: network ACL in the NAT gateway's subnet",
+			natVPC(routeToInternet).
+				NetworkACL("acl-public", []string{subnetPublic},
+					fake.AllowInbound(100, allTraffic, "0.0.0.0/0"),
+					fake.DenyOutbound(90, allTraffic, "203.0.113.0/24"),
+					fake.AllowOutbound(100, trafficTCP(), "0.0.0.0/0"),
+				),
+			"worker",
+			"internet",
+			[]expectedVector{
+				{"10.0.2.10", "0.0.0.0-203.0.112.255", trafficTCP(), natEIP},
+				{"10.0.2.10", "203.0.113.0/24", noTraffic, natEIP},
+				{"10.0.2.10", "203.0.114.0-255.255.255.255", trafficTCP(), natEIP},
+			},
+		},
+		{
+			"NAT gateway: instance in private subnet to public IP address of instance in public subnet",
+			natVPC(routeToInternet),
+			"worker",
+			"54.0.0.10",
+			[]expectedVector{
+				{"10.0.2.10", "54.0.0.10", trafficHTTPS(), natEIP},
+			},
+		},
+		{
+			"NAT gateway: internet to instance in private subnet",
+			natVPC(routeToInternet),
+			"internet",
+			"worker",
+			[]expectedVector{
+				{"0.0.0.0/0", "10.0.2.10", noTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to interface endpoint",
+			endpointsVPC(),
+			"app",
+			"vpce-ssm",
+			[]expectedVector{
+				{"10.0.1.10", "10.0.2.10", trafficHTTPS(), ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to interface endpoint whose security group doesn't allow the instance",
+			endpointsVPC(),
+			"app",
+			"vpce-closed",
+			[]expectedVector{
+				{"10.0.1.10", "10.0.2.11", noTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to interface endpoint that isn't available",
+			endpointsVPC().VPCEndpointState("vpce-ssm", "pendingAcceptance"),
+			"app",
+			"vpce-ssm",
+			[]expectedVector{
+				{"10.0.1.10", "10.0.2.10", noTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: interface endpoint to instance",
+			endpointsVPC(),
+			"vpce-ssm",
+			"app",
+			[]expectedVector{
+				{"10.0.2.10", "10.0.1.10", noTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to AWS service through gateway endpoint",
+			endpointsVPC(),
+			"app",
+			"s3:us-east-1",
+			[]expectedVector{
+				{"10.0.1.10", "52.216.0.0/15", allTraffic, ""},
+				{"10.0.1.10", "54.231.0.0/16", allTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to gateway endpoint",
+			endpointsVPC(),
+			"app",
+			"vpce-s3",
+			[]expectedVector{
+				{"10.0.1.10", "52.216.0.0/15", allTraffic, ""},
+				{"10.0.1.10", "54.231.0.0/16", allTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: instance to AWS service through gateway endpoint that isn't available",
+			endpointsVPC().VPCEndpointState("vpce-s3", "pending"),
+			"app",
+			"s3:us-east-1",
+			[]expectedVector{
+				{"10.0.1.10", "52.216.0.0/15", noTraffic, ""},
+				{"10.0.1.10", "54.231.0.0/16", noTraffic, ""},
+			},
+		},
+		{
+			"VPC endpoint: AWS service to instance",
+			endpointsVPC(),
+			"s3:us-east-1",
+			"app",
+			[]expectedVector{
+				{"52.216.0.0/15", "10.0.1.10", noTraffic, ""},
+				{"54.231.0.0/16", "10.0.1.10", noTraffic, ""},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := analyzeWithFakeProvider(t, tc.network, tc.source, tc.destination)
+
+			if len(analysis.NetworkVectors) != len(tc.expected) {
+				t.Fatalf("expected %d network vectors, but got %d", len(tc.expected), len(analysis.NetworkVectors))
+			}
+
+			for i, v := range analysis.NetworkVectors {
+				expected := tc.expected[i]
+
+				if address := v.Source.AddressString(); address != expected.source {
+					reach.DiffErrorf(t, "source address", expected.source, address)
+				}
+
+				if address := v.Destination.AddressString(); address != expected.destination {
+					reach.DiffErrorf(t, "destination address", expected.destination, address)
+				}
+
+				if v.Traffic.String() != expected.traffic.String() {
+					reach.DiffErrorf(t, "forward traffic", expected.traffic, v.Traffic)
+				}
+
+				translated := ""
+				if v.TranslatedSourceIPAddress != nil {
+					translated = v.TranslatedSourceIPAddress.String()
+				}
+				if translated != expected.translatedSourceIP {
+					reach.DiffErrorf(t, "translated source IP address", expected.translatedSourceIP, translated)
+				}
+			}
+		})
+	}
+}
+
+func trafficNATGateway() reach.TrafficContent {
+	tc, err := reach.NewTrafficContentFromMergingMultiple([]reach.TrafficContent{
+		trafficTCP(),
+		trafficUDP(),
+		reach.NewTrafficContentForICMP(reach.ProtocolICMPv4, set.NewFullICMPSet()),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return tc
+}
+
+func TestAnalyzeMatrixWithFakeProvider(t *testing.T) {
+	const subnet1 = "subnet-1"
+
+	provider, err := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnet1, "10.0.1.0/24").
+		SecurityGroup("sg-web",
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		SecurityGroup("sg-app",
+			fake.Inbound(trafficTCPPorts(8080), fake.SecurityGroup("sg-web")),
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		Instance("web-1", subnet1, "sg-web").
+		Instance("web-2", subnet1, "sg-web").
+		Instance("app-1", subnet1, "sg-app").
+		InstanceTag("web-1", "Role", "web").
+		InstanceTag("web-2", "Role", "web").
+		InstanceTag("app-1", "Role", "app").
+		Provider()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subjects, err := aws.NewSubjects("tag:Role", provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matrix, err := New(WithProvider(provider)).AnalyzeMatrix(subjects, subjects)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matrix.Cells) != 6 {
+		t.Fatalf("expected 6 cells (every pairing except each instance with itself), but got %d", len(matrix.Cells))
+	}
+
+	cases := []struct {
+		source            string
+		destination       string
+		expectedTraffic   reach.TrafficContent
+		expectedInstances []string // the EC2 instances in the cell's analysis resources
+	}{
+		{"i-web-1", "i-web-2", reach.NewTrafficContentForNoTraffic(), []string{"i-web-1", "i-web-2"}},
+		{"i-web-1", "i-app-1", trafficTCPPorts(8080), []string{"i-app-1", "i-web-1"}},
+		{"i-web-2", "i-app-1", trafficTCPPorts(8080), []string{"i-app-1", "i-web-2"}},
+		{"i-app-1", "i-web-2", reach.NewTrafficContentForNoTraffic(), []string{"i-app-1", "i-web-2"}}, // analyzed after every other pairing
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.source+" to "+tc.destination, func(t *testing.T) {
+			var cell *reach.MatrixCell
+			for i := range matrix.Cells {
+				if matrix.Cells[i].Source.ID == tc.source && matrix.Cells[i].Destination.ID == tc.destination {
+					cell = &matrix.Cells[i]
+				}
+			}
+			if cell == nil {
+				t.Fatal("matrix doesn't have a cell for the pairing")
+			}
+
+			if cell.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, cell.Traffic)
+			}
+
+			var instances []string
+			for _, resource := range cell.Analysis.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindEC2Instance) {
+				instances = append(instances, resource.Properties.(aws.EC2Instance).ID)
+			}
+			sort.Strings(instances)
+
+			if fmt.Sprint(instances) != fmt.Sprint(tc.expectedInstances) {
+				reach.DiffErrorf(t, "instances in analysis resources", tc.expectedInstances, instances)
+			}
+		})
+	}
+
+	if matrix.PassesAssertReachable() {
+		t.Error("expected matrix not to pass assert reachable")
+	}
+}
+
+// TestAnalyzeTrafficQueryWithFakeProvider analyzes the traffic from the instance "app" to the instance "db" for each traffic query. The query applies only to the forward traffic: the return traffic's ports are the source's ephemeral ports, not the queried destination ports, so the return traffic is left as is.
+func TestAnalyzeTrafficQueryWithFakeProvider(t *testing.T) {
+	const (
+		subnetApp = "subnet-app"
+		subnetDB  = "subnet-db"
+	)
+
+	ports, err := set.NewPortSetFromRange(1024, 65535)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ephemeralPorts := reach.NewTrafficContentForPorts(reach.ProtocolTCP, ports)
+
+	vpc := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnetApp, "10.0.1.0/24").
+		Subnet(subnetDB, "10.0.2.0/24").
+		NetworkACL("acl-db", []string{subnetDB},
+			fake.AllowInbound(100, reach.NewTrafficContentForAllTraffic(), "10.0.0.0/16"),
+			fake.AllowOutbound(100, ephemeralPorts, "10.0.0.0/16"),
+		).
+		SecurityGroup("sg-app",
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		SecurityGroup("sg-db",
+			fake.Inbound(trafficPostgres(), fake.SecurityGroup("sg-app")),
+			fake.Inbound(trafficHTTPS(), fake.SecurityGroup("sg-app")),
+		).
+		Instance("app", subnetApp, "sg-app").
+		Instance("db", subnetDB, "sg-db")
+
+	cases := []struct {
+		name                  string
+		query                 string
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+	}{
+		{"postgres", "tcp/5432", trafficPostgres(), ephemeralPorts},
+		{"ssh", "tcp/22", reach.NewTrafficContentForNoTraffic(), ephemeralPorts},
+		{"postgres and ssh", "tcp/22,tcp/5432", trafficPostgres(), ephemeralPorts},
+		{"postgres and https", "tcp/443,tcp/5432", trafficTCPPorts(443, 5432), ephemeralPorts},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := reach.ParseTrafficQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			analysis := analyzeWithFakeProvider(t, vpc, "app", "db", WithTrafficQuery(query))
+
+			if analysis.TrafficQuery == nil || analysis.TrafficQuery.String() != query.String() {
+				reach.DiffErrorf(t, "traffic query", query, analysis.TrafficQuery)
+			}
+
+			for _, v := range analysis.NetworkVectors {
+				if v.Traffic.String() != tc.expectedTraffic.String() {
+					reach.DiffErrorf(t, "forward traffic", tc.expectedTraffic, v.Traffic)
+				}
+
+				if v.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+					reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, v.ReturnTraffic)
+				}
+			}
+		})
+	}
+}
+
+// TestAttributeRuleDiffsWithFakeProvider compares the analyses of the traffic from the instance "app" to the instance "db" before and after a change to the security group rules of "db", scoped to each traffic query.
+func TestAttributeRuleDiffsWithFakeProvider(t *testing.T) {
+	const subnet1 = "subnet-1"
+
+	vpc := func(dbRules ...fake.SecurityGroupRule) *fake.VPC {
+		return fake.NewVPC("10.0.0.0/16").
+			Subnet(subnet1, "10.0.1.0/24").
+			SecurityGroup("sg-app",
+				fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+			).
+			SecurityGroup("sg-db", dbRules...).
+			Instance("app", subnet1, "sg-app").
+			Instance("db", subnet1, "sg-db")
+	}
+
+	beforeVPC := vpc(
+		fake.Inbound(trafficPostgres(), fake.SecurityGroup("sg-app")),
+		fake.Inbound(trafficHTTPS(), fake.CIDR("10.0.0.0/16")),
+	)
+	afterVPC := vpc(
+		fake.Inbound(trafficPostgres(), fake.SecurityGroup("sg-app")),
+		fake.Inbound(trafficSSH(), fake.CIDR("0.0.0.0/0")),
+	)
+
+	cases := []struct {
+		name          string
+		query         string // empty for all traffic
+		expectedRules []string
+	}{
+		{
+			"all traffic",
+			"",
+			[]string{
+				`inbound rule in security group sg-db (sg-db) for IP CIDR block 0.0.0.0/0: +TCP 22 -(none)`,
+				`inbound rule in security group sg-db (sg-db) for IP CIDR block 10.0.0.0/16: +(none) -TCP 443`,
+			},
+		},
+		{
+			"query includes one changed rule",
+			"tcp/22",
+			[]string{
+				`inbound rule in security group sg-db (sg-db) for IP CIDR block 0.0.0.0/0: +TCP 22 -(none)`,
+			},
+		},
+		{
+			"query excludes changed rules",
+			"tcp/5432",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var options []Option
+			if tc.query != "" {
+				query, err := reach.ParseTrafficQuery(tc.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				options = append(options, WithTrafficQuery(query))
+			}
+
+			before := analyzeWithFakeProvider(t, beforeVPC, "app", "db", options...)
+			after := analyzeWithFakeProvider(t, afterVPC, "app", "db", options...)
+
+			diff, err := reach.NewAnalysisDiff(before, after)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := aws.AttributeRuleDiffs(diff, before, after); err != nil {
+				t.Fatal(err)
+			}
+
+			var rules []string
+			for _, vd := range diff.Vectors {
+				for _, fd := range vd.Factors {
+					if fd.Kind != aws.FactorKindSecurityGroupRules || fd.Role != reach.SubjectRoleDestination {
+						t.Errorf("expected the change to be attributed to the destination's security group rules, but got: %s (%s)", fd.Kind, fd.Role)
+					}
+
+					for _, rule := range fd.Rules {
+						rules = append(rules, fmt.Sprintf("%s: +%s -%s", rule.Description, rule.Gained.Summary(), rule.Lost.Summary()))
+					}
+				}
+			}
+
+			if strings.Join(rules, "\n") != strings.Join(tc.expectedRules, "\n") {
+				reach.DiffErrorf(t, "rules", strings.Join(tc.expectedRules, "\n"), strings.Join(rules, "\n"))
+			}
+
+			if changed := diff.Changed(); changed != (len(tc.expectedRules) > 0) {
+				t.Errorf("expected Changed() to be %t, but got %t", len(tc.expectedRules) > 0, changed)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+)
+
+// AnalyzeMatrix analyzes the network traffic allowed from each of the given sources to each of the given destinations, and returns the results as a matrix. Each source and destination pairing is analyzed separately, with its own resource collection, except that a subject isn't analyzed against itself. The given subjects' roles are ignored, since a subject can appear as both a source and a destination.
+func (a *Analyzer) AnalyzeMatrix(sources, destinations []*reach.Subject) (*reach.Matrix, error) {
+	// The provider is shared by every pairing, so that resources retrieved for one pairing don't need to be retrieved again for another.
+	if err := a.ensureProvider(); err != nil {
+		return nil, err
+	}
+
+	sources = withRole(sources, reach.SubjectRoleSource)
+	destinations = withRole(destinations, reach.SubjectRoleDestination)
+
+	matrix := reach.NewMatrix(sources, destinations)
+	matrix.TrafficQuery = a.trafficQuery
+
+	for _, source := range sources {
+		for _, destination := range destinations {
+			if reach.SameSubject(source, destination) {
+				continue
+			}
+
+			analysis, err := a.forPairing().Analyze(source, destination)
+			if err != nil {
+				return nil, fmt.Errorf("unable to analyze traffic from %s to %s: %v", source.ID, destination.ID, err)
+			}
+
+			cell, err := reach.NewMatrixCell(source, destination, analysis)
+			if err != nil {
+				return nil, err
+			}
+
+			matrix.Cells = append(matrix.Cells, cell)
+		}
+	}
+
+	return matrix, nil
+}
+
+// forPairing returns a copy of the Analyzer that has a new resource collection, so that the analysis of one source and destination pairing doesn't include the resources of other pairings.
+func (a *Analyzer) forPairing() *Analyzer {
+	pairAnalyzer := *a
+	pairAnalyzer.resourceCollection = reach.NewResourceCollection()
+
+	return &pairAnalyzer
+}
+
+// withRole returns copies of the given subjects that have the given role.
+func withRole(subjects []*reach.Subject, role reach.SubjectRole) []*reach.Subject {
+	result := make([]*reach.Subject, len(subjects))
+
+	for i, subject := range subjects {
+		s := *subject
+		s.Role = role
+		result[i] = &s
+	}
+
+	return result
+}
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// cacheKindAWSServicePrefixList is the cache kind for AWS services keyed by the ID of their prefix list, rather than by their service name.
+const cacheKindAWSServicePrefixList = "AWSServicePrefixList"
+
+// AWSService queries the AWS API for the prefix list of the AWS service with the given service name (e.g. "com.amazonaws.us-east-1.s3").
+func (provider *ResourceProvider) AWSService(name string) (*reachAWS.AWSService, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindAWSService, name); exists {
+		service := cached.(reachAWS.AWSService)
+		return &service, nil
+	}
+
+	services, err := provider.describePrefixLists(&ec2.DescribePrefixListsInput{
+		Filters: []*ec2.Filter{filter("prefix-list-name", name)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(services) == 0 {
+		return nil, fmt.Errorf("AWS API did not return a prefix list for AWS service '%s' (is the service available in the current region?)", name)
+	}
+
+	return &services[0], nil
+}
+
+// AllAWSServices queries the AWS API for all AWS services in the current region that have a prefix list.
+func (provider *ResourceProvider) AllAWSServices() ([]reachAWS.AWSService, error) {
+	services, err := provider.describePrefixLists(&ec2.DescribePrefixListsInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all AWS services: %v", err)
+	}
+
+	return services, nil
+}
+
+// awsServicesForPrefixLists returns the AWS services whose prefix lists have the given IDs, keyed by prefix list ID. IDs that don't belong to an AWS service's prefix list (e.g. customer-managed prefix lists) are left out of the result.
+func (provider *ResourceProvider) awsServicesForPrefixLists(ids []string) (map[string]reachAWS.AWSService, error) {
+	for _, batch := range batches(provider.cache.missing(cacheKindAWSServicePrefixList, ids)) {
+		if _, err := provider.describePrefixLists(&ec2.DescribePrefixListsInput{
+			PrefixListIds: aws.StringSlice(batch),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[string]reachAWS.AWSService)
+
+	for _, id := range ids {
+		if cached, exists := provider.cache.get(cacheKindAWSServicePrefixList, id); exists {
+			result[id] = cached.(reachAWS.AWSService)
+		}
+	}
+
+	return result, nil
+}
+
+// describePrefixLists retrieves every page of AWS service prefix lists matching the input and adds them to the cache, both by service name and by prefix list ID.
+func (provider *ResourceProvider) describePrefixLists(input *ec2.DescribePrefixListsInput) ([]reachAWS.AWSService, error) {
+	var result []reachAWS.AWSService
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribePrefixListsPages(input, func(page *ec2.DescribePrefixListsOutput, _ bool) bool {
+			for _, prefixList := range page.PrefixLists {
+				service := newAWSServiceFromAPI(prefixList)
+				provider.cache.put(reachAWS.ResourceKindAWSService, service.Name, service)
+				provider.cache.put(cacheKindAWSServicePrefixList, service.PrefixListID, service)
+				result = append(result, service)
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func newAWSServiceFromAPI(prefixList *ec2.PrefixList) reachAWS.AWSService {
+	var networks []*net.IPNet
+
+	for _, cidr := range prefixList.Cidrs {
+		if _, network, err := net.ParseCIDR(aws.StringValue(cidr)); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return reachAWS.AWSService{
+		Name:         aws.StringValue(prefixList.PrefixListName),
+		PrefixListID: aws.StringValue(prefixList.PrefixListId),
+		IPNetworks:   networks,
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"sync"
+)
+
+// cache memoizes resources retrieved from the AWS API, so that each resource is described at most once per ResourceProvider. It's safe for concurrent use.
+type cache struct {
+	mu             sync.Mutex
+	resources      map[cacheKey]interface{}
+	prefetchedVPCs map[string]bool
+}
+
+type cacheKey struct {
+	kind string
+	id   string
+}
+
+func newCache() *cache {
+	return &cache{
+		resources:      make(map[cacheKey]interface{}),
+		prefetchedVPCs: make(map[string]bool),
+	}
+}
+
+func (c *cache) get(kind, id string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resource, exists := c.resources[cacheKey{kind, id}]
+	return resource, exists
+}
+
+func (c *cache) put(kind, id string, resource interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resources[cacheKey{kind, id}] = resource
+}
+
+// missing returns the subset of ids for which the cache has no resource of the given kind, without duplicates.
+func (c *cache) missing(kind string, ids []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, exists := c.resources[cacheKey{kind, id}]; !exists {
+			result = append(result, id)
+		}
+	}
+
+	return result
+}
+
+// claimVPCPrefetch reports whether the caller should prefetch the VPC with the given ID, and if so, records that the VPC has been prefetched.
+func (c *cache) claimVPCPrefetch(vpcID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.prefetchedVPCs[vpcID] {
+		return false
+	}
+
+	c.prefetchedVPCs[vpcID] = true
+	return true
+}
@@ -11,47 +11,69 @@ import (
 
 // EC2Instance queries the AWS API for an EC2 instance matching the given ID.
 func (provider *ResourceProvider) EC2Instance(id string) (*reachAWS.EC2Instance, error) {
-	input := &ec2.DescribeInstancesInput{
-		InstanceIds: []*string{
-			aws.String(id),
-		},
-	}
-	result, err := provider.ec2.DescribeInstances(input)
+	instances, err := provider.EC2Instances(id)
 	if err != nil {
 		return nil, err
 	}
 
-	instances, err := extractEC2Instances(result.Reservations)
+	return &instances[0], nil
+}
+
+// EC2Instances queries the AWS API for the EC2 instances matching the given IDs, describing any instances not already retrieved in batches.
+func (provider *ResourceProvider) EC2Instances(ids ...string) ([]reachAWS.EC2Instance, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindEC2Instance, "EC2 instance", ids, func(batch []string) error {
+		_, err := provider.describeEC2Instances(&ec2.DescribeInstancesInput{
+			InstanceIds: aws.StringSlice(batch),
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if len(instances) == 0 {
-		return nil, fmt.Errorf("AWS API returned no instances for ID '%s'", id)
+	instances := make([]reachAWS.EC2Instance, len(resources))
+	for i, resource := range resources {
+		instances[i] = resource.(reachAWS.EC2Instance)
 	}
 
-	if len(instances) > 1 {
-		return nil, fmt.Errorf("AWS API returned more than one instance for ID '%s'", id)
-	}
-
-	instance := instances[0]
-	return &instance, nil
+	return instances, nil
 }
 
 // AllEC2Instances queries the AWS API for all EC2 instances.
 func (provider *ResourceProvider) AllEC2Instances() ([]reachAWS.EC2Instance, error) {
-	const errFormat = "unable to get all EC2 instances: %v"
+	instances, err := provider.describeEC2Instances(&ec2.DescribeInstancesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all EC2 instances: %v", err)
+	}
 
-	describeInstancesOutput, err := provider.ec2.DescribeInstances(nil)
+	return instances, nil
+}
 
+// describeEC2Instances retrieves every page of instances matching the input and adds them to the cache.
+func (provider *ResourceProvider) describeEC2Instances(input *ec2.DescribeInstancesInput) ([]reachAWS.EC2Instance, error) {
+	var instances []reachAWS.EC2Instance
+	var vpcIDs []string
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeInstancesPages(input, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+			for _, r := range page.Reservations {
+				for _, i := range r.Instances {
+					instance := newEC2InstanceFromAPI(i)
+					provider.cache.put(reachAWS.ResourceKindEC2Instance, instance.ID, instance)
+					instances = append(instances, instance)
+					vpcIDs = append(vpcIDs, aws.StringValue(i.VpcId))
+				}
+			}
+
+			return true
+		})
+	})
 	if err != nil {
-		return nil, fmt.Errorf(errFormat, err)
+		return nil, err
 	}
 
-	reservations := describeInstancesOutput.Reservations
-	instances, err := extractEC2Instances(reservations)
-	if err != nil {
-		return nil, fmt.Errorf(errFormat, err)
+	if err := provider.prefetch(vpcIDs); err != nil {
+		return nil, err
 	}
 
 	return instances, nil
@@ -61,24 +83,12 @@ func newEC2InstanceFromAPI(instance *ec2.Instance) reachAWS.EC2Instance {
 	return reachAWS.EC2Instance{
 		ID:                          aws.StringValue(instance.InstanceId),
 		NameTag:                     nameTag(instance.Tags),
+		Tags:                        tagMap(instance.Tags),
 		State:                       aws.StringValue(instance.State.Name),
 		NetworkInterfaceAttachments: networkInterfaceAttachments(instance),
 	}
 }
 
-func extractEC2Instances(reservations []*ec2.Reservation) ([]reachAWS.EC2Instance, error) {
-	var instances []reachAWS.EC2Instance
-
-	for _, r := range reservations {
-		for _, i := range r.Instances {
-			instance := newEC2InstanceFromAPI(i)
-			instances = append(instances, instance)
-		}
-	}
-
-	return instances, nil
-}
-
 func networkInterfaceAttachments(instance *ec2.Instance) []reachAWS.NetworkInterfaceAttachment {
 	var attachments []reachAWS.NetworkInterfaceAttachment
 
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -9,24 +10,135 @@ import (
 	reachAWS "github.com/luhring/reach/reach/aws"
 )
 
+// AllElasticNetworkInterfaces queries the AWS API for all elastic network interfaces.
+func (provider *ResourceProvider) AllElasticNetworkInterfaces() ([]reachAWS.ElasticNetworkInterface, error) {
+	networkInterfaces, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all elastic network interfaces: %v", err)
+	}
+
+	return networkInterfaces, nil
+}
+
 // ElasticNetworkInterface queries the AWS API for an elastic network interface matching the given ID.
 func (provider *ResourceProvider) ElasticNetworkInterface(id string) (*reachAWS.ElasticNetworkInterface, error) {
-	input := &ec2.DescribeNetworkInterfacesInput{
-		NetworkInterfaceIds: []*string{
-			aws.String(id),
-		},
+	networkInterfaces, err := provider.ElasticNetworkInterfaces(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &networkInterfaces[0], nil
+}
+
+// ElasticNetworkInterfaces queries the AWS API for the elastic network interfaces matching the given IDs, describing any network interfaces not already retrieved in batches.
+func (provider *ResourceProvider) ElasticNetworkInterfaces(ids ...string) ([]reachAWS.ElasticNetworkInterface, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindElasticNetworkInterface, "elastic network interface", ids, func(batch []string) error {
+		_, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+			NetworkInterfaceIds: aws.StringSlice(batch),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	networkInterfaces := make([]reachAWS.ElasticNetworkInterface, len(resources))
+	for i, resource := range resources {
+		networkInterfaces[i] = resource.(reachAWS.ElasticNetworkInterface)
 	}
-	result, err := provider.ec2.DescribeNetworkInterfaces(input)
+
+	return networkInterfaces, nil
+}
+
+// ElasticNetworkInterfaceForIPAddress queries the AWS API for the elastic network interface that has the given private, public or IPv6 address. It returns nil if no network interface has the address.
+func (provider *ResourceProvider) ElasticNetworkInterfaceForIPAddress(ip net.IP) (*reachAWS.ElasticNetworkInterface, error) {
+	networkInterfaces, err := provider.elasticNetworkInterfacesForIPAddress(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(networkInterfaces) == 0 {
+		return nil, nil
+	}
+
+	return &networkInterfaces[0], nil
+}
+
+// ElasticNetworkInterfacesForSecurityGroup queries the AWS API for the elastic network interfaces that use the security group with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForSecurityGroup(id string) ([]reachAWS.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfacesForFilter("group-id", id)
+}
+
+// ElasticNetworkInterfacesForSubnet queries the AWS API for the elastic network interfaces in the subnet with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForSubnet(id string) ([]reachAWS.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfacesForFilter("subnet-id", id)
+}
+
+// ElasticNetworkInterfacesForVPC queries the AWS API for the elastic network interfaces in the VPC with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForVPC(id string) ([]reachAWS.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfacesForFilter("vpc-id", id)
+}
+
+func (provider *ResourceProvider) elasticNetworkInterfacesForFilter(name, value string) ([]reachAWS.ElasticNetworkInterface, error) {
+	networkInterfaces, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{filter(name, value)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get elastic network interfaces with %s '%s': %v", name, value, err)
+	}
+
+	return networkInterfaces, nil
+}
+
+// elasticNetworkInterfacesForIPAddress describes the network interfaces that have the given IP address, using any additional filters given. Each kind of address needs its own filter, so the filters are tried in turn until one of them matches.
+func (provider *ResourceProvider) elasticNetworkInterfacesForIPAddress(ip net.IP, filters ...*ec2.Filter) ([]reachAWS.ElasticNetworkInterface, error) {
+	addressFilters := []string{"addresses.private-ip-address", "association.public-ip"}
+	if ip.To4() == nil {
+		addressFilters = []string{"ipv6-addresses.ipv6-address"}
+	}
+
+	for _, addressFilter := range addressFilters {
+		networkInterfaces, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+			Filters: append([]*ec2.Filter{filter(addressFilter, ip.String())}, filters...),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(networkInterfaces) > 0 {
+			return networkInterfaces, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// describeElasticNetworkInterfaces retrieves every page of network interfaces matching the input and adds them to the cache.
+func (provider *ResourceProvider) describeElasticNetworkInterfaces(input *ec2.DescribeNetworkInterfacesInput) ([]reachAWS.ElasticNetworkInterface, error) {
+	var networkInterfaces []reachAWS.ElasticNetworkInterface
+	var vpcIDs []string
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeNetworkInterfacesPages(input, func(page *ec2.DescribeNetworkInterfacesOutput, _ bool) bool {
+			for _, eni := range page.NetworkInterfaces {
+				networkInterface := newElasticNetworkInterfaceFromAPI(eni)
+				provider.cache.put(reachAWS.ResourceKindElasticNetworkInterface, networkInterface.ID, networkInterface)
+				networkInterfaces = append(networkInterfaces, networkInterface)
+				vpcIDs = append(vpcIDs, networkInterface.VPCID)
+			}
+
+			return true
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.NetworkInterfaces), "elastic network interface", id); err != nil {
+	if err := provider.prefetch(vpcIDs); err != nil {
 		return nil, err
 	}
 
-	networkInterface := newElasticNetworkInterfaceFromAPI(result.NetworkInterfaces[0])
-	return &networkInterface, nil
+	return networkInterfaces, nil
 }
 
 func newElasticNetworkInterfaceFromAPI(eni *ec2.NetworkInterface) reachAWS.ElasticNetworkInterface {
@@ -37,6 +149,7 @@ func newElasticNetworkInterfaceFromAPI(eni *ec2.NetworkInterface) reachAWS.Elast
 	return reachAWS.ElasticNetworkInterface{
 		ID:                   aws.StringValue(eni.NetworkInterfaceId),
 		NameTag:              nameTag(eni.TagSet),
+		OwnerID:              aws.StringValue(eni.OwnerId),
 		SubnetID:             aws.StringValue(eni.SubnetId),
 		VPCID:                aws.StringValue(eni.VpcId),
 		SecurityGroupIDs:     securityGroupIDs(eni.Groups),
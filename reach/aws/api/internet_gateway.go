@@ -0,0 +1,98 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// InternetGateway queries the AWS API for an internet gateway matching the given ID.
+func (provider *ResourceProvider) InternetGateway(id string) (*reachAWS.InternetGateway, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindInternetGateway, id); exists {
+		igw := cached.(reachAWS.InternetGateway)
+		return &igw, nil
+	}
+
+	input := &ec2.DescribeInternetGatewaysInput{
+		InternetGatewayIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeInternetGatewaysOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeInternetGateways(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.InternetGateways), "internet gateway", id); err != nil {
+		return nil, err
+	}
+
+	igw := newInternetGatewayFromAPI(result.InternetGateways[0])
+	provider.cache.put(reachAWS.ResourceKindInternetGateway, id, igw)
+	return &igw, nil
+}
+
+// EgressOnlyInternetGateway queries the AWS API for an egress-only internet gateway matching the given ID.
+func (provider *ResourceProvider) EgressOnlyInternetGateway(id string) (*reachAWS.EgressOnlyInternetGateway, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindEgressOnlyInternetGateway, id); exists {
+		eigw := cached.(reachAWS.EgressOnlyInternetGateway)
+		return &eigw, nil
+	}
+
+	input := &ec2.DescribeEgressOnlyInternetGatewaysInput{
+		EgressOnlyInternetGatewayIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeEgressOnlyInternetGatewaysOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeEgressOnlyInternetGateways(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.EgressOnlyInternetGateways), "egress-only internet gateway", id); err != nil {
+		return nil, err
+	}
+
+	eigw := newEgressOnlyInternetGatewayFromAPI(result.EgressOnlyInternetGateways[0])
+	provider.cache.put(reachAWS.ResourceKindEgressOnlyInternetGateway, id, eigw)
+	return &eigw, nil
+}
+
+func newInternetGatewayFromAPI(igw *ec2.InternetGateway) reachAWS.InternetGateway {
+	return reachAWS.InternetGateway{
+		ID:          aws.StringValue(igw.InternetGatewayId),
+		NameTag:     nameTag(igw.Tags),
+		OwnerID:     aws.StringValue(igw.OwnerId),
+		Attachments: newInternetGatewayAttachmentsFromAPI(igw.Attachments),
+	}
+}
+
+func newEgressOnlyInternetGatewayFromAPI(eigw *ec2.EgressOnlyInternetGateway) reachAWS.EgressOnlyInternetGateway {
+	return reachAWS.EgressOnlyInternetGateway{
+		ID:          aws.StringValue(eigw.EgressOnlyInternetGatewayId),
+		NameTag:     nameTag(eigw.Tags),
+		Attachments: newInternetGatewayAttachmentsFromAPI(eigw.Attachments),
+	}
+}
+
+func newInternetGatewayAttachmentsFromAPI(attachments []*ec2.InternetGatewayAttachment) []reachAWS.InternetGatewayAttachment {
+	var result []reachAWS.InternetGatewayAttachment
+
+	for _, attachment := range attachments {
+		result = append(result, reachAWS.InternetGatewayAttachment{
+			VPCID: aws.StringValue(attachment.VpcId),
+			State: aws.StringValue(attachment.State),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,186 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// LoadBalancer queries the AWS API for an Application or Network Load Balancer matching the given ARN.
+func (provider *ResourceProvider) LoadBalancer(id string) (*reachAWS.LoadBalancer, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindLoadBalancer, id); exists {
+		lb := cached.(reachAWS.LoadBalancer)
+		return &lb, nil
+	}
+
+	loadBalancers, err := provider.describeLoadBalancers(&elbv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []*string{
+			aws.String(id),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(loadBalancers), "load balancer", id); err != nil {
+		return nil, err
+	}
+
+	return &loadBalancers[0], nil
+}
+
+// AllLoadBalancers queries the AWS API for all Application and Network Load Balancers.
+func (provider *ResourceProvider) AllLoadBalancers() ([]reachAWS.LoadBalancer, error) {
+	loadBalancers, err := provider.describeLoadBalancers(&elbv2.DescribeLoadBalancersInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all load balancers: %v", err)
+	}
+
+	return loadBalancers, nil
+}
+
+// describeLoadBalancers retrieves every page of load balancers matching the input, along with each load balancer's listeners, target groups and network interfaces, and adds them to the cache. Classic Load Balancers and Gateway Load Balancers are skipped.
+func (provider *ResourceProvider) describeLoadBalancers(input *elbv2.DescribeLoadBalancersInput) ([]reachAWS.LoadBalancer, error) {
+	var apiLoadBalancers []*elbv2.LoadBalancer
+
+	err := provider.request(func() error {
+		return provider.elbv2.DescribeLoadBalancersPages(input, func(page *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+			apiLoadBalancers = append(apiLoadBalancers, page.LoadBalancers...)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var loadBalancers []reachAWS.LoadBalancer
+
+	for _, apiLB := range apiLoadBalancers {
+		lbType := aws.StringValue(apiLB.Type)
+		if lbType != elbv2.LoadBalancerTypeEnumApplication && lbType != elbv2.LoadBalancerTypeEnumNetwork {
+			continue
+		}
+
+		lb, err := provider.newLoadBalancerFromAPI(apiLB)
+		if err != nil {
+			return nil, err
+		}
+
+		provider.cache.put(reachAWS.ResourceKindLoadBalancer, lb.ID, lb)
+		loadBalancers = append(loadBalancers, lb)
+	}
+
+	return loadBalancers, nil
+}
+
+func (provider *ResourceProvider) newLoadBalancerFromAPI(lb *elbv2.LoadBalancer) (reachAWS.LoadBalancer, error) {
+	arn := aws.StringValue(lb.LoadBalancerArn)
+
+	listeners, err := provider.loadBalancerListeners(arn)
+	if err != nil {
+		return reachAWS.LoadBalancer{}, fmt.Errorf("unable to get listeners for load balancer '%s': %v", arn, err)
+	}
+
+	targetGroupIDs, err := provider.loadBalancerTargetGroupIDs(arn)
+	if err != nil {
+		return reachAWS.LoadBalancer{}, fmt.Errorf("unable to get target groups for load balancer '%s': %v", arn, err)
+	}
+
+	eniIDs, err := provider.loadBalancerElasticNetworkInterfaceIDs(arn)
+	if err != nil {
+		return reachAWS.LoadBalancer{}, fmt.Errorf("unable to get network interfaces for load balancer '%s': %v", arn, err)
+	}
+
+	var state string
+	if lb.State != nil {
+		state = aws.StringValue(lb.State.Code)
+	}
+
+	return reachAWS.LoadBalancer{
+		ID:                         arn,
+		LoadBalancerName:           aws.StringValue(lb.LoadBalancerName),
+		Type:                       aws.StringValue(lb.Type),
+		Scheme:                     aws.StringValue(lb.Scheme),
+		State:                      state,
+		VPCID:                      aws.StringValue(lb.VpcId),
+		SecurityGroupIDs:           aws.StringValueSlice(lb.SecurityGroups),
+		ElasticNetworkInterfaceIDs: eniIDs,
+		Listeners:                  listeners,
+		TargetGroupIDs:             targetGroupIDs,
+	}, nil
+}
+
+func (provider *ResourceProvider) loadBalancerListeners(arn string) ([]reachAWS.LoadBalancerListener, error) {
+	var listeners []reachAWS.LoadBalancerListener
+
+	err := provider.request(func() error {
+		return provider.elbv2.DescribeListenersPages(&elbv2.DescribeListenersInput{
+			LoadBalancerArn: aws.String(arn),
+		}, func(page *elbv2.DescribeListenersOutput, _ bool) bool {
+			for _, listener := range page.Listeners {
+				listeners = append(listeners, reachAWS.LoadBalancerListener{
+					Protocol: aws.StringValue(listener.Protocol),
+					Port:     aws.Int64Value(listener.Port),
+				})
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listeners, nil
+}
+
+func (provider *ResourceProvider) loadBalancerTargetGroupIDs(arn string) ([]string, error) {
+	var ids []string
+
+	err := provider.request(func() error {
+		return provider.elbv2.DescribeTargetGroupsPages(&elbv2.DescribeTargetGroupsInput{
+			LoadBalancerArn: aws.String(arn),
+		}, func(page *elbv2.DescribeTargetGroupsOutput, _ bool) bool {
+			for _, tg := range page.TargetGroups {
+				ids = append(ids, aws.StringValue(tg.TargetGroupArn))
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// loadBalancerElasticNetworkInterfaceIDs finds the network interfaces that AWS manages on behalf of the load balancer. AWS identifies these using the network interface's description, e.g. "ELB app/my-load-balancer/50dc6c495c0c9188".
+func (provider *ResourceProvider) loadBalancerElasticNetworkInterfaceIDs(arn string) ([]string, error) {
+	const resourcePrefix = "loadbalancer/"
+
+	i := strings.Index(arn, resourcePrefix)
+	if i < 0 {
+		return nil, fmt.Errorf("unexpected ARN format: %s", arn)
+	}
+	description := "ELB " + arn[i+len(resourcePrefix):]
+
+	networkInterfaces, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{filter("description", description)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(networkInterfaces))
+	for i, eni := range networkInterfaces {
+		ids[i] = eni.ID
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// NATGateway queries the AWS API for a NAT gateway matching the given ID.
+func (provider *ResourceProvider) NATGateway(id string) (*reachAWS.NATGateway, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindNATGateway, id); exists {
+		nat := cached.(reachAWS.NATGateway)
+		return &nat, nil
+	}
+
+	input := &ec2.DescribeNatGatewaysInput{
+		NatGatewayIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeNatGatewaysOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeNatGateways(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.NatGateways), "NAT gateway", id); err != nil {
+		return nil, err
+	}
+
+	nat := newNATGatewayFromAPI(result.NatGateways[0])
+	provider.cache.put(reachAWS.ResourceKindNATGateway, id, nat)
+	return &nat, nil
+}
+
+func newNATGatewayFromAPI(nat *ec2.NatGateway) reachAWS.NATGateway {
+	result := reachAWS.NATGateway{
+		ID:       aws.StringValue(nat.NatGatewayId),
+		NameTag:  nameTag(nat.Tags),
+		State:    aws.StringValue(nat.State),
+		VPCID:    aws.StringValue(nat.VpcId),
+		SubnetID: aws.StringValue(nat.SubnetId),
+	}
+
+	// A NAT gateway has a single address, which consists of a network interface with a private IP address and, for a public NAT gateway, an Elastic IP address.
+	if len(nat.NatGatewayAddresses) > 0 {
+		address := nat.NatGatewayAddresses[0]
+		result.ElasticNetworkInterfaceID = aws.StringValue(address.NetworkInterfaceId)
+		result.PrivateIPv4Address = net.ParseIP(aws.StringValue(address.PrivateIp))
+		result.PublicIPv4Address = net.ParseIP(aws.StringValue(address.PublicIp))
+	}
+
+	return result
+}
@@ -13,38 +13,89 @@ import (
 
 // NetworkACL queries the AWS API for a network ACL matching the given ID.
 func (provider *ResourceProvider) NetworkACL(id string) (*reachAWS.NetworkACL, error) {
-	input := &ec2.DescribeNetworkAclsInput{
-		NetworkAclIds: []*string{
-			aws.String(id),
-		},
+	networkACLs, err := provider.NetworkACLs(id)
+	if err != nil {
+		return nil, err
 	}
-	result, err := provider.ec2.DescribeNetworkAcls(input)
+
+	return &networkACLs[0], nil
+}
+
+// NetworkACLs queries the AWS API for the network ACLs matching the given IDs, describing any network ACLs not already retrieved in batches.
+func (provider *ResourceProvider) NetworkACLs(ids ...string) ([]reachAWS.NetworkACL, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindNetworkACL, "network ACL", ids, func(batch []string) error {
+		_, err := provider.describeNetworkACLs(&ec2.DescribeNetworkAclsInput{
+			NetworkAclIds: aws.StringSlice(batch),
+		})
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.NetworkAcls), "network ACL", id); err != nil {
+	networkACLs := make([]reachAWS.NetworkACL, len(resources))
+	for i, resource := range resources {
+		networkACLs[i] = resource.(reachAWS.NetworkACL)
+	}
+
+	return networkACLs, nil
+}
+
+// describeNetworkACLs retrieves every page of network ACLs matching the input and adds them to the cache. It returns the API's representation of the network ACLs so that callers can inspect their subnet associations.
+func (provider *ResourceProvider) describeNetworkACLs(input *ec2.DescribeNetworkAclsInput) ([]*ec2.NetworkAcl, error) {
+	var result []*ec2.NetworkAcl
+	var conversionErr error
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeNetworkAclsPages(input, func(page *ec2.DescribeNetworkAclsOutput, _ bool) bool {
+			for _, acl := range page.NetworkAcls {
+				networkACL, err := newNetworkACLFromAPI(acl)
+				if err != nil {
+					conversionErr = err
+					return false
+				}
+
+				provider.cache.put(reachAWS.ResourceKindNetworkACL, networkACL.ID, networkACL)
+				result = append(result, acl)
+			}
+
+			return true
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	networkACL := newNetworkACLFromAPI(result.NetworkAcls[0])
-	return &networkACL, nil
+	if conversionErr != nil {
+		return nil, conversionErr
+	}
+
+	return result, nil
 }
 
-func newNetworkACLFromAPI(networkACL *ec2.NetworkAcl) reachAWS.NetworkACL {
-	inboundRules := inboundNetworkACLRules(networkACL.Entries)
-	outboundRules := outboundNetworkACLRules(networkACL.Entries)
+func newNetworkACLFromAPI(networkACL *ec2.NetworkAcl) (reachAWS.NetworkACL, error) {
+	id := aws.StringValue(networkACL.NetworkAclId)
+
+	inboundRules, err := inboundNetworkACLRules(networkACL.Entries)
+	if err != nil {
+		return reachAWS.NetworkACL{}, fmt.Errorf("unable to use inbound rules for network ACL '%s': %v", id, err)
+	}
+
+	outboundRules, err := outboundNetworkACLRules(networkACL.Entries)
+	if err != nil {
+		return reachAWS.NetworkACL{}, fmt.Errorf("unable to use outbound rules for network ACL '%s': %v", id, err)
+	}
 
 	return reachAWS.NetworkACL{
-		ID:            aws.StringValue(networkACL.NetworkAclId),
+		ID:            id,
 		InboundRules:  inboundRules,
 		OutboundRules: outboundRules,
-	}
+	}, nil
 }
 
-func networkACLRulesForSingleDirection(entries []*ec2.NetworkAclEntry, inbound bool) []reachAWS.NetworkACLRule {
+func networkACLRulesForSingleDirection(entries []*ec2.NetworkAclEntry, inbound bool) ([]reachAWS.NetworkACLRule, error) {
 	if entries == nil {
-		return nil
+		return nil, nil
 	}
 
 	rules := []reachAWS.NetworkACLRule{}
@@ -52,30 +103,35 @@ func networkACLRulesForSingleDirection(entries []*ec2.NetworkAclEntry, inbound b
 	for _, entry := range entries {
 		if entry != nil {
 			if inbound != aws.BoolValue(entry.Egress) {
-				rules = append(rules, networkACLRule(entry))
+				rule, err := networkACLRule(entry)
+				if err != nil {
+					return nil, err
+				}
+
+				rules = append(rules, rule)
 			}
 		}
 	}
 
-	return rules
+	return rules, nil
 }
 
-func inboundNetworkACLRules(entries []*ec2.NetworkAclEntry) []reachAWS.NetworkACLRule {
+func inboundNetworkACLRules(entries []*ec2.NetworkAclEntry) ([]reachAWS.NetworkACLRule, error) {
 	return networkACLRulesForSingleDirection(entries, true)
 }
 
-func outboundNetworkACLRules(entries []*ec2.NetworkAclEntry) []reachAWS.NetworkACLRule {
+func outboundNetworkACLRules(entries []*ec2.NetworkAclEntry) ([]reachAWS.NetworkACLRule, error) {
 	return networkACLRulesForSingleDirection(entries, false)
 }
 
-func networkACLRule(entry *ec2.NetworkAclEntry) reachAWS.NetworkACLRule { // note: this function ignores rule direction (inbound vs. outbound)
+func networkACLRule(entry *ec2.NetworkAclEntry) (reachAWS.NetworkACLRule, error) { // note: this function ignores rule direction (inbound vs. outbound)
 	if entry == nil {
-		return reachAWS.NetworkACLRule{}
+		return reachAWS.NetworkACLRule{}, nil
 	}
 
 	_, targetIPNetwork, err := net.ParseCIDR(aws.StringValue(entry.CidrBlock))
 	if err != nil {
-		return reachAWS.NetworkACLRule{}
+		return reachAWS.NetworkACLRule{}, nil
 	}
 
 	var action reachAWS.NetworkACLRuleAction
@@ -87,9 +143,8 @@ func networkACLRule(entry *ec2.NetworkAclEntry) reachAWS.NetworkACLRule { // not
 	}
 
 	tc, err := newTrafficContentFromAWSNACLEntry(entry)
-
 	if err != nil {
-		panic(err) // TODO: Better error handling
+		return reachAWS.NetworkACLRule{}, fmt.Errorf("unable to use rule number %d: %v", aws.Int64Value(entry.RuleNumber), err)
 	}
 
 	return reachAWS.NetworkACLRule{
@@ -97,7 +152,7 @@ func networkACLRule(entry *ec2.NetworkAclEntry) reachAWS.NetworkACLRule { // not
 		TrafficContent:  tc,
 		TargetIPNetwork: targetIPNetwork,
 		Action:          action,
-	}
+	}, nil
 }
 
 func newTrafficContentFromAWSNACLEntry(entry *ec2.NetworkAclEntry) (reach.TrafficContent, error) { // TODO: BUG! This needs to consider what rules preempt this rule, and handle set subtractions accordingly
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// ManagedPrefixList queries the AWS API for a managed prefix list matching the given ID, along with all of its entries.
+func (provider *ResourceProvider) ManagedPrefixList(id string) (*reachAWS.PrefixList, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindPrefixList, id); exists {
+		prefixList := cached.(reachAWS.PrefixList)
+		return &prefixList, nil
+	}
+
+	input := &ec2.DescribeManagedPrefixListsInput{
+		PrefixListIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeManagedPrefixListsOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeManagedPrefixLists(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.PrefixLists), "managed prefix list", id); err != nil {
+		return nil, err
+	}
+
+	entries, err := provider.managedPrefixListEntries(id)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixList := newPrefixListFromAPI(result.PrefixLists[0], entries)
+	provider.cache.put(reachAWS.ResourceKindPrefixList, id, prefixList)
+	return &prefixList, nil
+}
+
+// managedPrefixListEntries retrieves every page of entries for the managed prefix list with the given ID.
+func (provider *ResourceProvider) managedPrefixListEntries(id string) ([]reachAWS.PrefixListEntry, error) {
+	var entries []reachAWS.PrefixListEntry
+	var conversionErr error
+
+	input := &ec2.GetManagedPrefixListEntriesInput{
+		PrefixListId: aws.String(id),
+	}
+	err := provider.request(func() error {
+		return provider.ec2.GetManagedPrefixListEntriesPages(input, func(page *ec2.GetManagedPrefixListEntriesOutput, _ bool) bool {
+			for _, entry := range page.Entries {
+				_, network, err := net.ParseCIDR(aws.StringValue(entry.Cidr))
+				if err != nil {
+					conversionErr = fmt.Errorf("unable to use entry in managed prefix list '%s': %v", id, err)
+					return false
+				}
+
+				entries = append(entries, reachAWS.PrefixListEntry{
+					CIDR:        network,
+					Description: aws.StringValue(entry.Description),
+				})
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, conversionErr
+}
+
+func newPrefixListFromAPI(prefixList *ec2.ManagedPrefixList, entries []reachAWS.PrefixListEntry) reachAWS.PrefixList {
+	return reachAWS.PrefixList{
+		ID:             aws.StringValue(prefixList.PrefixListId),
+		PrefixListName: aws.StringValue(prefixList.PrefixListName),
+		OwnerID:        aws.StringValue(prefixList.OwnerId),
+		Entries:        entries,
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// RDSCluster queries the AWS API for an Aurora cluster matching the given DB cluster identifier.
+func (provider *ResourceProvider) RDSCluster(id string) (*reachAWS.RDSCluster, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindRDSCluster, id); exists {
+		cluster := cached.(reachAWS.RDSCluster)
+		return &cluster, nil
+	}
+
+	clusters, err := provider.describeRDSClusters(&rds.DescribeDBClustersInput{
+		DBClusterIdentifier: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(clusters), "RDS cluster", id); err != nil {
+		return nil, err
+	}
+
+	return &clusters[0], nil
+}
+
+// AllRDSClusters queries the AWS API for all Aurora clusters.
+func (provider *ResourceProvider) AllRDSClusters() ([]reachAWS.RDSCluster, error) {
+	clusters, err := provider.describeRDSClusters(&rds.DescribeDBClustersInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all RDS clusters: %v", err)
+	}
+
+	return clusters, nil
+}
+
+// describeRDSClusters retrieves every page of DB clusters matching the input and adds them to the cache. Clusters that don't use an Aurora engine (e.g. Neptune and DocumentDB clusters, which are also returned by the RDS API) are skipped.
+func (provider *ResourceProvider) describeRDSClusters(input *rds.DescribeDBClustersInput) ([]reachAWS.RDSCluster, error) {
+	var clusters []reachAWS.RDSCluster
+
+	err := provider.request(func() error {
+		return provider.rds.DescribeDBClustersPages(input, func(page *rds.DescribeDBClustersOutput, _ bool) bool {
+			for _, c := range page.DBClusters {
+				if !strings.HasPrefix(aws.StringValue(c.Engine), "aurora") {
+					continue
+				}
+
+				cluster := newRDSClusterFromAPI(c)
+				provider.cache.put(reachAWS.ResourceKindRDSCluster, cluster.ID, cluster)
+				clusters = append(clusters, cluster)
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusters, nil
+}
+
+func newRDSClusterFromAPI(c *rds.DBCluster) reachAWS.RDSCluster {
+	var memberIDs []string
+	var writerID string
+
+	for _, member := range c.DBClusterMembers {
+		id := aws.StringValue(member.DBInstanceIdentifier)
+		memberIDs = append(memberIDs, id)
+
+		if aws.BoolValue(member.IsClusterWriter) {
+			writerID = id
+		}
+	}
+
+	return reachAWS.RDSCluster{
+		ID:                     aws.StringValue(c.DBClusterIdentifier),
+		ARN:                    aws.StringValue(c.DBClusterArn),
+		Engine:                 aws.StringValue(c.Engine),
+		Status:                 aws.StringValue(c.Status),
+		EndpointAddress:        aws.StringValue(c.Endpoint),
+		ReaderEndpointAddress:  aws.StringValue(c.ReaderEndpoint),
+		Port:                   aws.Int64Value(c.Port),
+		MemberInstanceIDs:      memberIDs,
+		WriterMemberInstanceID: writerID,
+	}
+}
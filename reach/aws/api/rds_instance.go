@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/rds"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// RDSInstance queries the AWS API for an RDS instance matching the given DB instance identifier.
+func (provider *ResourceProvider) RDSInstance(id string) (*reachAWS.RDSInstance, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindRDSInstance, id); exists {
+		db := cached.(reachAWS.RDSInstance)
+		return &db, nil
+	}
+
+	instances, err := provider.describeRDSInstances(&rds.DescribeDBInstancesInput{
+		DBInstanceIdentifier: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(instances), "RDS instance", id); err != nil {
+		return nil, err
+	}
+
+	return &instances[0], nil
+}
+
+// AllRDSInstances queries the AWS API for all RDS instances.
+func (provider *ResourceProvider) AllRDSInstances() ([]reachAWS.RDSInstance, error) {
+	instances, err := provider.describeRDSInstances(&rds.DescribeDBInstancesInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all RDS instances: %v", err)
+	}
+
+	return instances, nil
+}
+
+// describeRDSInstances retrieves every page of RDS instances matching the input, along with each instance's network interfaces, and adds them to the cache.
+func (provider *ResourceProvider) describeRDSInstances(input *rds.DescribeDBInstancesInput) ([]reachAWS.RDSInstance, error) {
+	var apiInstances []*rds.DBInstance
+
+	err := provider.request(func() error {
+		return provider.rds.DescribeDBInstancesPages(input, func(page *rds.DescribeDBInstancesOutput, _ bool) bool {
+			apiInstances = append(apiInstances, page.DBInstances...)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var instances []reachAWS.RDSInstance
+
+	for _, apiInstance := range apiInstances {
+		db := newRDSInstanceFromAPI(apiInstance)
+
+		db.ElasticNetworkInterfaceIDs, err = provider.rdsInstanceElasticNetworkInterfaceIDs(db)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get network interfaces for RDS instance '%s': %v", db.ID, err)
+		}
+
+		provider.cache.put(reachAWS.ResourceKindRDSInstance, db.ID, db)
+		instances = append(instances, db)
+	}
+
+	return instances, nil
+}
+
+func newRDSInstanceFromAPI(db *rds.DBInstance) reachAWS.RDSInstance {
+	var address string
+	var port int64
+
+	if db.Endpoint != nil {
+		address = aws.StringValue(db.Endpoint.Address)
+		port = aws.Int64Value(db.Endpoint.Port)
+	}
+
+	var vpcID string
+	var subnetIDs []string
+
+	if db.DBSubnetGroup != nil {
+		vpcID = aws.StringValue(db.DBSubnetGroup.VpcId)
+
+		for _, subnet := range db.DBSubnetGroup.Subnets {
+			subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetIdentifier))
+		}
+	}
+
+	var securityGroupIDs []string
+	for _, membership := range db.VpcSecurityGroups {
+		securityGroupIDs = append(securityGroupIDs, aws.StringValue(membership.VpcSecurityGroupId))
+	}
+
+	return reachAWS.RDSInstance{
+		ID:               aws.StringValue(db.DBInstanceIdentifier),
+		ARN:              aws.StringValue(db.DBInstanceArn),
+		Engine:           aws.StringValue(db.Engine),
+		Status:           aws.StringValue(db.DBInstanceStatus),
+		EndpointAddress:  address,
+		Port:             port,
+		VPCID:            vpcID,
+		SubnetIDs:        subnetIDs,
+		SecurityGroupIDs: securityGroupIDs,
+		ClusterID:        aws.StringValue(db.DBClusterIdentifier),
+	}
+}
+
+// rdsInstanceElasticNetworkInterfaceIDs finds the network interface that AWS manages on behalf of the RDS instance. The RDS API doesn't identify this network interface, so it's found using the IP address to which the instance's endpoint resolves (a private address, or a public address for a publicly accessible instance).
+func (provider *ResourceProvider) rdsInstanceElasticNetworkInterfaceIDs(db reachAWS.RDSInstance) ([]string, error) {
+	if db.EndpointAddress == "" {
+		// The instance doesn't have an endpoint yet (e.g. it's still being created).
+		return nil, nil
+	}
+
+	ips, err := provider.lookupIP(db.EndpointAddress)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve endpoint address '%s': %v", db.EndpointAddress, err)
+	}
+
+	var ids []string
+	seen := make(map[string]bool)
+
+	for _, ip := range ips {
+		if ip.To4() == nil {
+			continue
+		}
+
+		networkInterfaces, err := provider.elasticNetworkInterfacesForIPAddress(ip, filter("vpc-id", db.VPCID))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, eni := range networkInterfaces {
+			if !seen[eni.ID] {
+				seen[eni.ID] = true
+				ids = append(ids, eni.ID)
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no network interface found for endpoint address '%s'", db.EndpointAddress)
+	}
+
+	return ids, nil
+}
@@ -3,34 +3,191 @@ package api
 import (
 	"errors"
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
 
 	"github.com/luhring/reach/reach"
 )
 
+// batchSize is the maximum number of IDs (or filter values) sent in a single describe request.
+const batchSize = 100
+
+// maxConcurrentRequests is the maximum number of AWS API requests that a ResourceProvider makes at the same time. Resources resolve their dependencies concurrently, and the dependencies of those dependencies concurrently in turn, so this limit is shared by every lookup made through the provider.
+const maxConcurrentRequests = 16
+
 // ResourceProvider implements an AWS resource provider using the AWS API (via the AWS SDK).
+//
+// Resources are cached by ID for the lifetime of the provider, and resources requested together are described in batches. At most maxConcurrentRequests AWS API requests are in progress at once, however many lookups are using the provider.
 type ResourceProvider struct {
-	session *session.Session
-	ec2     *ec2.EC2
+	ec2          ec2iface.EC2API
+	elbv2        elbv2iface.ELBV2API
+	rds          rdsiface.RDSAPI
+	lookupIP     func(host string) ([]net.IP, error)
+	cache        *cache
+	prefetchVPCs bool
+	requests     chan struct{}
 }
 
-// NewResourceProvider returns a reference to a new ResourceProvider for the AWS API.
-func NewResourceProvider() *ResourceProvider {
-	sess := session.Must(session.NewSessionWithOptions(session.Options{
+// An Option configures a ResourceProvider.
+type Option func(provider *ResourceProvider)
+
+// WithVPCPrefetch configures the ResourceProvider to describe all of the subnets, network ACLs, route tables, security groups, network interfaces and instances in a VPC as soon as the VPC is first encountered. This trades a few larger requests up front for many small requests later on.
+func WithVPCPrefetch() Option {
+	return func(provider *ResourceProvider) {
+		provider.prefetchVPCs = true
+	}
+}
+
+// NewResourceProvider returns a reference to a new ResourceProvider for the AWS API, configured using any given options.
+func NewResourceProvider(options ...Option) (*ResourceProvider, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
 		SharedConfigState: session.SharedConfigEnable,
-	})) // TODO: Don't call session.Must —- return error, and don't panic, this is a lib after all!
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create AWS session: %v", err)
+	}
+
+	return newResourceProvider(ec2.New(sess), elbv2.New(sess), rds.New(sess), options...), nil
+}
+
+func newResourceProvider(ec2Client ec2iface.EC2API, elbv2Client elbv2iface.ELBV2API, rdsClient rdsiface.RDSAPI, options ...Option) *ResourceProvider {
+	provider := &ResourceProvider{
+		ec2:      ec2Client,
+		elbv2:    elbv2Client,
+		rds:      rdsClient,
+		lookupIP: net.LookupIP,
+		cache:    newCache(),
+		requests: make(chan struct{}, maxConcurrentRequests),
+	}
+
+	for _, option := range options {
+		option(provider)
+	}
+
+	return provider
+}
+
+// request calls send, after first waiting until fewer than maxConcurrentRequests requests are in progress. send should make a single AWS API request (which can span multiple pages), and not call back into the provider.
+func (provider *ResourceProvider) request(send func() error) error {
+	provider.requests <- struct{}{}
+	defer func() { <-provider.requests }()
+
+	return send()
+}
+
+// batches splits ids into consecutive groups of at most batchSize IDs.
+func batches(ids []string) [][]string {
+	var result [][]string
+
+	for len(ids) > batchSize {
+		result = append(result, ids[:batchSize])
+		ids = ids[batchSize:]
+	}
+
+	if len(ids) > 0 {
+		result = append(result, ids)
+	}
+
+	return result
+}
+
+func filter(name string, values ...string) *ec2.Filter {
+	return &ec2.Filter{
+		Name:   aws.String(name),
+		Values: aws.StringSlice(values),
+	}
+}
+
+// lookup returns the cached resources of the given kind that match ids, in order. Any resources not yet cached are first retrieved by calling describe with batches of the missing IDs; describe is expected to add each resource it retrieves to the cache.
+func (provider *ResourceProvider) lookup(kind, entity string, ids []string, describe func(batch []string) error) ([]interface{}, error) {
+	for _, batch := range batches(provider.cache.missing(kind, ids)) {
+		if err := describe(batch); err != nil {
+			return nil, err
+		}
+	}
+
+	resources := make([]interface{}, len(ids))
+
+	for i, id := range ids {
+		resource, exists := provider.cache.get(kind, id)
+		if !exists {
+			return nil, fmt.Errorf("AWS API did not return a %s for ID '%s'", entity, id)
+		}
+
+		resources[i] = resource
+	}
 
-	ec2Client := ec2.New(sess)
+	return resources, nil
+}
 
-	return &ResourceProvider{
-		session: sess,
-		ec2:     ec2Client,
+// prefetch describes the contents of each of the given VPCs that haven't already been prefetched, if VPC prefetching is enabled.
+func (provider *ResourceProvider) prefetch(vpcIDs []string) error {
+	if !provider.prefetchVPCs {
+		return nil
 	}
+
+	for _, id := range vpcIDs {
+		if id == "" || !provider.cache.claimVPCPrefetch(id) {
+			continue
+		}
+
+		if err := provider.prefetchVPC(id); err != nil {
+			return fmt.Errorf("unable to prefetch VPC '%s': %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (provider *ResourceProvider) prefetchVPC(id string) error {
+	vpcFilter := []*ec2.Filter{filter("vpc-id", id)}
+
+	if _, err := provider.VPCs(id); err != nil {
+		return err
+	}
+
+	networkACLs, err := provider.describeNetworkACLs(&ec2.DescribeNetworkAclsInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+
+	routeTables, err := provider.describeRouteTables(&ec2.DescribeRouteTablesInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+
+	subnets, err := provider.describeSubnets(&ec2.DescribeSubnetsInput{Filters: vpcFilter})
+	if err != nil {
+		return err
+	}
+
+	if err := provider.cacheSubnets(subnets, networkACLs, routeTables); err != nil {
+		return err
+	}
+
+	if err := provider.describeSecurityGroups(&ec2.DescribeSecurityGroupsInput{Filters: vpcFilter}); err != nil {
+		return err
+	}
+
+	if _, err := provider.describeElasticNetworkInterfaces(&ec2.DescribeNetworkInterfacesInput{Filters: vpcFilter}); err != nil {
+		return err
+	}
+
+	if _, err := provider.describeEC2Instances(&ec2.DescribeInstancesInput{Filters: vpcFilter}); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func nameTag(tags []*ec2.Tag) string {
@@ -45,6 +202,19 @@ func nameTag(tags []*ec2.Tag) string {
 	return ""
 }
 
+func tagMap(tags []*ec2.Tag) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return result
+}
+
 func ensureSingleResult(resultSetLength int, entity, id string) error {
 	if resultSetLength == 0 {
 		return fmt.Errorf("AWS API did not return a %s for ID '%s'", entity, id)
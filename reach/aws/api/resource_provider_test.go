@@ -0,0 +1,981 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/rds"
+	"github.com/aws/aws-sdk-go/service/rds/rdsiface"
+
+	"github.com/luhring/reach/reach"
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// fakeEC2 serves describe requests from fixed pages of results, recording the requests it receives. Methods not overridden here panic via the nil embedded interface.
+type fakeEC2 struct {
+	ec2iface.EC2API
+
+	instancePages      []*ec2.DescribeInstancesOutput
+	securityGroups     []*ec2.SecurityGroup
+	subnets            []*ec2.Subnet
+	networkACLs        []*ec2.NetworkAcl
+	routeTables        []*ec2.RouteTable
+	vpcs               []*ec2.Vpc
+	networkInterfaces  []*ec2.NetworkInterface
+	tgwAttachments     []*ec2.TransitGatewayAttachment
+	tgwRouteTables     []*ec2.TransitGatewayRouteTable
+	tgwRouteSearches   map[string]*ec2.SearchTransitGatewayRoutesOutput
+	prefixLists        []*ec2.PrefixList
+	managedPrefixLists []*ec2.ManagedPrefixList
+	prefixListEntries  map[string][]*ec2.PrefixListEntry
+	calls              map[string]int
+	securityGroupBatch []int
+}
+
+func (f *fakeEC2) record(call string) {
+	if f.calls == nil {
+		f.calls = make(map[string]int)
+	}
+	f.calls[call]++
+}
+
+func (f *fakeEC2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	f.record("DescribeInstances")
+
+	for i, page := range f.instancePages {
+		var reservations []*ec2.Reservation
+		for _, r := range page.Reservations {
+			var instances []*ec2.Instance
+			for _, instance := range r.Instances {
+				if matches(aws.StringValue(instance.InstanceId), input.InstanceIds) && matchesVPC(aws.StringValue(instance.VpcId), input.Filters) {
+					instances = append(instances, instance)
+				}
+			}
+			reservations = append(reservations, &ec2.Reservation{Instances: instances})
+		}
+
+		if !fn(&ec2.DescribeInstancesOutput{Reservations: reservations}, i == len(f.instancePages)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeEC2) DescribeSecurityGroupsPages(input *ec2.DescribeSecurityGroupsInput, fn func(*ec2.DescribeSecurityGroupsOutput, bool) bool) error {
+	f.record("DescribeSecurityGroups")
+	f.securityGroupBatch = append(f.securityGroupBatch, len(input.GroupIds))
+
+	var result []*ec2.SecurityGroup
+	for _, sg := range f.securityGroups {
+		if matches(aws.StringValue(sg.GroupId), input.GroupIds) && matchesVPC(aws.StringValue(sg.VpcId), input.Filters) {
+			result = append(result, sg)
+		}
+	}
+
+	fn(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeSubnetsPages(input *ec2.DescribeSubnetsInput, fn func(*ec2.DescribeSubnetsOutput, bool) bool) error {
+	f.record("DescribeSubnets")
+
+	var result []*ec2.Subnet
+	for _, subnet := range f.subnets {
+		if matches(aws.StringValue(subnet.SubnetId), input.SubnetIds) && matchesVPC(aws.StringValue(subnet.VpcId), input.Filters) {
+			result = append(result, subnet)
+		}
+	}
+
+	fn(&ec2.DescribeSubnetsOutput{Subnets: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeNetworkAclsPages(input *ec2.DescribeNetworkAclsInput, fn func(*ec2.DescribeNetworkAclsOutput, bool) bool) error {
+	f.record("DescribeNetworkAcls")
+
+	fn(&ec2.DescribeNetworkAclsOutput{NetworkAcls: f.networkACLs}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeRouteTablesPages(input *ec2.DescribeRouteTablesInput, fn func(*ec2.DescribeRouteTablesOutput, bool) bool) error {
+	f.record("DescribeRouteTables")
+
+	fn(&ec2.DescribeRouteTablesOutput{RouteTables: f.routeTables}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeVpcsPages(input *ec2.DescribeVpcsInput, fn func(*ec2.DescribeVpcsOutput, bool) bool) error {
+	f.record("DescribeVpcs")
+
+	fn(&ec2.DescribeVpcsOutput{Vpcs: f.vpcs}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeNetworkInterfacesPages(input *ec2.DescribeNetworkInterfacesInput, fn func(*ec2.DescribeNetworkInterfacesOutput, bool) bool) error {
+	f.record("DescribeNetworkInterfaces")
+
+	var result []*ec2.NetworkInterface
+	for _, eni := range f.networkInterfaces {
+		if matchesVPC(aws.StringValue(eni.VpcId), input.Filters) && matchesPrivateIP(eni, input.Filters) &&
+			matchesFilter("subnet-id", []string{aws.StringValue(eni.SubnetId)}, input.Filters) &&
+			matchesFilter("group-id", securityGroupIDs(eni.Groups), input.Filters) {
+			result = append(result, eni)
+		}
+	}
+
+	fn(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribePrefixListsPages(input *ec2.DescribePrefixListsInput, fn func(*ec2.DescribePrefixListsOutput, bool) bool) error {
+	f.record("DescribePrefixLists")
+
+	var result []*ec2.PrefixList
+	for _, prefixList := range f.prefixLists {
+		if matches(aws.StringValue(prefixList.PrefixListId), input.PrefixListIds) {
+			result = append(result, prefixList)
+		}
+	}
+
+	fn(&ec2.DescribePrefixListsOutput{PrefixLists: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeManagedPrefixLists(input *ec2.DescribeManagedPrefixListsInput) (*ec2.DescribeManagedPrefixListsOutput, error) {
+	f.record("DescribeManagedPrefixLists")
+
+	var result []*ec2.ManagedPrefixList
+	for _, prefixList := range f.managedPrefixLists {
+		if matches(aws.StringValue(prefixList.PrefixListId), input.PrefixListIds) {
+			result = append(result, prefixList)
+		}
+	}
+
+	return &ec2.DescribeManagedPrefixListsOutput{PrefixLists: result}, nil
+}
+
+func (f *fakeEC2) GetManagedPrefixListEntriesPages(input *ec2.GetManagedPrefixListEntriesInput, fn func(*ec2.GetManagedPrefixListEntriesOutput, bool) bool) error {
+	f.record("GetManagedPrefixListEntries")
+
+	entries := f.prefixListEntries[aws.StringValue(input.PrefixListId)]
+
+	// serve one entry per page to exercise pagination
+	for i, entry := range entries {
+		if !fn(&ec2.GetManagedPrefixListEntriesOutput{Entries: []*ec2.PrefixListEntry{entry}}, i == len(entries)-1) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// fakeRDS serves DB instance describe requests from a fixed list of instances.
+type fakeRDS struct {
+	rdsiface.RDSAPI
+
+	instances []*rds.DBInstance
+}
+
+func (f *fakeRDS) DescribeDBInstancesPages(input *rds.DescribeDBInstancesInput, fn func(*rds.DescribeDBInstancesOutput, bool) bool) error {
+	var result []*rds.DBInstance
+	for _, db := range f.instances {
+		if input.DBInstanceIdentifier == nil || aws.StringValue(input.DBInstanceIdentifier) == aws.StringValue(db.DBInstanceIdentifier) {
+			result = append(result, db)
+		}
+	}
+
+	fn(&rds.DescribeDBInstancesOutput{DBInstances: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeTransitGatewayAttachmentsPages(input *ec2.DescribeTransitGatewayAttachmentsInput, fn func(*ec2.DescribeTransitGatewayAttachmentsOutput, bool) bool) error {
+	f.record("DescribeTransitGatewayAttachments")
+
+	var result []*ec2.TransitGatewayAttachment
+	for _, attachment := range f.tgwAttachments {
+		if matchesFilters(input.Filters, map[string]string{
+			"transit-gateway-id": aws.StringValue(attachment.TransitGatewayId),
+			"resource-type":      aws.StringValue(attachment.ResourceType),
+			"resource-id":        aws.StringValue(attachment.ResourceId),
+		}) {
+			result = append(result, attachment)
+		}
+	}
+
+	fn(&ec2.DescribeTransitGatewayAttachmentsOutput{TransitGatewayAttachments: result}, true)
+	return nil
+}
+
+func (f *fakeEC2) DescribeTransitGatewayRouteTables(input *ec2.DescribeTransitGatewayRouteTablesInput) (*ec2.DescribeTransitGatewayRouteTablesOutput, error) {
+	f.record("DescribeTransitGatewayRouteTables")
+
+	var result []*ec2.TransitGatewayRouteTable
+	for _, routeTable := range f.tgwRouteTables {
+		for _, id := range input.TransitGatewayRouteTableIds {
+			if aws.StringValue(id) == aws.StringValue(routeTable.TransitGatewayRouteTableId) {
+				result = append(result, routeTable)
+			}
+		}
+	}
+
+	return &ec2.DescribeTransitGatewayRouteTablesOutput{TransitGatewayRouteTables: result}, nil
+}
+
+func (f *fakeEC2) SearchTransitGatewayRoutes(input *ec2.SearchTransitGatewayRoutesInput) (*ec2.SearchTransitGatewayRoutesOutput, error) {
+	f.record("SearchTransitGatewayRoutes")
+
+	if output, exists := f.tgwRouteSearches[aws.StringValue(input.TransitGatewayRouteTableId)]; exists {
+		return output, nil
+	}
+
+	return &ec2.SearchTransitGatewayRoutesOutput{}, nil
+}
+
+// matchesFilters returns a boolean indicating whether every filter includes the value of the field that the filter names.
+func matchesFilters(filters []*ec2.Filter, fields map[string]string) bool {
+	for _, f := range filters {
+		matched := false
+		for _, value := range f.Values {
+			if aws.StringValue(value) == fields[aws.StringValue(f.Name)] {
+				matched = true
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matches(id string, ids []*string) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	for _, candidate := range ids {
+		if aws.StringValue(candidate) == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesVPC(vpcID string, filters []*ec2.Filter) bool {
+	for _, f := range filters {
+		if aws.StringValue(f.Name) == "vpc-id" {
+			return matches(vpcID, f.Values)
+		}
+	}
+
+	return true
+}
+
+// matchesFilter returns false if the filters include one with the given name that doesn't match any of the given values.
+func matchesFilter(name string, values []string, filters []*ec2.Filter) bool {
+	for _, f := range filters {
+		if aws.StringValue(f.Name) == name {
+			for _, value := range values {
+				if matches(value, f.Values) {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesPrivateIP(eni *ec2.NetworkInterface, filters []*ec2.Filter) bool {
+	for _, f := range filters {
+		if aws.StringValue(f.Name) == "addresses.private-ip-address" {
+			for _, address := range eni.PrivateIpAddresses {
+				if matches(aws.StringValue(address.PrivateIpAddress), f.Values) {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return true
+}
+
+func fakeSecurityGroups(count int) []*ec2.SecurityGroup {
+	result := make([]*ec2.SecurityGroup, count)
+	for i := range result {
+		result[i] = &ec2.SecurityGroup{
+			GroupId: aws.String(fmt.Sprintf("sg-%d", i)),
+			VpcId:   aws.String("vpc-1"),
+		}
+	}
+
+	return result
+}
+
+func TestResourceProviderCachesResources(t *testing.T) {
+	client := &fakeEC2{securityGroups: fakeSecurityGroups(1)}
+	provider := newResourceProvider(client, nil, nil)
+
+	for i := 0; i < 3; i++ {
+		if _, err := provider.SecurityGroup("sg-0"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if calls := client.calls["DescribeSecurityGroups"]; calls != 1 {
+		t.Errorf("expected 1 DescribeSecurityGroups call, but got %d", calls)
+	}
+}
+
+// slowEC2 serves each transit gateway request after a short delay, recording the highest number of requests in progress at once.
+type slowEC2 struct {
+	ec2iface.EC2API
+
+	mu          sync.Mutex
+	inProgress  int
+	maxInFlight int
+}
+
+func (f *slowEC2) DescribeTransitGateways(input *ec2.DescribeTransitGatewaysInput) (*ec2.DescribeTransitGatewaysOutput, error) {
+	f.mu.Lock()
+	f.inProgress++
+	if f.inProgress > f.maxInFlight {
+		f.maxInFlight = f.inProgress
+	}
+	f.mu.Unlock()
+
+	time.Sleep(time.Millisecond)
+
+	f.mu.Lock()
+	f.inProgress--
+	f.mu.Unlock()
+
+	return &ec2.DescribeTransitGatewaysOutput{
+		TransitGateways: []*ec2.TransitGateway{
+			{TransitGatewayId: input.TransitGatewayIds[0]},
+		},
+	}, nil
+}
+
+func TestResourceProviderLimitsConcurrentRequests(t *testing.T) {
+	const count = maxConcurrentRequests * 4
+
+	client := &slowEC2{}
+	provider := newResourceProvider(client, nil, nil)
+
+	err := reach.ForEachConcurrently(count, count, func(i int) error {
+		_, err := provider.TransitGateway(fmt.Sprintf("tgw-%d", i))
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if client.maxInFlight > maxConcurrentRequests {
+		t.Errorf("expected at most %d requests at once, but got %d", maxConcurrentRequests, client.maxInFlight)
+	}
+}
+
+func TestResourceProviderBatchesRequests(t *testing.T) {
+	const count = batchSize + 50
+
+	client := &fakeEC2{securityGroups: fakeSecurityGroups(count)}
+	provider := newResourceProvider(client, nil, nil)
+
+	// request one group first, so that the batch only includes groups that aren't yet cached
+	if _, err := provider.SecurityGroup("sg-0"); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []string
+	for _, sg := range client.securityGroups {
+		ids = append(ids, aws.StringValue(sg.GroupId))
+	}
+
+	securityGroups, err := provider.SecurityGroups(ids...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(securityGroups) != count {
+		t.Fatalf("expected %d security groups, but got %d", count, len(securityGroups))
+	}
+
+	for i, sg := range securityGroups {
+		if sg.ID != ids[i] {
+			t.Errorf("expected security group at index %d to be '%s', but got '%s'", i, ids[i], sg.ID)
+		}
+	}
+
+	expectedBatches := []int{1, batchSize, count - 1 - batchSize}
+	if fmt.Sprint(client.securityGroupBatch) != fmt.Sprint(expectedBatches) {
+		t.Errorf("expected batch sizes %v, but got %v", expectedBatches, client.securityGroupBatch)
+	}
+}
+
+func TestResourceProviderReportsMissingResources(t *testing.T) {
+	provider := newResourceProvider(&fakeEC2{securityGroups: fakeSecurityGroups(1)}, nil, nil)
+
+	if _, err := provider.SecurityGroups("sg-0", "sg-missing"); err == nil {
+		t.Error("expected an error for a security group that the API didn't return, but got nil")
+	}
+}
+
+func TestAllEC2InstancesReadsEveryPage(t *testing.T) {
+	page := func(id string) *ec2.DescribeInstancesOutput {
+		return &ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{
+				{
+					Instances: []*ec2.Instance{
+						{
+							InstanceId: aws.String(id),
+							State:      &ec2.InstanceState{Name: aws.String("running")},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	client := &fakeEC2{instancePages: []*ec2.DescribeInstancesOutput{page("i-1"), page("i-2"), page("i-3")}}
+	provider := newResourceProvider(client, nil, nil)
+
+	instances, err := provider.AllEC2Instances()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(instances) != 3 {
+		t.Fatalf("expected 3 instances, but got %d", len(instances))
+	}
+
+	if _, err := provider.EC2Instance("i-3"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls := client.calls["DescribeInstances"]; calls != 1 {
+		t.Errorf("expected 1 DescribeInstances call, but got %d", calls)
+	}
+}
+
+func TestVPCPrefetch(t *testing.T) {
+	client := &fakeEC2{
+		instancePages: []*ec2.DescribeInstancesOutput{
+			{
+				Reservations: []*ec2.Reservation{
+					{
+						Instances: []*ec2.Instance{
+							{
+								InstanceId: aws.String("i-1"),
+								VpcId:      aws.String("vpc-1"),
+								State:      &ec2.InstanceState{Name: aws.String("running")},
+							},
+						},
+					},
+				},
+			},
+		},
+		vpcs: []*ec2.Vpc{
+			{VpcId: aws.String("vpc-1")},
+		},
+		subnets: []*ec2.Subnet{
+			{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1")},
+		},
+		networkACLs: []*ec2.NetworkAcl{
+			{
+				NetworkAclId: aws.String("acl-1"),
+				VpcId:        aws.String("vpc-1"),
+				Associations: []*ec2.NetworkAclAssociation{{SubnetId: aws.String("subnet-1")}},
+			},
+		},
+		routeTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-1"),
+				VpcId:        aws.String("vpc-1"),
+				Associations: []*ec2.RouteTableAssociation{{Main: aws.Bool(true)}},
+			},
+		},
+		securityGroups: fakeSecurityGroups(2),
+		networkInterfaces: []*ec2.NetworkInterface{
+			{
+				NetworkInterfaceId: aws.String("eni-1"),
+				SubnetId:           aws.String("subnet-1"),
+				VpcId:              aws.String("vpc-1"),
+			},
+		},
+	}
+	provider := newResourceProvider(client, nil, nil, WithVPCPrefetch())
+
+	if _, err := provider.EC2Instance("i-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	callsAfterPrefetch := fmt.Sprint(client.calls)
+
+	if _, err := provider.ElasticNetworkInterface("eni-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	subnet, err := provider.Subnet("subnet-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if subnet.NetworkACLID != "acl-1" || subnet.RouteTableID != "rtb-1" {
+		t.Errorf("expected subnet associations (acl-1, rtb-1), but got (%s, %s)", subnet.NetworkACLID, subnet.RouteTableID)
+	}
+
+	if _, err := provider.SecurityGroups("sg-0", "sg-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := provider.VPC("vpc-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if calls := fmt.Sprint(client.calls); calls != callsAfterPrefetch {
+		t.Errorf("expected no API calls after prefetch, but calls changed from %s to %s", callsAfterPrefetch, calls)
+	}
+}
+
+func TestTransitGatewayVPCAttachment(t *testing.T) {
+	client := &fakeEC2{
+		tgwAttachments: []*ec2.TransitGatewayAttachment{
+			{
+				TransitGatewayAttachmentId: aws.String("tgw-attach-1"),
+				TransitGatewayId:           aws.String("tgw-1"),
+				ResourceType:               aws.String(ec2.TransitGatewayAttachmentResourceTypeVpc),
+				ResourceId:                 aws.String("vpc-1"),
+				State:                      aws.String(ec2.TransitGatewayAttachmentStateAvailable),
+				Association:                &ec2.TransitGatewayAttachmentAssociation{TransitGatewayRouteTableId: aws.String("tgw-rtb-1")},
+			},
+		},
+	}
+	provider := newResourceProvider(client, nil, nil)
+
+	cases := []struct {
+		name             string
+		transitGatewayID string
+		vpcID            string
+		expected         *reachAWS.TransitGatewayAttachment
+	}{
+		{
+			"attached VPC",
+			"tgw-1",
+			"vpc-1",
+			&reachAWS.TransitGatewayAttachment{
+				ID:                     "tgw-attach-1",
+				TransitGatewayID:       "tgw-1",
+				ResourceType:           reachAWS.TransitGatewayAttachmentResourceTypeVPC,
+				ResourceID:             "vpc-1",
+				State:                  reachAWS.TransitGatewayAttachmentStateAvailable,
+				AssociatedRouteTableID: "tgw-rtb-1",
+			},
+		},
+		{
+			"VPC that isn't attached",
+			"tgw-1",
+			"vpc-2",
+			nil,
+		},
+		{
+			"VPC attached to a different transit gateway",
+			"tgw-2",
+			"vpc-1",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			attachment, err := provider.TransitGatewayVPCAttachment(tc.transitGatewayID, tc.vpcID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (attachment == nil) != (tc.expected == nil) || (attachment != nil && *attachment != *tc.expected) {
+				t.Errorf("expected attachment %+v, but got %+v", tc.expected, attachment)
+			}
+		})
+	}
+}
+
+func TestTransitGatewayRouteTable(t *testing.T) {
+	routes := []*ec2.TransitGatewayRoute{
+		{
+			DestinationCidrBlock: aws.String("10.1.0.0/16"),
+			State:                aws.String(ec2.TransitGatewayRouteStateActive),
+			TransitGatewayAttachments: []*ec2.TransitGatewayRouteAttachment{
+				{TransitGatewayAttachmentId: aws.String("tgw-attach-1"), ResourceType: aws.String("vpc"), ResourceId: aws.String("vpc-1")},
+			},
+		},
+		{
+			DestinationCidrBlock: aws.String("10.2.0.0/16"),
+			State:                aws.String(ec2.TransitGatewayRouteStateBlackhole),
+		},
+	}
+
+	cases := []struct {
+		name           string
+		search         *ec2.SearchTransitGatewayRoutesOutput
+		expectedRoutes []string
+		expectedError  bool
+	}{
+		{
+			"all routes returned",
+			&ec2.SearchTransitGatewayRoutesOutput{Routes: routes, AdditionalRoutesAvailable: aws.Bool(false)},
+			[]string{"10.1.0.0/16 -> tgw-attach-1 (vpc vpc-1) (active)", "10.2.0.0/16 -> [no attachments] (blackhole)"},
+			false,
+		},
+		{
+			"more routes than were returned",
+			&ec2.SearchTransitGatewayRoutesOutput{Routes: routes, AdditionalRoutesAvailable: aws.Bool(true)},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeEC2{
+				tgwRouteTables: []*ec2.TransitGatewayRouteTable{
+					{TransitGatewayRouteTableId: aws.String("tgw-rtb-1"), TransitGatewayId: aws.String("tgw-1")},
+				},
+				tgwRouteSearches: map[string]*ec2.SearchTransitGatewayRoutesOutput{"tgw-rtb-1": tc.search},
+			}
+			provider := newResourceProvider(client, nil, nil)
+
+			routeTable, err := provider.TransitGatewayRouteTable("tgw-rtb-1")
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual []string
+			for _, route := range routeTable.Routes {
+				actual = append(actual, route.String())
+			}
+
+			if fmt.Sprint(actual) != fmt.Sprint(tc.expectedRoutes) {
+				reach.DiffErrorf(t, "routes", tc.expectedRoutes, actual)
+			}
+		})
+	}
+}
+
+func TestRDSInstanceFindsNetworkInterfaceByEndpointAddress(t *testing.T) {
+	eni := func(id, ip string) *ec2.NetworkInterface {
+		return &ec2.NetworkInterface{
+			NetworkInterfaceId: aws.String(id),
+			VpcId:              aws.String("vpc-1"),
+			PrivateIpAddresses: []*ec2.NetworkInterfacePrivateIpAddress{{PrivateIpAddress: aws.String(ip)}},
+		}
+	}
+
+	client := &fakeEC2{
+		networkInterfaces: []*ec2.NetworkInterface{
+			eni("eni-other-db", "10.0.1.20"),
+			eni("eni-db", "10.0.1.30"),
+		},
+	}
+	rdsClient := &fakeRDS{
+		instances: []*rds.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db"),
+				DBInstanceStatus:     aws.String("available"),
+				Engine:               aws.String("postgres"),
+				Endpoint:             &rds.Endpoint{Address: aws.String("db.example.us-east-1.rds.amazonaws.com"), Port: aws.Int64(5432)},
+				DBSubnetGroup:        &rds.DBSubnetGroup{VpcId: aws.String("vpc-1")},
+			},
+		},
+	}
+
+	provider := newResourceProvider(client, nil, rdsClient)
+	provider.lookupIP = func(host string) ([]net.IP, error) {
+		if host != "db.example.us-east-1.rds.amazonaws.com" {
+			return nil, fmt.Errorf("unexpected host: %s", host)
+		}
+
+		return []net.IP{net.ParseIP("10.0.1.30")}, nil
+	}
+
+	db, err := provider.RDSInstance("db")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(db.ElasticNetworkInterfaceIDs) != "[eni-db]" {
+		t.Errorf("expected network interfaces [eni-db], but got %v", db.ElasticNetworkInterfaceIDs)
+	}
+
+	if db.Port != 5432 {
+		t.Errorf("expected port 5432, but got %d", db.Port)
+	}
+}
+
+func TestRouteTableExpandsPrefixListRoutes(t *testing.T) {
+	client := &fakeEC2{
+		routeTables: []*ec2.RouteTable{
+			{
+				RouteTableId: aws.String("rtb-1"),
+				VpcId:        aws.String("vpc-1"),
+				Routes: []*ec2.Route{
+					{DestinationCidrBlock: aws.String("10.0.0.0/16"), GatewayId: aws.String("local"), State: aws.String("active")},
+					{DestinationPrefixListId: aws.String("pl-s3"), GatewayId: aws.String("vpce-1"), State: aws.String("active")},
+					{DestinationPrefixListId: aws.String("pl-customer"), GatewayId: aws.String("vpce-2"), State: aws.String("active")},
+				},
+			},
+		},
+		prefixLists: []*ec2.PrefixList{
+			{
+				PrefixListId:   aws.String("pl-s3"),
+				PrefixListName: aws.String("com.amazonaws.us-east-1.s3"),
+				Cidrs:          aws.StringSlice([]string{"52.216.0.0/15", "54.231.0.0/16"}),
+			},
+		},
+	}
+	provider := newResourceProvider(client, nil, nil)
+
+	routeTable, err := provider.RouteTable("rtb-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var routes []string
+	for _, route := range routeTable.Routes {
+		routes = append(routes, route.String())
+	}
+
+	expected := []string{
+		"10.0.0.0/16 -> local (active)",
+		"pl-s3 (52.216.0.0/15) -> VPCEndpoint vpce-1 (active)",
+		"pl-s3 (54.231.0.0/16) -> VPCEndpoint vpce-1 (active)",
+	}
+	if fmt.Sprint(routes) != fmt.Sprint(expected) {
+		reach.DiffErrorf(t, "routes", expected, routes)
+	}
+
+	service, err := provider.AWSService("com.amazonaws.us-east-1.s3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.PrefixListID != "pl-s3" || len(service.IPNetworks) != 2 {
+		t.Errorf("unexpected AWS service: %+v", service)
+	}
+
+	if calls := client.calls["DescribePrefixLists"]; calls != 1 {
+		t.Errorf("expected 1 DescribePrefixLists call, but got %d", calls)
+	}
+}
+
+func TestSecurityGroupRuleReferencesManagedPrefixList(t *testing.T) {
+	client := &fakeEC2{
+		securityGroups: []*ec2.SecurityGroup{
+			{
+				GroupId: aws.String("sg-1"),
+				VpcId:   aws.String("vpc-1"),
+				IpPermissions: []*ec2.IpPermission{
+					{
+						IpProtocol:    aws.String("tcp"),
+						FromPort:      aws.Int64(443),
+						ToPort:        aws.Int64(443),
+						PrefixListIds: []*ec2.PrefixListId{{PrefixListId: aws.String("pl-corp")}},
+					},
+				},
+			},
+		},
+		managedPrefixLists: []*ec2.ManagedPrefixList{
+			{
+				PrefixListId:   aws.String("pl-corp"),
+				PrefixListName: aws.String("corporate-networks"),
+				OwnerId:        aws.String("123456789012"),
+			},
+		},
+		prefixListEntries: map[string][]*ec2.PrefixListEntry{
+			"pl-corp": {
+				{Cidr: aws.String("198.51.100.0/24"), Description: aws.String("office")},
+				{Cidr: aws.String("192.168.5.0/24")},
+			},
+		},
+	}
+	provider := newResourceProvider(client, nil, nil)
+
+	sg, err := provider.SecurityGroup("sg-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := sg.InboundRules[0].TargetPrefixListIDs; fmt.Sprint(ids) != "[pl-corp]" {
+		t.Errorf("expected rule to refer to prefix list [pl-corp], but got %v", ids)
+	}
+
+	for i := 0; i < 2; i++ {
+		prefixList, err := provider.ManagedPrefixList("pl-corp")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var entries []string
+		for _, entry := range prefixList.Entries {
+			entries = append(entries, entry.String())
+		}
+
+		expected := []string{"198.51.100.0/24 (office)", "192.168.5.0/24"}
+		if fmt.Sprint(entries) != fmt.Sprint(expected) {
+			reach.DiffErrorf(t, "prefix list entries", expected, entries)
+		}
+	}
+
+	if calls := client.calls["DescribeManagedPrefixLists"]; calls != 1 {
+		t.Errorf("expected 1 DescribeManagedPrefixLists call, but got %d", calls)
+	}
+
+	if _, err := provider.ManagedPrefixList("pl-missing"); err == nil {
+		t.Error("expected an error for a missing prefix list")
+	}
+}
+
+func TestTransitGatewayRouteTableExpandsPrefixListRoutes(t *testing.T) {
+	client := &fakeEC2{
+		tgwRouteTables: []*ec2.TransitGatewayRouteTable{
+			{TransitGatewayRouteTableId: aws.String("tgw-rtb-1"), TransitGatewayId: aws.String("tgw-1")},
+		},
+		tgwRouteSearches: map[string]*ec2.SearchTransitGatewayRoutesOutput{
+			"tgw-rtb-1": {
+				Routes: []*ec2.TransitGatewayRoute{
+					{
+						DestinationCidrBlock: aws.String("10.1.0.0/16"),
+						State:                aws.String("active"),
+						TransitGatewayAttachments: []*ec2.TransitGatewayRouteAttachment{
+							{TransitGatewayAttachmentId: aws.String("tgw-attach-1"), ResourceType: aws.String("vpc"), ResourceId: aws.String("vpc-1")},
+						},
+					},
+					{
+						PrefixListId: aws.String("pl-corp"),
+						State:        aws.String("active"),
+						TransitGatewayAttachments: []*ec2.TransitGatewayRouteAttachment{
+							{TransitGatewayAttachmentId: aws.String("tgw-attach-2"), ResourceType: aws.String("vpc"), ResourceId: aws.String("vpc-2")},
+						},
+					},
+				},
+			},
+		},
+		managedPrefixLists: []*ec2.ManagedPrefixList{
+			{PrefixListId: aws.String("pl-corp"), PrefixListName: aws.String("corporate-networks")},
+		},
+		prefixListEntries: map[string][]*ec2.PrefixListEntry{
+			"pl-corp": {
+				{Cidr: aws.String("10.2.0.0/16")},
+				{Cidr: aws.String("10.3.0.0/16")},
+			},
+		},
+	}
+	provider := newResourceProvider(client, nil, nil)
+
+	routeTable, err := provider.TransitGatewayRouteTable("tgw-rtb-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var routes []string
+	for _, route := range routeTable.Routes {
+		routes = append(routes, route.String())
+	}
+
+	expected := []string{
+		"10.1.0.0/16 -> tgw-attach-1 (vpc vpc-1) (active)",
+		"pl-corp (10.2.0.0/16) -> tgw-attach-2 (vpc vpc-2) (active)",
+		"pl-corp (10.3.0.0/16) -> tgw-attach-2 (vpc vpc-2) (active)",
+	}
+	if fmt.Sprint(routes) != fmt.Sprint(expected) {
+		reach.DiffErrorf(t, "routes", expected, routes)
+	}
+}
+
+func TestElasticNetworkInterfacesForSelectors(t *testing.T) {
+	eni := func(id, subnetID, vpcID, sgID string) *ec2.NetworkInterface {
+		return &ec2.NetworkInterface{
+			NetworkInterfaceId: aws.String(id),
+			SubnetId:           aws.String(subnetID),
+			VpcId:              aws.String(vpcID),
+			Groups:             []*ec2.GroupIdentifier{{GroupId: aws.String(sgID)}},
+		}
+	}
+
+	client := &fakeEC2{
+		networkInterfaces: []*ec2.NetworkInterface{
+			eni("eni-1", "subnet-1", "vpc-1", "sg-1"),
+			eni("eni-2", "subnet-2", "vpc-1", "sg-2"),
+			eni("eni-3", "subnet-3", "vpc-2", "sg-1"),
+		},
+	}
+
+	cases := []struct {
+		name     string
+		lookup   func(provider *ResourceProvider) ([]reachAWS.ElasticNetworkInterface, error)
+		expected []string
+	}{
+		{
+			"security group",
+			func(provider *ResourceProvider) ([]reachAWS.ElasticNetworkInterface, error) {
+				return provider.ElasticNetworkInterfacesForSecurityGroup("sg-1")
+			},
+			[]string{"eni-1", "eni-3"},
+		},
+		{
+			"subnet",
+			func(provider *ResourceProvider) ([]reachAWS.ElasticNetworkInterface, error) {
+				return provider.ElasticNetworkInterfacesForSubnet("subnet-2")
+			},
+			[]string{"eni-2"},
+		},
+		{
+			"VPC",
+			func(provider *ResourceProvider) ([]reachAWS.ElasticNetworkInterface, error) {
+				return provider.ElasticNetworkInterfacesForVPC("vpc-1")
+			},
+			[]string{"eni-1", "eni-2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client.calls = nil
+			networkInterfaces, err := tc.lookup(newResourceProvider(client, nil, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, networkInterface := range networkInterfaces {
+				ids = append(ids, networkInterface.ID)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expected) {
+				reach.DiffErrorf(t, "network interface IDs", tc.expected, ids)
+			}
+
+			if calls := client.calls["DescribeNetworkInterfaces"]; calls != 1 {
+				t.Errorf("expected 1 DescribeNetworkInterfaces call, but got %d", calls)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package api
 
 import (
+	"net"
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 
@@ -9,26 +12,64 @@ import (
 
 // RouteTable queries the AWS API for a route table matching the given ID.
 func (provider *ResourceProvider) RouteTable(id string) (*reachAWS.RouteTable, error) {
-	input := &ec2.DescribeRouteTablesInput{
-		RouteTableIds: []*string{
-			aws.String(id),
-		},
+	routeTables, err := provider.RouteTables(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routeTables[0], nil
+}
+
+// RouteTables queries the AWS API for the route tables matching the given IDs, describing any route tables not already retrieved in batches.
+func (provider *ResourceProvider) RouteTables(ids ...string) ([]reachAWS.RouteTable, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindRouteTable, "route table", ids, func(batch []string) error {
+		_, err := provider.describeRouteTables(&ec2.DescribeRouteTablesInput{
+			RouteTableIds: aws.StringSlice(batch),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routeTables := make([]reachAWS.RouteTable, len(resources))
+	for i, resource := range resources {
+		routeTables[i] = resource.(reachAWS.RouteTable)
 	}
-	result, err := provider.ec2.DescribeRouteTables(input)
+
+	return routeTables, nil
+}
+
+// describeRouteTables retrieves every page of route tables matching the input and adds them to the cache. It returns the API's representation of the route tables so that callers can inspect their subnet associations.
+func (provider *ResourceProvider) describeRouteTables(input *ec2.DescribeRouteTablesInput) ([]*ec2.RouteTable, error) {
+	var result []*ec2.RouteTable
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeRouteTablesPages(input, func(page *ec2.DescribeRouteTablesOutput, _ bool) bool {
+			result = append(result, page.RouteTables...)
+			return true
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.RouteTables), "security group", id); err != nil {
+	// Routes to prefix lists can only be represented once the prefix lists' CIDR blocks are known.
+	prefixLists, err := provider.awsServicesForPrefixLists(routeTablePrefixListIDs(result))
+	if err != nil {
 		return nil, err
 	}
 
-	routeTable := newRouteTableFromAPI(result.RouteTables[0])
-	return &routeTable, nil
+	for _, rtb := range result {
+		routeTable := newRouteTableFromAPI(rtb, prefixLists)
+		provider.cache.put(reachAWS.ResourceKindRouteTable, routeTable.ID, routeTable)
+	}
+
+	return result, nil
 }
 
-func newRouteTableFromAPI(routeTable *ec2.RouteTable) reachAWS.RouteTable {
-	routes := []reachAWS.RouteTableRoute{} // TODO: implement
+func newRouteTableFromAPI(routeTable *ec2.RouteTable, prefixLists map[string]reachAWS.AWSService) reachAWS.RouteTable {
+	routes := routeTableRoutes(routeTable.Routes, prefixLists)
 
 	return reachAWS.RouteTable{
 		ID:     aws.StringValue(routeTable.RouteTableId),
@@ -37,8 +78,50 @@ func newRouteTableFromAPI(routeTable *ec2.RouteTable) reachAWS.RouteTable {
 	}
 }
 
-func routeTableRoutes(routes []*ec2.Route) []reachAWS.RouteTableRoute {
-	return nil // TODO: implement
+// routeTablePrefixListIDs returns the IDs of the prefix lists used as route destinations in the given route tables.
+func routeTablePrefixListIDs(routeTables []*ec2.RouteTable) []string {
+	var ids []string
+
+	for _, rtb := range routeTables {
+		for _, route := range rtb.Routes {
+			if route != nil && route.DestinationPrefixListId != nil {
+				ids = append(ids, aws.StringValue(route.DestinationPrefixListId))
+			}
+		}
+	}
+
+	return ids
+}
+
+func routeTableRoutes(inputRoutes []*ec2.Route, prefixLists map[string]reachAWS.AWSService) []reachAWS.RouteTableRoute {
+	var routes []reachAWS.RouteTableRoute
+
+	for _, inputRoute := range inputRoutes {
+		if inputRoute == nil {
+			continue
+		}
+
+		route := routeTableRoute(inputRoute)
+
+		if id := aws.StringValue(inputRoute.DestinationPrefixListId); id != "" {
+			// e.g. a route to a gateway VPC endpoint, which becomes one route for each of the prefix list's CIDR blocks
+			route.DestinationPrefixListID = id
+
+			for _, network := range prefixLists[id].IPNetworks {
+				route.Destination = network
+				routes = append(routes, route)
+			}
+			continue
+		}
+
+		if route.Destination == nil {
+			continue
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes
 }
 
 func routeTableRoute(route *ec2.Route) reachAWS.RouteTableRoute {
@@ -46,5 +129,66 @@ func routeTableRoute(route *ec2.Route) reachAWS.RouteTableRoute {
 		return reachAWS.RouteTableRoute{}
 	}
 
-	panic("need to finish implementing")
+	var destination *net.IPNet
+
+	if cidr := aws.StringValue(route.DestinationCidrBlock); cidr != "" {
+		_, destination, _ = net.ParseCIDR(cidr)
+	} else if cidr := aws.StringValue(route.DestinationIpv6CidrBlock); cidr != "" {
+		_, destination, _ = net.ParseCIDR(cidr)
+	}
+
+	return reachAWS.RouteTableRoute{
+		Destination: destination,
+		Target:      routeTableRouteTarget(route),
+		State:       reachAWS.RouteTableRouteState(aws.StringValue(route.State)),
+		Propagated:  aws.StringValue(route.Origin) == ec2.RouteOriginEnableVgwRoutePropagation,
+	}
+}
+
+func routeTableRouteTarget(route *ec2.Route) reachAWS.RouteTableRouteTarget {
+	target := func(targetType reachAWS.RouteTableRouteTargetType, id *string) reachAWS.RouteTableRouteTarget {
+		return reachAWS.RouteTableRouteTarget{
+			Type: targetType,
+			ID:   aws.StringValue(id),
+		}
+	}
+
+	switch {
+	case route.VpcPeeringConnectionId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeVPCPeeringConnection, route.VpcPeeringConnectionId)
+	case route.TransitGatewayId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeTransitGateway, route.TransitGatewayId)
+	case route.NatGatewayId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeNATGateway, route.NatGatewayId)
+	case route.EgressOnlyInternetGatewayId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeEgressOnlyInternetGateway, route.EgressOnlyInternetGatewayId)
+	case route.LocalGatewayId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeLocalGateway, route.LocalGatewayId)
+	case route.InstanceId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeEC2Instance, route.InstanceId)
+	case route.NetworkInterfaceId != nil:
+		return target(reachAWS.RouteTableRouteTargetTypeElasticNetworkInterface, route.NetworkInterfaceId)
+	case route.GatewayId != nil:
+		return target(gatewayTargetType(aws.StringValue(route.GatewayId)), route.GatewayId)
+	default:
+		return reachAWS.RouteTableRouteTarget{
+			Type: reachAWS.RouteTableRouteTargetTypeUnknown,
+		}
+	}
+}
+
+// gatewayTargetType determines the kind of gateway referred to by a route's "GatewayId" field, which the AWS API uses for several different kinds of targets.
+func gatewayTargetType(gatewayID string) reachAWS.RouteTableRouteTargetType {
+	switch {
+	case gatewayID == "local":
+		return reachAWS.RouteTableRouteTargetTypeLocal
+	case strings.HasPrefix(gatewayID, "igw-"):
+		return reachAWS.RouteTableRouteTargetTypeInternetGateway
+	case strings.HasPrefix(gatewayID, "vgw-"):
+		return reachAWS.RouteTableRouteTargetTypeVirtualPrivateGateway
+	case strings.HasPrefix(gatewayID, "vpce-"):
+		return reachAWS.RouteTableRouteTargetTypeVPCEndpoint
+	default:
+		return reachAWS.RouteTableRouteTargetTypeUnknown
+	}
 }
@@ -14,62 +14,112 @@ import (
 
 // SecurityGroup queries the AWS API for a security group matching the given ID.
 func (provider *ResourceProvider) SecurityGroup(id string) (*reachAWS.SecurityGroup, error) {
-	input := &ec2.DescribeSecurityGroupsInput{
-		GroupIds: []*string{
-			aws.String(id),
-		},
-	}
-	result, err := provider.ec2.DescribeSecurityGroups(input)
+	securityGroups, err := provider.SecurityGroups(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.SecurityGroups), "security group", id); err != nil {
+	return &securityGroups[0], nil
+}
+
+// SecurityGroups queries the AWS API for the security groups matching the given IDs, describing any security groups not already retrieved in batches.
+func (provider *ResourceProvider) SecurityGroups(ids ...string) ([]reachAWS.SecurityGroup, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindSecurityGroup, "security group", ids, func(batch []string) error {
+		return provider.describeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+			GroupIds: aws.StringSlice(batch),
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	securityGroup := newSecurityGroupFromAPI(result.SecurityGroups[0])
-	return &securityGroup, nil
+	securityGroups := make([]reachAWS.SecurityGroup, len(resources))
+	for i, resource := range resources {
+		securityGroups[i] = resource.(reachAWS.SecurityGroup)
+	}
+
+	return securityGroups, nil
+}
+
+// describeSecurityGroups retrieves every page of security groups matching the input and adds them to the cache.
+func (provider *ResourceProvider) describeSecurityGroups(input *ec2.DescribeSecurityGroupsInput) error {
+	var conversionErr error
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeSecurityGroupsPages(input, func(page *ec2.DescribeSecurityGroupsOutput, _ bool) bool {
+			for _, sg := range page.SecurityGroups {
+				securityGroup, err := newSecurityGroupFromAPI(sg)
+				if err != nil {
+					conversionErr = err
+					return false
+				}
+
+				provider.cache.put(reachAWS.ResourceKindSecurityGroup, securityGroup.ID, securityGroup)
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	return conversionErr
 }
 
-func newSecurityGroupFromAPI(securityGroup *ec2.SecurityGroup) reachAWS.SecurityGroup {
-	inboundRules := securityGroupRules(securityGroup.IpPermissions)
-	outboundRules := securityGroupRules(securityGroup.IpPermissionsEgress)
+func newSecurityGroupFromAPI(securityGroup *ec2.SecurityGroup) (reachAWS.SecurityGroup, error) {
+	id := aws.StringValue(securityGroup.GroupId)
+
+	inboundRules, err := securityGroupRules(securityGroup.IpPermissions)
+	if err != nil {
+		return reachAWS.SecurityGroup{}, fmt.Errorf("unable to use inbound rules for security group '%s': %v", id, err)
+	}
+
+	outboundRules, err := securityGroupRules(securityGroup.IpPermissionsEgress)
+	if err != nil {
+		return reachAWS.SecurityGroup{}, fmt.Errorf("unable to use outbound rules for security group '%s': %v", id, err)
+	}
 
 	return reachAWS.SecurityGroup{
-		ID:            aws.StringValue(securityGroup.GroupId),
+		ID:            id,
 		NameTag:       nameTag(securityGroup.Tags),
 		GroupName:     aws.StringValue(securityGroup.GroupName),
+		OwnerID:       aws.StringValue(securityGroup.OwnerId),
 		VPCID:         aws.StringValue(securityGroup.VpcId),
 		InboundRules:  inboundRules,
 		OutboundRules: outboundRules,
-	}
+	}, nil
 }
 
-func securityGroupRules(inputRules []*ec2.IpPermission) []reachAWS.SecurityGroupRule {
+func securityGroupRules(inputRules []*ec2.IpPermission) ([]reachAWS.SecurityGroupRule, error) {
 	if inputRules == nil {
-		return nil
+		return nil, nil
 	}
 
 	rules := make([]reachAWS.SecurityGroupRule, len(inputRules))
 
 	for i, inputRule := range inputRules {
 		if inputRule != nil {
-			rules[i] = securityGroupRule(inputRule)
+			rule, err := securityGroupRule(inputRule)
+			if err != nil {
+				return nil, err
+			}
+
+			rules[i] = rule
 		}
 	}
 
-	return rules
+	return rules, nil
 }
 
-func securityGroupRule(rule *ec2.IpPermission) reachAWS.SecurityGroupRule { // note: this function ignores rule direction (inbound vs. outbound)
+func securityGroupRule(rule *ec2.IpPermission) (reachAWS.SecurityGroupRule, error) { // note: this function ignores rule direction (inbound vs. outbound)
 	if rule == nil {
-		return reachAWS.SecurityGroupRule{}
+		return reachAWS.SecurityGroupRule{}, nil
 	}
 
 	tc, err := trafficContentFromAWSIPPermission(rule)
 	if err != nil {
-		panic(err) // TODO: Better error handling
+		return reachAWS.SecurityGroupRule{}, err
 	}
 
 	// TODO: see if we really need to handle multiple pairs -- the docs don't mention this capability -- https://docs.aws.amazon.com/vpc/latest/userguide/VPC_SecurityGroups.html#SecurityGroupRules
@@ -82,17 +132,23 @@ func securityGroupRule(rule *ec2.IpPermission) reachAWS.SecurityGroupRule { // n
 		targetSecurityGroupReferenceAccountID = securityGroupReferenceAccountID(firstPair)
 	}
 
-	// TODO: Handle prefix lists (and thus VPC endpoints)
-	// for context: https://docs.aws.amazon.com/vpc/latest/userguide/vpce-gateway.html
-
 	targetIPNetworks := ipNetworksFromSecurityGroupRule(rule.IpRanges, rule.Ipv6Ranges)
 
+	var targetPrefixListIDs []string
+
+	for _, prefixListID := range rule.PrefixListIds {
+		if id := aws.StringValue(prefixListID.PrefixListId); id != "" {
+			targetPrefixListIDs = append(targetPrefixListIDs, id)
+		}
+	}
+
 	return reachAWS.SecurityGroupRule{
 		TrafficContent:                        tc,
 		TargetSecurityGroupReferenceID:        targetSecurityGroupReferenceID,
 		TargetSecurityGroupReferenceAccountID: targetSecurityGroupReferenceAccountID,
 		TargetIPNetworks:                      targetIPNetworks,
-	}
+		TargetPrefixListIDs:                   targetPrefixListIDs,
+	}, nil
 }
 
 func newPortSetFromAWSPortRange(portRange *ec2.PortRange) (set.PortSet, error) {
@@ -1,22 +1,31 @@
 package api
 
 import (
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
 	reachAWS "github.com/luhring/reach/reach/aws"
 )
 
+const errCodeSecurityGroupNotFound = "InvalidGroup.NotFound"
+
 // SecurityGroupReference queries the AWS API for a security group matching the given ID, but returns a security group reference representation instead of the full security group representation.
 func (provider *ResourceProvider) SecurityGroupReference(id, accountID string) (*reachAWS.SecurityGroupReference, error) {
-	// TODO: Incorporate account ID in search.
-	// In the meantime, this will be a known bug, where other accounts are not considered.
-
 	sg, err := provider.SecurityGroup(id)
 	if err != nil {
+		// A rule can refer to a security group in a peered VPC that belongs to another AWS account, which we're unable to describe. We still know enough to match on the group's ID and account ID.
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == errCodeSecurityGroupNotFound && accountID != "" {
+			return &reachAWS.SecurityGroupReference{
+				ID:        id,
+				AccountID: accountID,
+			}, nil
+		}
+
 		return nil, err
 	}
 
 	return &reachAWS.SecurityGroupReference{
 		ID:        sg.ID,
-		AccountID: "",
+		AccountID: sg.OwnerID,
 		NameTag:   sg.NameTag,
 		GroupName: sg.GroupName,
 	}, nil
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"fmt"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
 
@@ -9,57 +11,169 @@ import (
 
 // Subnet queries the AWS API for a subnet matching the given ID.
 func (provider *ResourceProvider) Subnet(id string) (*reachAWS.Subnet, error) {
-	input := &ec2.DescribeSubnetsInput{
-		SubnetIds: []*string{
-			aws.String(id),
-		},
-	}
-	result, err := provider.ec2.DescribeSubnets(input)
+	subnets, err := provider.Subnets(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.Subnets), "subnet", id); err != nil {
+	return &subnets[0], nil
+}
+
+// Subnets queries the AWS API for the subnets matching the given IDs, describing any subnets not already retrieved in batches.
+func (provider *ResourceProvider) Subnets(ids ...string) ([]reachAWS.Subnet, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindSubnet, "subnet", ids, func(batch []string) error {
+		subnets, err := provider.describeSubnets(&ec2.DescribeSubnetsInput{
+			SubnetIds: aws.StringSlice(batch),
+		})
+		if err != nil {
+			return err
+		}
+
+		networkACLs, routeTables, err := provider.subnetAssociations(subnets)
+		if err != nil {
+			return err
+		}
+
+		return provider.cacheSubnets(subnets, networkACLs, routeTables)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	awsSubnet := result.Subnets[0]
-	networkACLID, err := provider.networkACLIDFromSubnetID(aws.StringValue(awsSubnet.SubnetId))
+	subnets := make([]reachAWS.Subnet, len(resources))
+	for i, resource := range resources {
+		subnets[i] = resource.(reachAWS.Subnet)
+	}
+
+	return subnets, nil
+}
+
+// describeSubnets retrieves every page of subnets matching the input. Unlike other describe methods, it doesn't add the subnets to the cache, since a subnet can't be represented until its network ACL and route table are known (see cacheSubnets).
+func (provider *ResourceProvider) describeSubnets(input *ec2.DescribeSubnetsInput) ([]*ec2.Subnet, error) {
+	var result []*ec2.Subnet
+	var vpcIDs []string
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeSubnetsPages(input, func(page *ec2.DescribeSubnetsOutput, _ bool) bool {
+			for _, subnet := range page.Subnets {
+				result = append(result, subnet)
+				vpcIDs = append(vpcIDs, aws.StringValue(subnet.VpcId))
+			}
+
+			return true
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	subnet := newSubnetFromAPI(result.Subnets[0], networkACLID)
-	return &subnet, nil
+	if err := provider.prefetch(vpcIDs); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func newSubnetFromAPI(subnet *ec2.Subnet, networkACLID string) reachAWS.Subnet {
-	return reachAWS.Subnet{
-		ID:           aws.StringValue(subnet.SubnetId),
-		NetworkACLID: networkACLID,
-		VPCID:        aws.StringValue(subnet.VpcId),
+// subnetAssociations retrieves the network ACLs and route tables that apply to the given subnets, including the main route table of each subnet's VPC, for subnets that have no explicitly associated route table.
+func (provider *ResourceProvider) subnetAssociations(subnets []*ec2.Subnet) ([]*ec2.NetworkAcl, []*ec2.RouteTable, error) {
+	var subnetIDs, vpcIDs []string
+	seenVPCs := make(map[string]bool)
+
+	for _, subnet := range subnets {
+		subnetIDs = append(subnetIDs, aws.StringValue(subnet.SubnetId))
+
+		if vpcID := aws.StringValue(subnet.VpcId); !seenVPCs[vpcID] {
+			seenVPCs[vpcID] = true
+			vpcIDs = append(vpcIDs, vpcID)
+		}
 	}
-}
 
-func (provider *ResourceProvider) networkACLIDFromSubnetID(id string) (string, error) {
-	input := &ec2.DescribeNetworkAclsInput{
-		Filters: []*ec2.Filter{
-			{
-				Name: aws.String("association.subnet-id"),
-				Values: []*string{
-					aws.String(id),
-				},
+	var networkACLs []*ec2.NetworkAcl
+	var routeTables []*ec2.RouteTable
+
+	for _, batch := range batches(subnetIDs) {
+		acls, err := provider.describeNetworkACLs(&ec2.DescribeNetworkAclsInput{
+			Filters: []*ec2.Filter{filter("association.subnet-id", batch...)},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		networkACLs = append(networkACLs, acls...)
+
+		rtbs, err := provider.describeRouteTables(&ec2.DescribeRouteTablesInput{
+			Filters: []*ec2.Filter{filter("association.subnet-id", batch...)},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		routeTables = append(routeTables, rtbs...)
+	}
+
+	for _, batch := range batches(vpcIDs) {
+		rtbs, err := provider.describeRouteTables(&ec2.DescribeRouteTablesInput{
+			Filters: []*ec2.Filter{
+				filter("vpc-id", batch...),
+				filter("association.main", "true"),
 			},
-		},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		routeTables = append(routeTables, rtbs...)
 	}
-	result, err := provider.ec2.DescribeNetworkAcls(input)
-	if err != nil {
-		return "", err
+
+	return networkACLs, routeTables, nil
+}
+
+// cacheSubnets adds the given subnets to the cache, using the given network ACLs and route tables to determine each subnet's associations.
+func (provider *ResourceProvider) cacheSubnets(subnets []*ec2.Subnet, networkACLs []*ec2.NetworkAcl, routeTables []*ec2.RouteTable) error {
+	networkACLIDs := make(map[string]string) // subnet ID -> network ACL ID
+	for _, acl := range networkACLs {
+		for _, association := range acl.Associations {
+			networkACLIDs[aws.StringValue(association.SubnetId)] = aws.StringValue(acl.NetworkAclId)
+		}
 	}
 
-	if err = ensureSingleResult(len(result.NetworkAcls), "network ACL (via subnet)", id); err != nil {
-		return "", err
+	routeTableIDs := make(map[string]string)     // subnet ID -> route table ID
+	mainRouteTableIDs := make(map[string]string) // VPC ID -> route table ID
+	for _, rtb := range routeTables {
+		for _, association := range rtb.Associations {
+			if aws.BoolValue(association.Main) {
+				mainRouteTableIDs[aws.StringValue(rtb.VpcId)] = aws.StringValue(rtb.RouteTableId)
+			} else if subnetID := aws.StringValue(association.SubnetId); subnetID != "" {
+				routeTableIDs[subnetID] = aws.StringValue(rtb.RouteTableId)
+			}
+		}
 	}
 
-	return aws.StringValue(result.NetworkAcls[0].NetworkAclId), nil
+	for _, s := range subnets {
+		id := aws.StringValue(s.SubnetId)
+
+		networkACLID, exists := networkACLIDs[id]
+		if !exists {
+			return fmt.Errorf("AWS API did not return a network ACL associated with subnet '%s'", id)
+		}
+
+		routeTableID, exists := routeTableIDs[id]
+		if !exists {
+			// subnets without an explicit association use the VPC's main route table
+			if routeTableID, exists = mainRouteTableIDs[aws.StringValue(s.VpcId)]; !exists {
+				return fmt.Errorf("AWS API did not return a route table associated with subnet '%s'", id)
+			}
+		}
+
+		subnet := newSubnetFromAPI(s, networkACLID, routeTableID)
+		provider.cache.put(reachAWS.ResourceKindSubnet, subnet.ID, subnet)
+	}
+
+	return nil
+}
+
+func newSubnetFromAPI(subnet *ec2.Subnet, networkACLID, routeTableID string) reachAWS.Subnet {
+	return reachAWS.Subnet{
+		ID:           aws.StringValue(subnet.SubnetId),
+		NetworkACLID: networkACLID,
+		RouteTableID: routeTableID,
+		VPCID:        aws.StringValue(subnet.VpcId),
+	}
 }
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// TargetGroup queries the AWS API for a target group matching the given ARN, including the targets registered with the group.
+func (provider *ResourceProvider) TargetGroup(id string) (*reachAWS.TargetGroup, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindTargetGroup, id); exists {
+		targetGroup := cached.(reachAWS.TargetGroup)
+		return &targetGroup, nil
+	}
+
+	input := &elbv2.DescribeTargetGroupsInput{
+		TargetGroupArns: []*string{
+			aws.String(id),
+		},
+	}
+	var result *elbv2.DescribeTargetGroupsOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.elbv2.DescribeTargetGroups(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.TargetGroups), "target group", id); err != nil {
+		return nil, err
+	}
+
+	targets, err := provider.targetGroupTargets(id)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroup := newTargetGroupFromAPI(result.TargetGroups[0], targets)
+	provider.cache.put(reachAWS.ResourceKindTargetGroup, id, targetGroup)
+	return &targetGroup, nil
+}
+
+func (provider *ResourceProvider) targetGroupTargets(id string) ([]reachAWS.TargetGroupTarget, error) {
+	var result *elbv2.DescribeTargetHealthOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.elbv2.DescribeTargetHealth(&elbv2.DescribeTargetHealthInput{
+			TargetGroupArn: aws.String(id),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var targets []reachAWS.TargetGroupTarget
+
+	for _, description := range result.TargetHealthDescriptions {
+		if description == nil || description.Target == nil {
+			continue
+		}
+
+		targets = append(targets, reachAWS.TargetGroupTarget{
+			ID:   aws.StringValue(description.Target.Id),
+			Port: aws.Int64Value(description.Target.Port),
+		})
+	}
+
+	return targets, nil
+}
+
+func newTargetGroupFromAPI(tg *elbv2.TargetGroup, targets []reachAWS.TargetGroupTarget) reachAWS.TargetGroup {
+	return reachAWS.TargetGroup{
+		ID:                  aws.StringValue(tg.TargetGroupArn),
+		TargetGroupName:     aws.StringValue(tg.TargetGroupName),
+		Protocol:            aws.StringValue(tg.Protocol),
+		Port:                aws.Int64Value(tg.Port),
+		TargetType:          aws.StringValue(tg.TargetType),
+		VPCID:               aws.StringValue(tg.VpcId),
+		HealthCheckProtocol: aws.StringValue(tg.HealthCheckProtocol),
+		HealthCheckPort:     aws.StringValue(tg.HealthCheckPort),
+		Targets:             targets,
+	}
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// TransitGateway queries the AWS API for a transit gateway matching the given ID.
+func (provider *ResourceProvider) TransitGateway(id string) (*reachAWS.TransitGateway, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindTransitGateway, id); exists {
+		tgw := cached.(reachAWS.TransitGateway)
+		return &tgw, nil
+	}
+
+	input := &ec2.DescribeTransitGatewaysInput{
+		TransitGatewayIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeTransitGatewaysOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeTransitGateways(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.TransitGateways), "transit gateway", id); err != nil {
+		return nil, err
+	}
+
+	tgw := newTransitGatewayFromAPI(result.TransitGateways[0])
+	provider.cache.put(reachAWS.ResourceKindTransitGateway, id, tgw)
+	return &tgw, nil
+}
+
+func newTransitGatewayFromAPI(tgw *ec2.TransitGateway) reachAWS.TransitGateway {
+	return reachAWS.TransitGateway{
+		ID:      aws.StringValue(tgw.TransitGatewayId),
+		NameTag: nameTag(tgw.Tags),
+		OwnerID: aws.StringValue(tgw.OwnerId),
+		State:   aws.StringValue(tgw.State),
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// TransitGatewayVPCAttachment queries the AWS API for the attachment that connects the given VPC to the given transit gateway. It returns nil (without an error) if the VPC isn't attached to the transit gateway.
+func (provider *ResourceProvider) TransitGatewayVPCAttachment(transitGatewayID, vpcID string) (*reachAWS.TransitGatewayAttachment, error) {
+	key := transitGatewayID + "/" + vpcID // attachments are looked up by transit gateway and VPC rather than by their own ID
+
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindTransitGatewayAttachment, key); exists {
+		attachment := cached.(reachAWS.TransitGatewayAttachment)
+		return &attachment, nil
+	}
+
+	input := &ec2.DescribeTransitGatewayAttachmentsInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("transit-gateway-id"),
+				Values: []*string{aws.String(transitGatewayID)},
+			},
+			{
+				Name:   aws.String("resource-type"),
+				Values: []*string{aws.String(ec2.TransitGatewayAttachmentResourceTypeVpc)},
+			},
+			{
+				Name:   aws.String("resource-id"),
+				Values: []*string{aws.String(vpcID)},
+			},
+		},
+	}
+	var attachments []*ec2.TransitGatewayAttachment
+	err := provider.request(func() error {
+		return provider.ec2.DescribeTransitGatewayAttachmentsPages(input, func(page *ec2.DescribeTransitGatewayAttachmentsOutput, _ bool) bool {
+			attachments = append(attachments, page.TransitGatewayAttachments...)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if err = ensureSingleResult(len(attachments), "transit gateway attachment", vpcID); err != nil {
+		return nil, err
+	}
+
+	attachment := newTransitGatewayAttachmentFromAPI(attachments[0])
+	provider.cache.put(reachAWS.ResourceKindTransitGatewayAttachment, key, attachment)
+	return &attachment, nil
+}
+
+func newTransitGatewayAttachmentFromAPI(attachment *ec2.TransitGatewayAttachment) reachAWS.TransitGatewayAttachment {
+	var routeTableID string
+	if attachment.Association != nil {
+		routeTableID = aws.StringValue(attachment.Association.TransitGatewayRouteTableId)
+	}
+
+	return reachAWS.TransitGatewayAttachment{
+		ID:                     aws.StringValue(attachment.TransitGatewayAttachmentId),
+		TransitGatewayID:       aws.StringValue(attachment.TransitGatewayId),
+		ResourceType:           aws.StringValue(attachment.ResourceType),
+		ResourceID:             aws.StringValue(attachment.ResourceId),
+		ResourceOwnerID:        aws.StringValue(attachment.ResourceOwnerId),
+		State:                  aws.StringValue(attachment.State),
+		AssociatedRouteTableID: routeTableID,
+	}
+}
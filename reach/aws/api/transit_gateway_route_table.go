@@ -0,0 +1,142 @@
+package api
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// maxTransitGatewayRoutes is the largest number of routes that the AWS API returns from a search of a transit gateway route table.
+const maxTransitGatewayRoutes = 1000
+
+// TransitGatewayRouteTable queries the AWS API for a transit gateway route table matching the given ID, including the table's routes.
+func (provider *ResourceProvider) TransitGatewayRouteTable(id string) (*reachAWS.TransitGatewayRouteTable, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindTransitGatewayRouteTable, id); exists {
+		routeTable := cached.(reachAWS.TransitGatewayRouteTable)
+		return &routeTable, nil
+	}
+
+	input := &ec2.DescribeTransitGatewayRouteTablesInput{
+		TransitGatewayRouteTableIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeTransitGatewayRouteTablesOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeTransitGatewayRouteTables(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.TransitGatewayRouteTables), "transit gateway route table", id); err != nil {
+		return nil, err
+	}
+
+	routes, err := provider.transitGatewayRoutes(id)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTable := reachAWS.TransitGatewayRouteTable{
+		ID:               aws.StringValue(result.TransitGatewayRouteTables[0].TransitGatewayRouteTableId),
+		TransitGatewayID: aws.StringValue(result.TransitGatewayRouteTables[0].TransitGatewayId),
+		Routes:           routes,
+	}
+	provider.cache.put(reachAWS.ResourceKindTransitGatewayRouteTable, id, routeTable)
+	return &routeTable, nil
+}
+
+// transitGatewayRoutes retrieves the active and blackhole routes of the transit gateway route table with the given ID. Routes whose destination is a managed prefix list become one route for each of the prefix list's CIDR blocks. The API returns a limited number of routes, without a way to request more, so transitGatewayRoutes returns an error instead of an incomplete set of routes.
+func (provider *ResourceProvider) transitGatewayRoutes(routeTableID string) ([]reachAWS.TransitGatewayRoute, error) {
+	input := &ec2.SearchTransitGatewayRoutesInput{
+		TransitGatewayRouteTableId: aws.String(routeTableID),
+		Filters: []*ec2.Filter{
+			{
+				Name: aws.String("state"),
+				Values: []*string{
+					aws.String(ec2.TransitGatewayRouteStateActive),
+					aws.String(ec2.TransitGatewayRouteStateBlackhole),
+				},
+			},
+		},
+		MaxResults: aws.Int64(maxTransitGatewayRoutes),
+	}
+	var result *ec2.SearchTransitGatewayRoutesOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.SearchTransitGatewayRoutes(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if aws.BoolValue(result.AdditionalRoutesAvailable) {
+		return nil, fmt.Errorf("transit gateway route table '%s' has more than %d routes, which can't all be retrieved", routeTableID, maxTransitGatewayRoutes)
+	}
+
+	var routes []reachAWS.TransitGatewayRoute
+
+	for _, inputRoute := range result.Routes {
+		if inputRoute == nil {
+			continue
+		}
+
+		route := transitGatewayRoute(inputRoute)
+
+		if id := aws.StringValue(inputRoute.PrefixListId); id != "" {
+			prefixList, err := provider.ManagedPrefixList(id)
+			if err != nil {
+				return nil, err
+			}
+
+			route.DestinationPrefixListID = id
+
+			for _, entry := range prefixList.Entries {
+				route.Destination = entry.CIDR
+				routes = append(routes, route)
+			}
+			continue
+		}
+
+		if route.Destination == nil {
+			continue
+		}
+
+		routes = append(routes, route)
+	}
+
+	return routes, nil
+}
+
+func transitGatewayRoute(route *ec2.TransitGatewayRoute) reachAWS.TransitGatewayRoute {
+	var destination *net.IPNet
+	if cidr := aws.StringValue(route.DestinationCidrBlock); cidr != "" {
+		_, destination, _ = net.ParseCIDR(cidr)
+	}
+
+	var attachments []reachAWS.TransitGatewayRouteAttachment
+	for _, attachment := range route.TransitGatewayAttachments {
+		if attachment == nil {
+			continue
+		}
+
+		attachments = append(attachments, reachAWS.TransitGatewayRouteAttachment{
+			ID:           aws.StringValue(attachment.TransitGatewayAttachmentId),
+			ResourceType: aws.StringValue(attachment.ResourceType),
+			ResourceID:   aws.StringValue(attachment.ResourceId),
+		})
+	}
+
+	return reachAWS.TransitGatewayRoute{
+		Destination: destination,
+		Attachments: attachments,
+		Type:        aws.StringValue(route.Type),
+		State:       aws.StringValue(route.State),
+	}
+}
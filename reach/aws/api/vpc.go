@@ -11,22 +11,40 @@ import (
 
 // VPC queries the AWS API for a VPC matching the given ID.
 func (provider *ResourceProvider) VPC(id string) (*reachAWS.VPC, error) {
-	input := &ec2.DescribeVpcsInput{
-		VpcIds: []*string{
-			aws.String(id),
-		},
-	}
-	result, err := provider.ec2.DescribeVpcs(input)
+	vpcs, err := provider.VPCs(id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ensureSingleResult(len(result.Vpcs), "VPC", id); err != nil {
+	return &vpcs[0], nil
+}
+
+// VPCs queries the AWS API for the VPCs matching the given IDs, describing any VPCs not already retrieved in batches.
+func (provider *ResourceProvider) VPCs(ids ...string) ([]reachAWS.VPC, error) {
+	resources, err := provider.lookup(reachAWS.ResourceKindVPC, "VPC", ids, func(batch []string) error {
+		return provider.request(func() error {
+			return provider.ec2.DescribeVpcsPages(&ec2.DescribeVpcsInput{
+				VpcIds: aws.StringSlice(batch),
+			}, func(page *ec2.DescribeVpcsOutput, _ bool) bool {
+				for _, v := range page.Vpcs {
+					vpc := newVPCFromAPI(v)
+					provider.cache.put(reachAWS.ResourceKindVPC, vpc.ID, vpc)
+				}
+
+				return true
+			})
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	vpc := newVPCFromAPI(result.Vpcs[0])
-	return &vpc, nil
+	vpcs := make([]reachAWS.VPC, len(resources))
+	for i, resource := range resources {
+		vpcs[i] = resource.(reachAWS.VPC)
+	}
+
+	return vpcs, nil
 }
 
 func newVPCFromAPI(vpc *ec2.Vpc) reachAWS.VPC {
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// VPCEndpoint queries the AWS API for a VPC endpoint matching the given ID.
+func (provider *ResourceProvider) VPCEndpoint(id string) (*reachAWS.VPCEndpoint, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindVPCEndpoint, id); exists {
+		vpce := cached.(reachAWS.VPCEndpoint)
+		return &vpce, nil
+	}
+
+	input := &ec2.DescribeVpcEndpointsInput{
+		VpcEndpointIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeVpcEndpointsOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeVpcEndpoints(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.VpcEndpoints), "VPC endpoint", id); err != nil {
+		return nil, err
+	}
+
+	vpce := newVPCEndpointFromAPI(result.VpcEndpoints[0])
+	provider.cache.put(reachAWS.ResourceKindVPCEndpoint, id, vpce)
+	return &vpce, nil
+}
+
+// AllVPCEndpoints queries the AWS API for all VPC endpoints.
+func (provider *ResourceProvider) AllVPCEndpoints() ([]reachAWS.VPCEndpoint, error) {
+	var endpoints []reachAWS.VPCEndpoint
+
+	err := provider.request(func() error {
+		return provider.ec2.DescribeVpcEndpointsPages(&ec2.DescribeVpcEndpointsInput{}, func(page *ec2.DescribeVpcEndpointsOutput, _ bool) bool {
+			for _, e := range page.VpcEndpoints {
+				vpce := newVPCEndpointFromAPI(e)
+				provider.cache.put(reachAWS.ResourceKindVPCEndpoint, vpce.ID, vpce)
+				endpoints = append(endpoints, vpce)
+			}
+
+			return true
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get all VPC endpoints: %v", err)
+	}
+
+	return endpoints, nil
+}
+
+func newVPCEndpointFromAPI(vpce *ec2.VpcEndpoint) reachAWS.VPCEndpoint {
+	var securityGroupIDs []string
+	for _, group := range vpce.Groups {
+		securityGroupIDs = append(securityGroupIDs, aws.StringValue(group.GroupId))
+	}
+
+	return reachAWS.VPCEndpoint{
+		ID:                         aws.StringValue(vpce.VpcEndpointId),
+		NameTag:                    nameTag(vpce.Tags),
+		Type:                       aws.StringValue(vpce.VpcEndpointType),
+		ServiceName:                aws.StringValue(vpce.ServiceName),
+		State:                      aws.StringValue(vpce.State),
+		VPCID:                      aws.StringValue(vpce.VpcId),
+		SubnetIDs:                  aws.StringValueSlice(vpce.SubnetIds),
+		SecurityGroupIDs:           securityGroupIDs,
+		ElasticNetworkInterfaceIDs: aws.StringValueSlice(vpce.NetworkInterfaceIds),
+		RouteTableIDs:              aws.StringValueSlice(vpce.RouteTableIds),
+		PolicyDocument:             aws.StringValue(vpce.PolicyDocument),
+	}
+}
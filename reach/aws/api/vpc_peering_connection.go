@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	reachAWS "github.com/luhring/reach/reach/aws"
+)
+
+// VPCPeeringConnection queries the AWS API for a VPC peering connection matching the given ID.
+func (provider *ResourceProvider) VPCPeeringConnection(id string) (*reachAWS.VPCPeeringConnection, error) {
+	if cached, exists := provider.cache.get(reachAWS.ResourceKindVPCPeeringConnection, id); exists {
+		pcx := cached.(reachAWS.VPCPeeringConnection)
+		return &pcx, nil
+	}
+
+	input := &ec2.DescribeVpcPeeringConnectionsInput{
+		VpcPeeringConnectionIds: []*string{
+			aws.String(id),
+		},
+	}
+	var result *ec2.DescribeVpcPeeringConnectionsOutput
+	err := provider.request(func() (err error) {
+		result, err = provider.ec2.DescribeVpcPeeringConnections(input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ensureSingleResult(len(result.VpcPeeringConnections), "VPC peering connection", id); err != nil {
+		return nil, err
+	}
+
+	pcx := newVPCPeeringConnectionFromAPI(result.VpcPeeringConnections[0])
+	provider.cache.put(reachAWS.ResourceKindVPCPeeringConnection, id, pcx)
+	return &pcx, nil
+}
+
+func newVPCPeeringConnectionFromAPI(pcx *ec2.VpcPeeringConnection) reachAWS.VPCPeeringConnection {
+	var status string
+	if pcx.Status != nil {
+		status = aws.StringValue(pcx.Status.Code)
+	}
+
+	requester := pcx.RequesterVpcInfo
+	if requester == nil {
+		requester = &ec2.VpcPeeringConnectionVpcInfo{}
+	}
+
+	accepter := pcx.AccepterVpcInfo
+	if accepter == nil {
+		accepter = &ec2.VpcPeeringConnectionVpcInfo{}
+	}
+
+	return reachAWS.VPCPeeringConnection{
+		ID:               aws.StringValue(pcx.VpcPeeringConnectionId),
+		NameTag:          nameTag(pcx.Tags),
+		Status:           status,
+		RequesterVPCID:   aws.StringValue(requester.VpcId),
+		RequesterOwnerID: aws.StringValue(requester.OwnerId),
+		RequesterRegion:  aws.StringValue(requester.Region),
+		AccepterVPCID:    aws.StringValue(accepter.VpcId),
+		AccepterOwnerID:  aws.StringValue(accepter.OwnerId),
+		AccepterRegion:   aws.StringValue(accepter.Region),
+	}
+}
@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindAWSService specifies the unique name for the AWS service kind of resource.
+const ResourceKindAWSService = "AWSService"
+
+// awsServiceShorthand matches a shorthand reference to an AWS service in a region, such as "s3:us-east-1".
+var awsServiceShorthand = regexp.MustCompile(`^([a-z0-9-]+):([a-z]{2}(-gov)?-[a-z]+-[0-9])$`)
+
+// An AWSService resource representation, for an AWS service that's reachable through a gateway VPC endpoint (such as S3 or DynamoDB). The service's ID is its service name, and its IP networks are the entries of the AWS-managed prefix list for the service.
+type AWSService struct {
+	Name         string // e.g. "com.amazonaws.us-east-1.s3"
+	PrefixListID string
+	IPNetworks   []*net.IPNet
+}
+
+// ToResource returns the AWS service converted to a generalized Reach resource.
+func (service AWSService) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindAWSService,
+		Properties: service,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the AWS service.
+func (service AWSService) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindAWSService,
+		ID:     service.Name,
+	}
+}
+
+// AWSServiceName returns the full service name (e.g. "com.amazonaws.us-east-1.s3") for the given shorthand reference to an AWS service (e.g. "s3:us-east-1"). The second return value is false if the identifier isn't a shorthand reference to an AWS service.
+func AWSServiceName(identifier string) (string, bool) {
+	match := awsServiceShorthand.FindStringSubmatch(identifier)
+	if match == nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("com.amazonaws.%s.%s", match[2], match[1]), true
+}
@@ -0,0 +1,31 @@
+package aws
+
+import (
+	"github.com/luhring/reach/reach"
+)
+
+// dependencyWorkers is the maximum number of dependency lookups that a single resource performs at the same time. Because lookups resolve their own dependencies in turn, this doesn't bound the total number of requests made to the provider; providers that make API requests limit those themselves (see api.ResourceProvider).
+const dependencyWorkers = 8
+
+// A dependencyLookup retrieves one resource (or group of resources) that another resource depends on.
+type dependencyLookup func() (*reach.ResourceCollection, error)
+
+// resolveDependencies performs the given lookups concurrently and merges their results into a single collection.
+func resolveDependencies(lookups ...dependencyLookup) (*reach.ResourceCollection, error) {
+	rc := reach.NewResourceCollection()
+
+	err := reach.ForEachConcurrently(len(lookups), dependencyWorkers, func(i int) error {
+		dependencies, err := lookups[i]()
+		if err != nil {
+			return err
+		}
+
+		rc.Merge(dependencies)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rc, nil
+}
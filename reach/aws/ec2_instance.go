@@ -13,7 +13,8 @@ const ResourceKindEC2Instance = "EC2Instance"
 // An EC2Instance resource representation.
 type EC2Instance struct {
 	ID                          string
-	NameTag                     string `json:"NameTag,omitempty"`
+	NameTag                     string            `json:"NameTag,omitempty"`
+	Tags                        map[string]string `json:"Tags,omitempty"`
 	State                       string
 	NetworkInterfaceAttachments []NetworkInterfaceAttachment
 }
@@ -49,19 +50,18 @@ func (i EC2Instance) elasticNetworkInterfaceIDs() []string {
 	return ids
 }
 
-// Dependencies returns a collection of the EC2 instance's resource dependencies.
+// Dependencies returns a collection of the EC2 instance's resource dependencies. The dependencies of each network interface attachment are retrieved concurrently.
 func (i EC2Instance) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
-	rc := reach.NewResourceCollection()
+	lookups := make([]dependencyLookup, len(i.NetworkInterfaceAttachments))
 
-	for _, attachment := range i.NetworkInterfaceAttachments {
-		attachmentDependencies, err := attachment.Dependencies(provider)
-		if err != nil {
-			return nil, err
+	for j, attachment := range i.NetworkInterfaceAttachments {
+		attachment := attachment
+		lookups[j] = func() (*reach.ResourceCollection, error) {
+			return attachment.Dependencies(provider)
 		}
-		rc.Merge(attachmentDependencies)
 	}
 
-	return rc, nil
+	return resolveDependencies(lookups...)
 }
 
 func (i EC2Instance) networkPoints(rc *reach.ResourceCollection) []reach.NetworkPoint {
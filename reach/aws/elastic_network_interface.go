@@ -15,6 +15,7 @@ const ResourceKindElasticNetworkInterface = "ElasticNetworkInterface"
 type ElasticNetworkInterface struct {
 	ID                   string
 	NameTag              string `json:"NameTag,omitempty"`
+	OwnerID              string `json:"OwnerID,omitempty"`
 	SubnetID             string
 	VPCID                string
 	SecurityGroupIDs     []string
@@ -52,55 +53,67 @@ func (eni ElasticNetworkInterface) ToResourceReference() reach.ResourceReference
 	}
 }
 
-// Dependencies returns a collection of the elastic network interface's resource dependencies.
+// Dependencies returns a collection of the elastic network interface's resource dependencies. The subnet, VPC and security groups are retrieved concurrently.
 func (eni ElasticNetworkInterface) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
-	rc := reach.NewResourceCollection()
-
-	subnet, err := provider.Subnet(eni.SubnetID)
-	if err != nil {
-		return nil, err
-	}
-	rc.Put(reach.ResourceReference{
-		Domain: ResourceDomainAWS,
-		Kind:   ResourceKindSubnet,
-		ID:     subnet.ID,
-	}, subnet.ToResource())
-
-	subnetDependencies, err := subnet.Dependencies(provider)
-	if err != nil {
-		return nil, err
+	lookups := []dependencyLookup{
+		func() (*reach.ResourceCollection, error) {
+			subnet, err := provider.Subnet(eni.SubnetID)
+			if err != nil {
+				return nil, err
+			}
+
+			rc, err := subnet.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(reach.ResourceReference{
+				Domain: ResourceDomainAWS,
+				Kind:   ResourceKindSubnet,
+				ID:     subnet.ID,
+			}, subnet.ToResource())
+
+			return rc, nil
+		},
+		func() (*reach.ResourceCollection, error) {
+			vpc, err := provider.VPC(eni.VPCID)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := reach.NewResourceCollection()
+			rc.Put(reach.ResourceReference{
+				Domain: ResourceDomainAWS,
+				Kind:   ResourceKindVPC,
+				ID:     vpc.ID,
+			}, vpc.ToResource())
+
+			return rc, nil
+		},
 	}
-	rc.Merge(subnetDependencies)
-
-	vpc, err := provider.VPC(eni.VPCID)
-	if err != nil {
-		return nil, err
-	}
-	rc.Put(reach.ResourceReference{
-		Domain: ResourceDomainAWS,
-		Kind:   ResourceKindVPC,
-		ID:     vpc.ID,
-	}, vpc.ToResource())
 
 	for _, sgID := range eni.SecurityGroupIDs {
-		sg, err := provider.SecurityGroup(sgID)
-		if err != nil {
-			return nil, err
-		}
-		rc.Put(reach.ResourceReference{
-			Domain: ResourceDomainAWS,
-			Kind:   ResourceKindSecurityGroup,
-			ID:     sg.ID,
-		}, sg.ToResource())
-
-		sgDependencies, err := sg.Dependencies(provider)
-		if err != nil {
-			return nil, err
-		}
-		rc.Merge(sgDependencies)
+		sgID := sgID
+		lookups = append(lookups, func() (*reach.ResourceCollection, error) {
+			sg, err := provider.SecurityGroup(sgID)
+			if err != nil {
+				return nil, err
+			}
+
+			rc, err := sg.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(reach.ResourceReference{
+				Domain: ResourceDomainAWS,
+				Kind:   ResourceKindSecurityGroup,
+				ID:     sg.ID,
+			}, sg.ToResource())
+
+			return rc, nil
+		})
 	}
 
-	return rc, nil
+	return resolveDependencies(lookups...)
 }
 
 func (eni ElasticNetworkInterface) getNetworkPoints(parent reach.ResourceReference) []reach.NetworkPoint {
@@ -139,6 +152,46 @@ func (eni ElasticNetworkInterface) getNetworkPoints(parent reach.ResourceReferen
 	return networkPoints
 }
 
+// HasIPAddress returns a boolean indicating whether or not the given IP address is one of the network interface's private, public or IPv6 addresses.
+func (eni ElasticNetworkInterface) HasIPAddress(ip net.IP) bool {
+	addresses := append([]net.IP{eni.PublicIPv4Address}, eni.PrivateIPv4Addresses...)
+	addresses = append(addresses, eni.IPv6Addresses...)
+
+	for _, address := range addresses {
+		if address != nil && address.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ipAddressNetworkPoint returns the network point for the given IP address of the network interface.
+func (eni ElasticNetworkInterface) ipAddressNetworkPoint(ip net.IP) reach.NetworkPoint {
+	return reach.NetworkPoint{
+		IPAddress: ip,
+		Lineage: []reach.ResourceReference{
+			eni.ToResourceReference(),
+		},
+	}
+}
+
+// networkPoints returns a network point for each of the network interface's IP addresses, for a network interface that's analyzed on its own rather than as part of the resource that uses it.
+func (eni ElasticNetworkInterface) networkPoints() []reach.NetworkPoint {
+	var points []reach.NetworkPoint
+
+	addresses := append(append([]net.IP{}, eni.PrivateIPv4Addresses...), eni.PublicIPv4Address)
+	addresses = append(addresses, eni.IPv6Addresses...)
+
+	for _, ip := range addresses {
+		if ip != nil {
+			points = append(points, eni.ipAddressNetworkPoint(ip))
+		}
+	}
+
+	return points
+}
+
 // Name returns the elastic network interface's ID, and, if available, its name tag value.
 func (eni ElasticNetworkInterface) Name() string {
 	if name := strings.TrimSpace(eni.NameTag); name != "" {
@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// SubjectKindElasticNetworkInterface specifies the unique name for the elastic network interface kind of subject. The network interface is analyzed on its own, as every one of its IP addresses, regardless of what resource uses it.
+const SubjectKindElasticNetworkInterface = "ElasticNetworkInterface"
+
+// NewElasticNetworkInterfaceSubject returns a new subject for the specified elastic network interface (identified by its ID, e.g. "eni-0abc123").
+func NewElasticNetworkInterfaceSubject(id string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	if !isElasticNetworkInterfaceID(id) {
+		return nil, reach.NewSubjectError(fmt.Sprintf("invalid elastic network interface ID: '%s'", id))
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindElasticNetworkInterface,
+		ID:     id,
+		Role:   role,
+	}, nil
+}
+
+func isElasticNetworkInterfaceID(text string) bool {
+	const eniIDPrefix = "eni-"
+	return len(text) > len(eniIDPrefix) && strings.HasPrefix(text, eniIDPrefix)
+}
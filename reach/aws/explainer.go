@@ -2,7 +2,6 @@ package aws
 
 import (
 	"fmt"
-	"log"
 	"sort"
 	"strings"
 
@@ -25,22 +24,54 @@ func NewExplainer(analysis reach.Analysis) *Explainer {
 }
 
 // NetworkPoint explains the analysis component for the specified network point.
-func (ex *Explainer) NetworkPoint(point reach.NetworkPoint, p reach.Perspective) string {
+func (ex *Explainer) NetworkPoint(point reach.NetworkPoint, p reach.Perspective) (string, error) {
 	var outputItems []string
 
 	if f, _ := getInstanceStateFactor(point.Factors); f != nil {
 		outputItems = append(outputItems, ex.InstanceState(*f))
 	}
 
+	if f, _ := getRDSInstanceEndpointFactor(point.Factors); f != nil {
+		outputItems = append(outputItems, ex.RDSInstanceEndpoint(*f))
+	}
+
+	if f, _ := getLoadBalancerListenersFactor(point.Factors); f != nil {
+		outputItems = append(outputItems, ex.LoadBalancerListeners(*f))
+	}
+
+	if f, _ := getLoadBalancerTargetsFactor(point.Factors); f != nil {
+		outputItems = append(outputItems, ex.LoadBalancerTargets(*f, p))
+	}
+
+	if f, _ := getVPCEndpointFactor(point.Factors); f != nil && f.Properties.(vpcEndpointFactor).Type == VPCEndpointTypeInterface {
+		outputItems = append(outputItems, ex.VPCEndpoint(*f, p))
+	}
+
 	if f, _ := getSecurityGroupRulesFactor(point.Factors); f != nil {
-		outputItems = append(outputItems, ex.SecurityGroupRules(*f, p))
+		explanation, err := ex.SecurityGroupRules(*f, p)
+		if err != nil {
+			return "", err
+		}
+		outputItems = append(outputItems, explanation)
 	}
 
 	if f, _ := getNetworkACLRulesFactor(point.Factors); f != nil {
 		outputItems = append(outputItems, ex.NetworkACLRules(*f, p))
 	}
 
-	return strings.Join(outputItems, "\n")
+	if f, _ := getRouteTablesFactor(point.Factors); f != nil {
+		outputItems = append(outputItems, ex.RouteTables(*f, p))
+	}
+
+	if f, _ := getNATGatewayFactor(point.Factors); f != nil {
+		outputItems = append(outputItems, ex.NATGateway(*f, p))
+	}
+
+	if f, _ := getVPCEndpointFactor(point.Factors); f != nil && f.Properties.(vpcEndpointFactor).Type != VPCEndpointTypeInterface {
+		outputItems = append(outputItems, ex.VPCEndpoint(*f, p))
+	}
+
+	return strings.Join(outputItems, "\n"), nil
 }
 
 // InstanceState explains the analysis component for the specified instance state factor.
@@ -62,8 +93,98 @@ func (ex *Explainer) InstanceState(factor reach.Factor) string {
 	return strings.Join(outputItems, "\n")
 }
 
+// RDSInstanceEndpoint explains the analysis component for the specified RDS instance endpoint factor.
+func (ex *Explainer) RDSInstanceEndpoint(factor reach.Factor) string {
+	var outputItems []string
+	outputItems = append(outputItems, helper.Bold("database endpoint:"))
+
+	props := factor.Properties.(rdsInstanceEndpointFactor)
+
+	var bodyItems []string
+	bodyItems = append(bodyItems, fmt.Sprintf("status: \"%s\"", props.Status))
+	bodyItems = append(bodyItems, fmt.Sprintf("port: %d", props.Port))
+
+	if !props.Available {
+		bodyItems = append(bodyItems, "database is not accepting connections")
+	}
+
+	bodyItems = append(bodyItems, "")
+	bodyItems = append(bodyItems, "network traffic allowed based on database endpoint:")
+	bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+
+	outputItems = append(outputItems, helper.Indent(strings.Join(bodyItems, "\n"), 2))
+
+	return strings.Join(outputItems, "\n")
+}
+
+// LoadBalancerListeners explains the analysis component for the specified load balancer listeners factor.
+func (ex *Explainer) LoadBalancerListeners(factor reach.Factor) string {
+	var outputItems []string
+	outputItems = append(outputItems, helper.Bold("load balancer listeners:"))
+
+	props := factor.Properties.(loadBalancerListenersFactor)
+
+	var bodyItems []string
+
+	if !props.Active {
+		bodyItems = append(bodyItems, "load balancer is not active")
+	} else if len(props.Listeners) == 0 {
+		bodyItems = append(bodyItems, "no listeners")
+	}
+
+	for _, listener := range props.Listeners {
+		bodyItems = append(bodyItems, fmt.Sprintf("%s on port %d", listener.Protocol, listener.Port))
+	}
+
+	bodyItems = append(bodyItems, "")
+	bodyItems = append(bodyItems, "network traffic allowed based on listeners:")
+	bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+
+	outputItems = append(outputItems, helper.Indent(strings.Join(bodyItems, "\n"), 2))
+
+	return strings.Join(outputItems, "\n")
+}
+
+// LoadBalancerTargets explains the analysis component for the specified load balancer targets factor.
+func (ex *Explainer) LoadBalancerTargets(factor reach.Factor, p reach.Perspective) string {
+	var outputItems []string
+	header := fmt.Sprintf(
+		"%s (including only targets that match %s):",
+		helper.Bold("load balancer targets"),
+		p.OtherRole,
+	)
+	outputItems = append(outputItems, header)
+
+	props := factor.Properties.(loadBalancerTargetsFactor)
+
+	var bodyItems []string
+
+	if !props.Active {
+		bodyItems = append(bodyItems, "load balancer is not active")
+	} else if len(props.Targets) == 0 {
+		bodyItems = append(bodyItems, fmt.Sprintf("the %s is not a registered target of any of the load balancer's target groups", p.OtherRole))
+	}
+
+	for _, component := range props.Targets {
+		targetGroupName := component.TargetGroup.ID
+		if tgRef := ex.analysis.Resources.Get(component.TargetGroup); tgRef != nil {
+			targetGroupName = tgRef.Properties.(TargetGroup).Name()
+		}
+
+		bodyItems = append(bodyItems, fmt.Sprintf("target group \"%s\" (target: %s):", targetGroupName, component.Target.ID))
+		bodyItems = append(bodyItems, helper.Indent(component.Traffic.String(), 2))
+	}
+
+	bodyItems = append(bodyItems, "network traffic allowed based on targets (including health checks):")
+	bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+
+	outputItems = append(outputItems, helper.Indent(strings.Join(bodyItems, "\n"), 2))
+
+	return strings.Join(outputItems, "\n")
+}
+
 // SecurityGroupRules explains the analysis component for the specified security group rules factor.
-func (ex *Explainer) SecurityGroupRules(factor reach.Factor, p reach.Perspective) string {
+func (ex *Explainer) SecurityGroupRules(factor reach.Factor, p reach.Perspective) (string, error) {
 	var outputItems []string
 	header := fmt.Sprintf(
 		"%s (including only rules from %s that match %s):",
@@ -85,13 +206,13 @@ func (ex *Explainer) SecurityGroupRules(factor reach.Factor, p reach.Perspective
 		for _, rule := range rules {
 			sgRef := ex.analysis.Resources.Get(rule.SecurityGroup)
 			if sgRef == nil {
-				log.Fatalf(formatResourceMissing, rule.SecurityGroup)
+				return "", fmt.Errorf(formatResourceMissing, rule.SecurityGroup)
 			}
 
 			sg := sgRef.Properties.(SecurityGroup)
 			originalRule, err := sg.rule(rule.RuleDirection, rule.RuleIndex)
 			if err != nil {
-				log.Fatalf(err.Error())
+				return "", fmt.Errorf("unable to explain analysis for network point: %v", err)
 			}
 
 			var inclusionReason string
@@ -110,6 +231,20 @@ func (ex *Explainer) SecurityGroupRules(factor reach.Factor, p reach.Perspective
 					p.OtherRole,
 					p.Other.IPAddress,
 				)
+			case securityGroupRuleMatchBasisPrefixList:
+				requirement := rule.Match.Requirement.(securityGroupRulePrefixListRequirement)
+				prefixListName := requirement.PrefixListID
+				if resource := ex.analysis.Resources.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindPrefixList, ID: requirement.PrefixListID}); resource != nil {
+					prefixListName = resource.Properties.(PrefixList).Name()
+				}
+
+				inclusionReason = fmt.Sprintf(
+					"This rule specifies a prefix list \"%s\" with an entry \"%s\" that contains the %s's IP address (%s).",
+					prefixListName,
+					requirement.Entry,
+					p.OtherRole,
+					p.Other.IPAddress,
+				)
 			default:
 				inclusionReason = fmt.Sprintf("Unknown reason for inclusion. Match basis is '%s'. Please report this.", rule.Match.Basis)
 			}
@@ -168,7 +303,7 @@ func (ex *Explainer) SecurityGroupRules(factor reach.Factor, p reach.Perspective
 	body := strings.Join(bodyItems, "\n")
 	outputItems = append(outputItems, helper.Indent(body, 2))
 
-	return strings.Join(outputItems, "\n")
+	return strings.Join(outputItems, "\n"), nil
 }
 
 // NetworkACLRules explains the analysis component for the specified network ACL rules factor.
@@ -223,27 +358,317 @@ func (ex *Explainer) NetworkACLRules(factor reach.Factor, p reach.Perspective) s
 	return strings.Join(outputItems, "\n")
 }
 
-// CheckBothInAWS returns a boolean indicating whether both network points in a network vector are AWS resources.
-func (ex Explainer) CheckBothInAWS(v reach.NetworkVector) bool {
-	return IsUsedByNetworkPoint(v.Source) && IsUsedByNetworkPoint(v.Destination)
+// RouteTables explains the analysis component for the specified route tables factor.
+func (ex *Explainer) RouteTables(factor reach.Factor, p reach.Perspective) string {
+	var outputItems []string
+	header := fmt.Sprintf(
+		"%s (used by %s to route network traffic to %s):",
+		helper.Bold("route table"),
+		p.SelfRole,
+		p.OtherRole,
+	)
+	outputItems = append(outputItems, header)
+
+	props := factor.Properties.(routeTablesFactor)
+
+	var bodyItems []string
+
+	bodyItems = append(bodyItems, props.RouteTable.ID)
+
+	if route := props.MatchedRoute; route == nil {
+		bodyItems = append(bodyItems, fmt.Sprintf("no route matches the %s's IP address (%s)\n", p.OtherRole, p.Other.IPAddress))
+	} else {
+		bodyItems = append(bodyItems, fmt.Sprintf("most specific route that matches the %s's IP address (%s):", p.OtherRole, p.Other.IPAddress))
+		bodyItems = append(bodyItems, helper.Indent(route.String(), 2)+"\n")
+
+		if route.Target.Type == RouteTableRouteTargetTypeVPCPeeringConnection {
+			bodyItems = append(bodyItems, ex.VPCPeeringConnection(route.Target.ID))
+		}
+
+		if t := route.Target.Type; t == RouteTableRouteTargetTypeInternetGateway || t == RouteTableRouteTargetTypeEgressOnlyInternetGateway {
+			bodyItems = append(bodyItems, ex.InternetGateway(route.Target, factor.Resource, p))
+		}
+
+		if path := props.TransitGatewayPath; path != nil {
+			bodyItems = append(bodyItems, ex.TransitGatewayPath(*path, p))
+		}
+	}
+
+	if p.SelfRole == reach.SubjectRoleSource {
+		bodyItems = append(bodyItems, "network traffic allowed based on route table:")
+		bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+	} else {
+		bodyItems = append(bodyItems, "return network traffic allowed based on route table:")
+		bodyItems = append(bodyItems, helper.Indent(factor.ReturnTraffic.ColorString(), 2))
+	}
+
+	body := strings.Join(bodyItems, "\n")
+	outputItems = append(outputItems, helper.Indent(body, 2))
+
+	return strings.Join(outputItems, "\n")
+}
+
+// VPCPeeringConnection explains the state of the VPC peering connection with the specified ID.
+func (ex *Explainer) VPCPeeringConnection(id string) string {
+	ref := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindVPCPeeringConnection,
+		ID:     id,
+	}
+
+	pcxResource := ex.analysis.Resources.Get(ref)
+	if pcxResource == nil {
+		return fmt.Sprintf(formatResourceMissing, ref)
+	}
+	pcx := pcxResource.Properties.(VPCPeeringConnection)
+
+	var outputItems []string
+	outputItems = append(outputItems, fmt.Sprintf("VPC peering connection %s:", pcx.Name()))
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("status: \"%s\"", pcx.Status), 2))
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("requester VPC: %s (account %s)", pcx.RequesterVPCID, pcx.RequesterOwnerID), 2))
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("accepter VPC: %s (account %s)", pcx.AccepterVPCID, pcx.AccepterOwnerID), 2)+"\n")
+
+	return strings.Join(outputItems, "\n")
+}
+
+// InternetGateway explains whether the internet gateway (or egress-only internet gateway) targeted by a route is able to carry network traffic for the network interface of the perspective's "self" network point.
+func (ex *Explainer) InternetGateway(target RouteTableRouteTarget, eniRef reach.ResourceReference, p reach.Perspective) string {
+	eniResource := ex.analysis.Resources.Get(eniRef)
+	if eniResource == nil {
+		return fmt.Sprintf(formatResourceMissing, eniRef)
+	}
+	eni := eniResource.Properties.(ElasticNetworkInterface)
+
+	var outputItems []string
+
+	name := target.ID
+	attached := false
+
+	if target.Type == RouteTableRouteTargetTypeInternetGateway {
+		if resource := ex.analysis.Resources.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindInternetGateway, ID: target.ID}); resource != nil {
+			igw := resource.Properties.(InternetGateway)
+			name, attached = igw.Name(), igw.IsAttachedTo(eni.VPCID)
+		}
+		name = "internet gateway " + name
+	} else {
+		if resource := ex.analysis.Resources.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindEgressOnlyInternetGateway, ID: target.ID}); resource != nil {
+			eigw := resource.Properties.(EgressOnlyInternetGateway)
+			name, attached = eigw.Name(), eigw.IsAttachedTo(eni.VPCID)
+		}
+		name = "egress-only internet gateway " + name
+	}
+
+	outputItems = append(outputItems, name+":")
+
+	if attached {
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("attached to the %s's VPC (%s)", p.SelfRole, eni.VPCID), 2))
+	} else {
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("not attached to the %s's VPC (%s)", p.SelfRole, eni.VPCID), 2))
+	}
+
+	switch {
+	case target.Type == RouteTableRouteTargetTypeEgressOnlyInternetGateway:
+		outputItems = append(outputItems, helper.Indent("only carries IPv6 traffic for connections initiated from within the VPC", 2))
+	case p.Other.IPAddress.To4() == nil:
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("IPv6 addresses of the %s's network interface: %d", p.SelfRole, len(eni.IPv6Addresses)), 2))
+	case eni.PublicIPv4Address == nil:
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("the %s's network interface has no public IPv4 address", p.SelfRole), 2))
+	default:
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("public IPv4 address of the %s's network interface: %s", p.SelfRole, eni.PublicIPv4Address), 2))
+	}
+
+	return strings.Join(outputItems, "\n") + "\n"
 }
 
-// CheckBothInSameVPC returns a boolean indicating whether both network points in a network vector reside in the same AWS VPC.
-func (ex Explainer) CheckBothInSameVPC(v reach.NetworkVector) bool {
-	sourceENI, destinationENI, err := GetENIsFromVector(v, ex.analysis.Resources)
-	if err != nil {
-		return false
+// VPCEndpoint explains the analysis component for the specified VPC endpoint factor, including the endpoint's policy.
+func (ex *Explainer) VPCEndpoint(factor reach.Factor, p reach.Perspective) string {
+	var outputItems []string
+	outputItems = append(outputItems, helper.Bold("VPC endpoint:"))
+
+	props := factor.Properties.(vpcEndpointFactor)
+
+	name := factor.Resource.ID
+	if resource := ex.analysis.Resources.Get(factor.Resource); resource != nil {
+		name = resource.Properties.(VPCEndpoint).Name()
+	}
+
+	var bodyItems []string
+	bodyItems = append(bodyItems, name)
+	bodyItems = append(bodyItems, fmt.Sprintf("type: %s", props.Type))
+	bodyItems = append(bodyItems, fmt.Sprintf("service: %s", props.ServiceName))
+	bodyItems = append(bodyItems, fmt.Sprintf("state: \"%s\"", props.State))
+
+	if !props.Available {
+		bodyItems = append(bodyItems, "endpoint is not available")
 	}
 
-	return sourceENI.VPCID == destinationENI.VPCID
+	if props.Type == VPCEndpointTypeInterface && p.SelfRole == reach.SubjectRoleSource {
+		bodyItems = append(bodyItems, "an interface endpoint only accepts connections, and doesn't initiate them")
+	}
+
+	// The policy doesn't affect network traffic, but it often explains why a request that reaches the endpoint is still denied.
+	if props.PolicyDocument == "" {
+		bodyItems = append(bodyItems, "policy: (none)")
+	} else {
+		bodyItems = append(bodyItems, "policy (evaluated by AWS for each request to the service, not by the network):")
+		bodyItems = append(bodyItems, helper.Indent(props.PolicyDocument, 2))
+	}
+
+	bodyItems = append(bodyItems, "")
+	bodyItems = append(bodyItems, "network traffic allowed based on VPC endpoint:")
+	bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+
+	outputItems = append(outputItems, helper.Indent(strings.Join(bodyItems, "\n"), 2))
+
+	return strings.Join(outputItems, "\n")
 }
 
-// CheckBothInSameSubnet returns a boolean indicating whether both network points in a network vector reside in the same AWS subnet.
-func (ex Explainer) CheckBothInSameSubnet(v reach.NetworkVector) bool {
-	sourceENI, destinationENI, err := GetENIsFromVector(v, ex.analysis.Resources)
-	if err != nil {
-		return false
+// NATGateway explains the analysis component for the specified NAT gateway factor, including both legs of the path through the NAT gateway: from the source to the NAT gateway, and from the NAT gateway to the destination.
+func (ex *Explainer) NATGateway(factor reach.Factor, p reach.Perspective) string {
+	var outputItems []string
+	header := fmt.Sprintf(
+		"%s (used by %s to send network traffic to %s):",
+		helper.Bold("NAT gateway"),
+		p.SelfRole,
+		p.OtherRole,
+	)
+	outputItems = append(outputItems, header)
+
+	props := factor.Properties.(natGatewayFactor)
+
+	name := props.NATGateway.ID
+	if resource := ex.analysis.Resources.Get(props.NATGateway); resource != nil {
+		name = resource.Properties.(NATGateway).Name()
 	}
 
-	return sourceENI.SubnetID == destinationENI.SubnetID
+	var bodyItems []string
+	bodyItems = append(bodyItems, name)
+	bodyItems = append(bodyItems, fmt.Sprintf("state: \"%s\"", props.State))
+	bodyItems = append(bodyItems, fmt.Sprintf("translated source IP address: %s\n", props.TranslatedSourceIPAddress))
+
+	// first leg
+	bodyItems = append(bodyItems, fmt.Sprintf("%s to NAT gateway (network ACL %s):", p.SelfRole, props.NetworkACL.ID))
+	if props.SameSubnet {
+		bodyItems = append(bodyItems, helper.Indent(fmt.Sprintf("the NAT gateway is in the %s's subnet, so no network ACL applies", p.SelfRole), 2)+"\n")
+	} else {
+		// The NAT gateway's network ACL sees the "self" network point as the other end of this leg.
+		selfP := reach.Perspective{
+			Self:      p.Self,
+			Other:     p.Self,
+			SelfRole:  p.SelfRole,
+			OtherRole: p.SelfRole,
+		}
+		bodyItems = append(bodyItems, helper.Indent(ex.natGatewayLeg(props.SourceLeg, selfP, "inbound", "outbound"), 2))
+	}
+
+	// second leg
+	bodyItems = append(bodyItems, fmt.Sprintf("NAT gateway to %s (network ACL %s):", p.OtherRole, props.NetworkACL.ID))
+	bodyItems = append(bodyItems, helper.Indent(ex.natGatewayLeg(props.DestinationLeg, p, "outbound", "inbound"), 2))
+
+	bodyItems = append(bodyItems, fmt.Sprintf("route table for the NAT gateway's subnet: %s", props.RouteTable.ID))
+	if route := props.MatchedRoute; route == nil {
+		bodyItems = append(bodyItems, helper.Indent(fmt.Sprintf("no route matches the %s's IP address (%s)", p.OtherRole, p.Other.IPAddress), 2)+"\n")
+	} else {
+		bodyItems = append(bodyItems, helper.Indent(fmt.Sprintf("most specific route that matches the %s's IP address (%s):", p.OtherRole, p.Other.IPAddress), 2))
+		bodyItems = append(bodyItems, helper.Indent(route.String(), 4)+"\n")
+
+		if t := route.Target.Type; t == RouteTableRouteTargetTypeInternetGateway || t == RouteTableRouteTargetTypeEgressOnlyInternetGateway {
+			if resource := ex.analysis.Resources.Get(props.NATGateway); resource != nil {
+				eniRef := reach.ResourceReference{
+					Domain: ResourceDomainAWS,
+					Kind:   ResourceKindElasticNetworkInterface,
+					ID:     resource.Properties.(NATGateway).ElasticNetworkInterfaceID,
+				}
+				natP := p
+				natP.SelfRole = "NAT gateway"
+				bodyItems = append(bodyItems, helper.Indent(ex.InternetGateway(route.Target, eniRef, natP), 2))
+			}
+		}
+	}
+
+	bodyItems = append(bodyItems, "network traffic allowed based on NAT gateway:")
+	bodyItems = append(bodyItems, helper.Indent(factor.Traffic.ColorString(), 2))
+
+	bodyItems = append(bodyItems, "return network traffic allowed based on NAT gateway:")
+	bodyItems = append(bodyItems, helper.Indent(factor.ReturnTraffic.String(), 2))
+
+	body := strings.Join(bodyItems, "\n")
+	outputItems = append(outputItems, helper.Indent(body, 2))
+
+	return strings.Join(outputItems, "\n")
+}
+
+func (ex *Explainer) natGatewayLeg(leg natGatewayLeg, p reach.Perspective, forwardDirection, returnDirection string) string {
+	var outputItems []string
+
+	for _, section := range []struct {
+		direction  string
+		components []networkACLRulesFactorComponent
+	}{
+		{forwardDirection, leg.RuleComponentsForwardDirection},
+		{returnDirection + " (return network traffic)", leg.RuleComponentsReturnDirection},
+	} {
+		outputItems = append(outputItems, fmt.Sprintf("%s rules that match the %s:", section.direction, p.OtherRole))
+
+		if len(section.components) == 0 {
+			outputItems = append(outputItems, helper.Indent("no rules that apply to analysis\n", 2))
+			continue
+		}
+
+		var explanation string
+		for _, model := range networkACLRuleComponentsToViewModels(section.components, p) {
+			explanation += model.String()
+		}
+		outputItems = append(outputItems, helper.Indent(explanation, 2))
+	}
+
+	return strings.Join(outputItems, "\n")
+}
+
+// TransitGatewayPath explains how a transit gateway handles network traffic sent to it from the VPC of the perspective's "self" network point.
+func (ex *Explainer) TransitGatewayPath(path transitGatewayPath, p reach.Perspective) string {
+	var outputItems []string
+
+	tgwName := path.TransitGateway.ID
+	tgwState := "[unknown]"
+	if tgwResource := ex.analysis.Resources.Get(path.TransitGateway); tgwResource != nil {
+		tgw := tgwResource.Properties.(TransitGateway)
+		tgwName = tgw.Name()
+		tgwState = tgw.State
+	}
+
+	outputItems = append(outputItems, fmt.Sprintf("transit gateway %s:", tgwName))
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("state: \"%s\"", tgwState), 2))
+
+	if path.Attachment == nil {
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("no attachment found for the %s's VPC", p.SelfRole), 2)+"\n")
+		return strings.Join(outputItems, "\n")
+	}
+
+	attachmentState := "[unknown]"
+	if attachmentResource := ex.analysis.Resources.Get(*path.Attachment); attachmentResource != nil {
+		attachmentState = attachmentResource.Properties.(TransitGatewayAttachment).State
+	}
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("attachment for the %s's VPC: %s (state: \"%s\")", p.SelfRole, path.Attachment.ID, attachmentState), 2))
+
+	if path.RouteTable == nil {
+		outputItems = append(outputItems, helper.Indent("attachment is not associated with a transit gateway route table", 2)+"\n")
+		return strings.Join(outputItems, "\n")
+	}
+
+	outputItems = append(outputItems, helper.Indent(fmt.Sprintf("associated transit gateway route table: %s", path.RouteTable.ID), 2))
+
+	if route := path.MatchedRoute; route == nil {
+		outputItems = append(outputItems, helper.Indent(fmt.Sprintf("no transit gateway route matches the %s's IP address (%s)", p.OtherRole, p.Other.IPAddress), 2)+"\n")
+	} else {
+		outputItems = append(outputItems, helper.Indent("most specific transit gateway route:", 2))
+		outputItems = append(outputItems, helper.Indent(route.String(), 4)+"\n")
+	}
+
+	return strings.Join(outputItems, "\n")
+}
+
+// CheckBothInAWS returns a boolean indicating whether both network points in a network vector are AWS resources.
+func (ex Explainer) CheckBothInAWS(v reach.NetworkVector) bool {
+	return IsUsedByNetworkPoint(v.Source) && IsUsedByNetworkPoint(v.Destination)
 }
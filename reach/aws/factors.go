@@ -35,3 +35,63 @@ func getNetworkACLRulesFactor(factors []reach.Factor) (*reach.Factor, error) {
 
 	return nil, errors.New("no network ACL rules factor found")
 }
+
+func getRouteTablesFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindRouteTables {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no route tables factor found")
+}
+
+func getLoadBalancerListenersFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindLoadBalancerListeners {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no load balancer listeners factor found")
+}
+
+func getLoadBalancerTargetsFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindLoadBalancerTargets {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no load balancer targets factor found")
+}
+
+func getRDSInstanceEndpointFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindRDSInstanceEndpoint {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no RDS instance endpoint factor found")
+}
+
+func getNATGatewayFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindNATGateway {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no NAT gateway factor found")
+}
+
+func getVPCEndpointFactor(factors []reach.Factor) (*reach.Factor, error) {
+	for _, factor := range factors {
+		if factor.Kind == FactorKindVPCEndpoint {
+			return &factor, nil
+		}
+	}
+
+	return nil, errors.New("no VPC endpoint factor found")
+}
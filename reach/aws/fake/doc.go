@@ -0,0 +1,2 @@
+// Package fake provides an in-memory AWS resource provider, along with a builder for describing a VPC's network configuration, so that Reach's analysis can be tested without access to AWS.
+package fake
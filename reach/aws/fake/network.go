@@ -0,0 +1,228 @@
+package fake
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+const region = "us-east-1"
+
+// A Network is a builder for several VPCs (see VPC) and the VPC peering connections and transit gateways between them. Each method returns the Network so that calls can be chained, and any error encountered along the way is returned by Build.
+type Network struct {
+	vpcs               []*VPC
+	peeringConnections []aws.VPCPeeringConnection
+	transitGateways    []*transitGateway
+	err                error
+}
+
+type transitGateway struct {
+	gateway     aws.TransitGateway
+	routeTable  aws.TransitGatewayRouteTable
+	attachments []aws.TransitGatewayAttachment
+}
+
+// NewNetwork returns a builder for a network of the specified VPCs. The VPCs' resource IDs (e.g. of subnets and security groups) must be unique across the network.
+func NewNetwork(vpcs ...*VPC) *Network {
+	n := &Network{}
+
+	ids := make(map[string]bool)
+	for _, v := range vpcs {
+		if ids[v.id] {
+			n.err = fmt.Errorf("unable to add VPC '%s' to network more than once", v.id)
+			return n
+		}
+		ids[v.id] = true
+	}
+
+	n.vpcs = vpcs
+
+	return n
+}
+
+// VPCPeeringConnection adds a VPC peering connection with the specified status (e.g. "active" or "pending-acceptance") between the requester and accepter VPCs. Each VPC's route tables need a route to the peering connection (see RouteToVPCPeeringConnection) for traffic to use it.
+func (n *Network) VPCPeeringConnection(id string, requester, accepter *VPC, status string) *Network {
+	if n.err != nil {
+		return n
+	}
+
+	for _, v := range []*VPC{requester, accepter} {
+		if !n.contains(v) {
+			n.err = fmt.Errorf("unable to add VPC peering connection '%s' for VPC '%s', which isn't in the network", id, v.id)
+			return n
+		}
+	}
+
+	n.peeringConnections = append(n.peeringConnections, aws.VPCPeeringConnection{
+		ID:               id,
+		Status:           status,
+		RequesterVPCID:   requester.id,
+		RequesterOwnerID: requester.ownerID,
+		RequesterRegion:  region,
+		AccepterVPCID:    accepter.id,
+		AccepterOwnerID:  accepter.ownerID,
+		AccepterRegion:   region,
+	})
+
+	return n
+}
+
+// TransitGateway adds an available transit gateway to the network. The transit gateway has a single route table (with an ID of "tgw-rtb-" followed by the transit gateway ID's suffix), which is associated with each of the transit gateway's VPC attachments (see TransitGatewayVPCAttachment).
+func (n *Network) TransitGateway(id string) *Network {
+	if n.err != nil {
+		return n
+	}
+
+	if n.transitGateway(id) != nil {
+		n.err = fmt.Errorf("unable to add transit gateway '%s' to network more than once", id)
+		return n
+	}
+
+	n.transitGateways = append(n.transitGateways, &transitGateway{
+		gateway: aws.TransitGateway{
+			ID:    id,
+			State: aws.TransitGatewayStateAvailable,
+		},
+		routeTable: aws.TransitGatewayRouteTable{
+			ID:               "tgw-rtb-" + strings.TrimPrefix(id, "tgw-"),
+			TransitGatewayID: id,
+		},
+	})
+
+	return n
+}
+
+// TransitGatewayVPCAttachment attaches the VPC to the transit gateway, and adds a route for the VPC's CIDR block to the transit gateway's route table, as if the attachment's routes were propagated. Each VPC's route tables need a route to the transit gateway (see RouteToTransitGateway) for traffic to use it.
+func (n *Network) TransitGatewayVPCAttachment(id, transitGatewayID string, v *VPC) *Network {
+	if n.err != nil {
+		return n
+	}
+
+	tgw := n.transitGateway(transitGatewayID)
+	if tgw == nil {
+		n.err = fmt.Errorf("unable to add transit gateway attachment '%s' to unknown transit gateway '%s'", id, transitGatewayID)
+		return n
+	}
+
+	if !n.contains(v) {
+		n.err = fmt.Errorf("unable to add transit gateway attachment '%s' for VPC '%s', which isn't in the network", id, v.id)
+		return n
+	}
+
+	tgw.attachments = append(tgw.attachments, aws.TransitGatewayAttachment{
+		ID:                     id,
+		TransitGatewayID:       transitGatewayID,
+		ResourceType:           aws.TransitGatewayAttachmentResourceTypeVPC,
+		ResourceID:             v.id,
+		ResourceOwnerID:        v.ownerID,
+		State:                  aws.TransitGatewayAttachmentStateAvailable,
+		AssociatedRouteTableID: tgw.routeTable.ID,
+	})
+
+	tgw.routeTable.Routes = append(tgw.routeTable.Routes, aws.TransitGatewayRoute{
+		Destination: v.cidr,
+		Attachments: []aws.TransitGatewayRouteAttachment{
+			{
+				ID:           id,
+				ResourceType: aws.TransitGatewayAttachmentResourceTypeVPC,
+				ResourceID:   v.id,
+			},
+		},
+		Type:  "propagated",
+		State: aws.TransitGatewayRouteStateActive,
+	})
+
+	return n
+}
+
+// TransitGatewayBlackholeRoute adds a static route to the transit gateway's route table that drops traffic for the IP CIDR block.
+func (n *Network) TransitGatewayBlackholeRoute(transitGatewayID, cidr string) *Network {
+	if n.err != nil {
+		return n
+	}
+
+	tgw := n.transitGateway(transitGatewayID)
+	if tgw == nil {
+		n.err = fmt.Errorf("unable to add route to unknown transit gateway '%s'", transitGatewayID)
+		return n
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		n.err = fmt.Errorf("unable to use CIDR for route in transit gateway route table '%s': %v", tgw.routeTable.ID, err)
+		return n
+	}
+
+	tgw.routeTable.Routes = append(tgw.routeTable.Routes, aws.TransitGatewayRoute{
+		Destination: network,
+		Type:        "static",
+		State:       "blackhole",
+	})
+
+	return n
+}
+
+// Build returns a collection of all of the resources described by the builder and its VPCs, or the first error encountered while building.
+func (n *Network) Build() (*reach.ResourceCollection, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+
+	rc := reach.NewResourceCollection()
+
+	for _, v := range n.vpcs {
+		vpcResources, err := v.Build()
+		if err != nil {
+			return nil, err
+		}
+		rc.Merge(vpcResources)
+	}
+
+	for _, pcx := range n.peeringConnections {
+		rc.Put(pcx.ToResourceReference(), pcx.ToResource())
+	}
+
+	for _, tgw := range n.transitGateways {
+		rc.Put(tgw.gateway.ToResourceReference(), tgw.gateway.ToResource())
+		rc.Put(tgw.routeTable.ToResourceReference(), tgw.routeTable.ToResource())
+
+		for _, attachment := range tgw.attachments {
+			rc.Put(attachment.ToResourceReference(), attachment.ToResource())
+		}
+	}
+
+	return rc, nil
+}
+
+// Provider returns an in-memory resource provider for all of the resources described by the builder and its VPCs, or the first error encountered while building.
+func (n *Network) Provider() (aws.ResourceProvider, error) {
+	rc, err := n.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceProvider(rc), nil
+}
+
+func (n *Network) contains(v *VPC) bool {
+	for _, existing := range n.vpcs {
+		if existing == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (n *Network) transitGateway(id string) *transitGateway {
+	for _, tgw := range n.transitGateways {
+		if tgw.gateway.ID == id {
+			return tgw
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package fake
+
+import (
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/snapshot"
+)
+
+// NewResourceProvider returns an in-memory AWS resource provider that serves the resources in the given collection.
+func NewResourceProvider(rc *reach.ResourceCollection) aws.ResourceProvider {
+	return snapshot.NewResourceProvider(&snapshot.Snapshot{
+		Version:   snapshot.FormatVersion,
+		Resources: rc,
+	})
+}
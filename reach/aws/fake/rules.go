@@ -0,0 +1,142 @@
+package fake
+
+import (
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// A SecurityGroupRule describes a rule for a security group created by the VPC builder.
+type SecurityGroupRule struct {
+	inbound bool
+	traffic reach.TrafficContent
+	target  RuleTarget
+}
+
+// A RuleTarget describes what a security group rule refers to: an IP CIDR block, another security group, or a managed prefix list.
+type RuleTarget struct {
+	cidr            string
+	securityGroupID string
+	accountID       string
+	prefixListID    string
+}
+
+// CIDR returns a rule target for the specified IP CIDR block (e.g. "10.0.1.0/24").
+func CIDR(cidr string) RuleTarget {
+	return RuleTarget{
+		cidr: cidr,
+	}
+}
+
+// SecurityGroup returns a rule target for the security group with the specified ID.
+func SecurityGroup(id string) RuleTarget {
+	return RuleTarget{
+		securityGroupID: id,
+	}
+}
+
+// SecurityGroupInAccount returns a rule target for the security group with the specified ID in the specified AWS account, which is how rules refer to security groups in peered VPCs.
+func SecurityGroupInAccount(id, accountID string) RuleTarget {
+	return RuleTarget{
+		securityGroupID: id,
+		accountID:       accountID,
+	}
+}
+
+// PrefixList returns a rule target for the managed prefix list with the specified ID (see VPC.PrefixList).
+func PrefixList(id string) RuleTarget {
+	return RuleTarget{
+		prefixListID: id,
+	}
+}
+
+// Entry returns a managed prefix list entry for the specified IP CIDR block, with an optional description.
+func Entry(cidr, description string) PrefixListEntry {
+	return PrefixListEntry{
+		cidr:        cidr,
+		description: description,
+	}
+}
+
+// A PrefixListEntry describes an entry for a managed prefix list created by the VPC builder.
+type PrefixListEntry struct {
+	cidr        string
+	description string
+}
+
+// Inbound returns a security group rule that allows the specified inbound traffic from the target.
+func Inbound(traffic reach.TrafficContent, from RuleTarget) SecurityGroupRule {
+	return SecurityGroupRule{
+		inbound: true,
+		traffic: traffic,
+		target:  from,
+	}
+}
+
+// Outbound returns a security group rule that allows the specified outbound traffic to the target.
+func Outbound(traffic reach.TrafficContent, to RuleTarget) SecurityGroupRule {
+	return SecurityGroupRule{
+		inbound: false,
+		traffic: traffic,
+		target:  to,
+	}
+}
+
+// A NetworkACLRule describes a rule for a network ACL created by the VPC builder.
+type NetworkACLRule struct {
+	inbound bool
+	number  int64
+	action  aws.NetworkACLRuleAction
+	traffic reach.TrafficContent
+	cidr    string
+}
+
+// AllowInbound returns a network ACL rule that allows the specified inbound traffic from the IP CIDR block.
+func AllowInbound(number int64, traffic reach.TrafficContent, cidr string) NetworkACLRule {
+	return NetworkACLRule{true, number, aws.NetworkACLRuleActionAllow, traffic, cidr}
+}
+
+// DenyInbound returns a network ACL rule that denies the specified inbound traffic from the IP CIDR block.
+func DenyInbound(number int64, traffic reach.TrafficContent, cidr string) NetworkACLRule {
+	return NetworkACLRule{true, number, aws.NetworkACLRuleActionDeny, traffic, cidr}
+}
+
+// AllowOutbound returns a network ACL rule that allows the specified outbound traffic to the IP CIDR block.
+func AllowOutbound(number int64, traffic reach.TrafficContent, cidr string) NetworkACLRule {
+	return NetworkACLRule{false, number, aws.NetworkACLRuleActionAllow, traffic, cidr}
+}
+
+// DenyOutbound returns a network ACL rule that denies the specified outbound traffic to the IP CIDR block.
+func DenyOutbound(number int64, traffic reach.TrafficContent, cidr string) NetworkACLRule {
+	return NetworkACLRule{false, number, aws.NetworkACLRuleActionDeny, traffic, cidr}
+}
+
+// A Route describes a route for a route table created by the VPC builder.
+type Route struct {
+	cidr   string
+	target aws.RouteTableRouteTarget
+}
+
+// RouteToVPCPeeringConnection returns a route that sends traffic for the IP CIDR block to the VPC peering connection with the specified ID (see Network.VPCPeeringConnection).
+func RouteToVPCPeeringConnection(cidr, id string) Route {
+	return Route{cidr, aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeVPCPeeringConnection, ID: id}}
+}
+
+// RouteToTransitGateway returns a route that sends traffic for the IP CIDR block to the transit gateway with the specified ID (see Network.TransitGateway).
+func RouteToTransitGateway(cidr, id string) Route {
+	return Route{cidr, aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeTransitGateway, ID: id}}
+}
+
+// RouteToInternetGateway returns a route that sends traffic for the IP CIDR block to the internet gateway with the specified ID.
+func RouteToInternetGateway(cidr, id string) Route {
+	return Route{cidr, aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeInternetGateway, ID: id}}
+}
+
+// RouteToEgressOnlyInternetGateway returns a route that sends traffic for the IP CIDR block to the egress-only internet gateway with the specified ID.
+func RouteToEgressOnlyInternetGateway(cidr, id string) Route {
+	return Route{cidr, aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeEgressOnlyInternetGateway, ID: id}}
+}
+
+// RouteToNATGateway returns a route that sends traffic for the IP CIDR block to the NAT gateway with the specified ID.
+func RouteToNATGateway(cidr, id string) Route {
+	return Route{cidr, aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeNATGateway, ID: id}}
+}
@@ -0,0 +1,1081 @@
+package fake
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+const firstHostOffset = 10 // AWS reserves the first few addresses of each subnet
+
+// A VPC is a builder for the network configuration of a single VPC and the EC2 instances, load balancers and databases within it. Each method returns the VPC so that calls can be chained, and any error encountered along the way is returned by Build. To build several VPCs that are connected to each other, see Network.
+type VPC struct {
+	id             string
+	ownerID        string
+	cidr           *net.IPNet
+	subnets        []*subnet
+	securityGroups []aws.SecurityGroup
+	networkACLs    []aws.NetworkACL
+	routeTables    []aws.RouteTable
+	instances      []aws.EC2Instance
+	enis           []aws.ElasticNetworkInterface
+	loadBalancers  []aws.LoadBalancer
+	targetGroups   []aws.TargetGroup
+	rdsInstances   []aws.RDSInstance
+	rdsClusters    []aws.RDSCluster
+	igws           []aws.InternetGateway
+	eigws          []aws.EgressOnlyInternetGateway
+	natGateways    []aws.NATGateway
+	vpcEndpoints   []aws.VPCEndpoint
+	awsServices    []aws.AWSService
+	prefixLists    []aws.PrefixList
+	err            error
+}
+
+type subnet struct {
+	id           string
+	cidr         *net.IPNet
+	networkACLID string
+	routeTableID string
+	nextHost     uint32
+}
+
+// NewVPC returns a builder for a new VPC that uses the specified IPv4 CIDR block. The VPC contains a main route table with a local route, and a default network ACL that allows all IPv4 and IPv6 traffic.
+func NewVPC(cidr string) *VPC {
+	v := &VPC{
+		id: "vpc-" + strings.NewReplacer(".", "-", "/", "-").Replace(cidr),
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		v.err = fmt.Errorf("unable to use VPC CIDR: %v", err)
+		return v
+	}
+
+	if network.IP.To4() == nil {
+		v.err = fmt.Errorf("unable to use VPC CIDR: only IPv4 CIDR blocks are supported")
+		return v
+	}
+	v.cidr = network
+
+	return v
+}
+
+// ID returns the ID of the VPC.
+func (v *VPC) ID() string {
+	return v.id
+}
+
+// Owner sets the ID of the AWS account that owns the VPC and its security groups and network interfaces. By default, the owner isn't known.
+func (v *VPC) Owner(accountID string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	v.ownerID = accountID
+
+	return v
+}
+
+// Subnet adds a subnet to the VPC.
+func (v *VPC) Subnet(id, cidr string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		v.err = fmt.Errorf("unable to use CIDR for subnet '%s': %v", id, err)
+		return v
+	}
+
+	if v.cidr != nil && !v.cidr.Contains(network.IP) {
+		v.err = fmt.Errorf("subnet '%s' (%s) is not within the VPC's CIDR block (%s)", id, network, v.cidr)
+		return v
+	}
+
+	v.subnets = append(v.subnets, &subnet{
+		id:           id,
+		cidr:         network,
+		networkACLID: v.defaultNetworkACLID(),
+		routeTableID: v.mainRouteTableID(),
+		nextHost:     firstHostOffset,
+	})
+
+	return v
+}
+
+// SecurityGroup adds a security group with the specified rules to the VPC.
+func (v *VPC) SecurityGroup(id string, rules ...SecurityGroupRule) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	sg := aws.SecurityGroup{
+		ID:        id,
+		GroupName: id,
+		VPCID:     v.id,
+	}
+
+	for _, rule := range rules {
+		sgRule := aws.SecurityGroupRule{
+			TrafficContent:                        rule.traffic,
+			TargetSecurityGroupReferenceID:        rule.target.securityGroupID,
+			TargetSecurityGroupReferenceAccountID: rule.target.accountID,
+		}
+
+		if rule.target.cidr != "" {
+			_, network, err := net.ParseCIDR(rule.target.cidr)
+			if err != nil {
+				v.err = fmt.Errorf("unable to use CIDR for rule in security group '%s': %v", id, err)
+				return v
+			}
+			sgRule.TargetIPNetworks = []*net.IPNet{network}
+		}
+
+		if rule.target.prefixListID != "" {
+			sgRule.TargetPrefixListIDs = []string{rule.target.prefixListID}
+		}
+
+		if rule.inbound {
+			sg.InboundRules = append(sg.InboundRules, sgRule)
+		} else {
+			sg.OutboundRules = append(sg.OutboundRules, sgRule)
+		}
+	}
+
+	v.securityGroups = append(v.securityGroups, sg)
+
+	return v
+}
+
+// PrefixList adds a customer-managed prefix list with the specified entries, which security group rules can refer to (see the PrefixList rule target).
+func (v *VPC) PrefixList(id, name string, entries ...PrefixListEntry) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	prefixList := aws.PrefixList{
+		ID:             id,
+		PrefixListName: name,
+	}
+
+	for _, entry := range entries {
+		_, network, err := net.ParseCIDR(entry.cidr)
+		if err != nil {
+			v.err = fmt.Errorf("unable to use CIDR for entry in prefix list '%s': %v", id, err)
+			return v
+		}
+		prefixList.Entries = append(prefixList.Entries, aws.PrefixListEntry{
+			CIDR:        network,
+			Description: entry.description,
+		})
+	}
+
+	v.prefixLists = append(v.prefixLists, prefixList)
+
+	return v
+}
+
+// NetworkACL adds a network ACL with the specified rules to the VPC, and associates it with the specified subnets (in place of the VPC's default network ACL).
+func (v *VPC) NetworkACL(id string, subnetIDs []string, rules ...NetworkACLRule) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	networkACL := aws.NetworkACL{
+		ID: id,
+	}
+
+	for _, rule := range rules {
+		_, network, err := net.ParseCIDR(rule.cidr)
+		if err != nil {
+			v.err = fmt.Errorf("unable to use CIDR for rule %d in network ACL '%s': %v", rule.number, id, err)
+			return v
+		}
+
+		naclRule := aws.NetworkACLRule{
+			Number:          rule.number,
+			TrafficContent:  rule.traffic,
+			TargetIPNetwork: network,
+			Action:          rule.action,
+		}
+
+		if rule.inbound {
+			networkACL.InboundRules = append(networkACL.InboundRules, naclRule)
+		} else {
+			networkACL.OutboundRules = append(networkACL.OutboundRules, naclRule)
+		}
+	}
+
+	for _, subnetID := range subnetIDs {
+		s := v.subnet(subnetID)
+		if s == nil {
+			v.err = fmt.Errorf("unable to associate network ACL '%s' with unknown subnet '%s'", id, subnetID)
+			return v
+		}
+
+		s.networkACLID = id
+	}
+
+	v.networkACLs = append(v.networkACLs, networkACL)
+
+	return v
+}
+
+// RouteTable adds a route table with a local route and the specified routes to the VPC, and associates it with the specified subnets (in place of the VPC's main route table).
+func (v *VPC) RouteTable(id string, subnetIDs []string, routes ...Route) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	routeTable := aws.RouteTable{
+		ID:     id,
+		VPCID:  v.id,
+		Routes: []aws.RouteTableRoute{v.localRoute()},
+	}
+
+	for _, route := range routes {
+		_, network, err := net.ParseCIDR(route.cidr)
+		if err != nil {
+			v.err = fmt.Errorf("unable to use CIDR for route in route table '%s': %v", id, err)
+			return v
+		}
+
+		routeTable.Routes = append(routeTable.Routes, aws.RouteTableRoute{
+			Destination: network,
+			Target:      route.target,
+			State:       aws.RouteTableRouteStateActive,
+		})
+	}
+
+	for _, subnetID := range subnetIDs {
+		s := v.subnet(subnetID)
+		if s == nil {
+			v.err = fmt.Errorf("unable to associate route table '%s' with unknown subnet '%s'", id, subnetID)
+			return v
+		}
+
+		s.routeTableID = id
+	}
+
+	v.routeTables = append(v.routeTables, routeTable)
+
+	return v
+}
+
+// InternetGateway adds an internet gateway, attached to the VPC.
+func (v *VPC) InternetGateway(id string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	v.igws = append(v.igws, aws.InternetGateway{
+		ID:          id,
+		Attachments: []aws.InternetGatewayAttachment{{VPCID: v.id, State: "available"}},
+	})
+
+	return v
+}
+
+// EgressOnlyInternetGateway adds an egress-only internet gateway, attached to the VPC.
+func (v *VPC) EgressOnlyInternetGateway(id string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	v.eigws = append(v.eigws, aws.EgressOnlyInternetGateway{
+		ID:          id,
+		Attachments: []aws.InternetGatewayAttachment{{VPCID: v.id, State: "attached"}},
+	})
+
+	return v
+}
+
+// NATGateway adds an available NAT gateway to the specified subnet. The NAT gateway has a network interface (with an ID of "eni-" followed by the NAT gateway's ID) that uses the next available IP address in the subnet and the specified public (Elastic) IPv4 address.
+func (v *VPC) NATGateway(id, subnetID, publicIP string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	s := v.subnet(subnetID)
+	if s == nil {
+		v.err = fmt.Errorf("unable to add NAT gateway '%s' to unknown subnet '%s'", id, subnetID)
+		return v
+	}
+
+	public := net.ParseIP(publicIP)
+	if public == nil {
+		v.err = fmt.Errorf("unable to use invalid public IP address '%s' for NAT gateway '%s'", publicIP, id)
+		return v
+	}
+
+	eni := aws.ElasticNetworkInterface{
+		ID:                   "eni-" + id,
+		SubnetID:             s.id,
+		VPCID:                v.id,
+		PrivateIPv4Addresses: []net.IP{s.nextIP()},
+		PublicIPv4Address:    public,
+	}
+
+	v.enis = append(v.enis, eni)
+	v.natGateways = append(v.natGateways, aws.NATGateway{
+		ID:                        id,
+		State:                     aws.NATGatewayStateAvailable,
+		VPCID:                     v.id,
+		SubnetID:                  s.id,
+		ElasticNetworkInterfaceID: eni.ID,
+		PrivateIPv4Address:        eni.PrivateIPv4Addresses[0],
+		PublicIPv4Address:         public,
+	})
+
+	return v
+}
+
+// AWSService adds an AWS service (e.g. "com.amazonaws.us-east-1.s3") whose prefix list contains the specified CIDR blocks. The prefix list's ID is "pl-" followed by the last part of the service name.
+func (v *VPC) AWSService(name string, cidrs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	service := aws.AWSService{
+		Name:         name,
+		PrefixListID: "pl-" + name[strings.LastIndex(name, ".")+1:],
+	}
+
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			v.err = fmt.Errorf("unable to use CIDR for AWS service '%s': %v", name, err)
+			return v
+		}
+		service.IPNetworks = append(service.IPNetworks, network)
+	}
+
+	v.awsServices = append(v.awsServices, service)
+
+	return v
+}
+
+// InterfaceVPCEndpoint adds an available interface endpoint for the specified service to the VPC. The endpoint has one network interface (with an ID of "eni-" followed by the endpoint's ID and the subnet ID) in each of the specified subnets, which uses the specified security groups.
+func (v *VPC) InterfaceVPCEndpoint(id, serviceName string, subnetIDs []string, securityGroupIDs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	for _, sgID := range securityGroupIDs {
+		if v.securityGroup(sgID) == nil {
+			v.err = fmt.Errorf("unable to add VPC endpoint '%s' with unknown security group '%s'", id, sgID)
+			return v
+		}
+	}
+
+	vpce := aws.VPCEndpoint{
+		ID:               id,
+		Type:             aws.VPCEndpointTypeInterface,
+		ServiceName:      serviceName,
+		State:            "available",
+		VPCID:            v.id,
+		SubnetIDs:        subnetIDs,
+		SecurityGroupIDs: securityGroupIDs,
+	}
+
+	for _, subnetID := range subnetIDs {
+		s := v.subnet(subnetID)
+		if s == nil {
+			v.err = fmt.Errorf("unable to add VPC endpoint '%s' to unknown subnet '%s'", id, subnetID)
+			return v
+		}
+
+		eni := aws.ElasticNetworkInterface{
+			ID:                   "eni-" + id + "-" + s.id,
+			SubnetID:             s.id,
+			VPCID:                v.id,
+			SecurityGroupIDs:     securityGroupIDs,
+			PrivateIPv4Addresses: []net.IP{s.nextIP()},
+		}
+
+		v.enis = append(v.enis, eni)
+		vpce.ElasticNetworkInterfaceIDs = append(vpce.ElasticNetworkInterfaceIDs, eni.ID)
+	}
+
+	v.vpcEndpoints = append(v.vpcEndpoints, vpce)
+
+	return v
+}
+
+// GatewayVPCEndpoint adds an available gateway endpoint for the specified AWS service (see AWSService) to the VPC, and adds a route to the endpoint for each of the service's CIDR blocks to each of the specified route tables (see RouteTable).
+func (v *VPC) GatewayVPCEndpoint(id, serviceName string, routeTableIDs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	var service *aws.AWSService
+	for i := range v.awsServices {
+		if v.awsServices[i].Name == serviceName {
+			service = &v.awsServices[i]
+		}
+	}
+	if service == nil {
+		v.err = fmt.Errorf("unable to add VPC endpoint '%s' for unknown AWS service '%s'", id, serviceName)
+		return v
+	}
+
+	for _, routeTableID := range routeTableIDs {
+		routeTable := v.routeTable(routeTableID)
+		if routeTable == nil {
+			v.err = fmt.Errorf("unable to add VPC endpoint '%s' to unknown route table '%s'", id, routeTableID)
+			return v
+		}
+
+		for _, network := range service.IPNetworks {
+			routeTable.Routes = append(routeTable.Routes, aws.RouteTableRoute{
+				Destination:             network,
+				DestinationPrefixListID: service.PrefixListID,
+				Target:                  aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeVPCEndpoint, ID: id},
+				State:                   aws.RouteTableRouteStateActive,
+			})
+		}
+	}
+
+	v.vpcEndpoints = append(v.vpcEndpoints, aws.VPCEndpoint{
+		ID:            id,
+		Type:          aws.VPCEndpointTypeGateway,
+		ServiceName:   serviceName,
+		State:         "available",
+		VPCID:         v.id,
+		RouteTableIDs: routeTableIDs,
+	})
+
+	return v
+}
+
+// VPCEndpointState sets the state (e.g. "pendingAcceptance") of the VPC endpoint with the specified ID.
+func (v *VPC) VPCEndpointState(id, state string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	for i := range v.vpcEndpoints {
+		if v.vpcEndpoints[i].ID == id {
+			v.vpcEndpoints[i].State = state
+			return v
+		}
+	}
+
+	v.err = fmt.Errorf("unable to set state for unknown VPC endpoint '%s'", id)
+	return v
+}
+
+// VPCEndpointPolicy sets the policy document of the VPC endpoint with the specified ID.
+func (v *VPC) VPCEndpointPolicy(id, policyDocument string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	for i := range v.vpcEndpoints {
+		if v.vpcEndpoints[i].ID == id {
+			v.vpcEndpoints[i].PolicyDocument = policyDocument
+			return v
+		}
+	}
+
+	v.err = fmt.Errorf("unable to set policy for unknown VPC endpoint '%s'", id)
+	return v
+}
+
+// PublicIPv4Address assigns the public IPv4 address to the network interface with the specified ID.
+func (v *VPC) PublicIPv4Address(eniID, ip string) *VPC {
+	return v.assignIPAddress(eniID, ip, func(eni *aws.ElasticNetworkInterface, parsed net.IP) {
+		eni.PublicIPv4Address = parsed
+	})
+}
+
+// IPv6Address adds the IPv6 address to the network interface with the specified ID.
+func (v *VPC) IPv6Address(eniID, ip string) *VPC {
+	return v.assignIPAddress(eniID, ip, func(eni *aws.ElasticNetworkInterface, parsed net.IP) {
+		eni.IPv6Addresses = append(eni.IPv6Addresses, parsed)
+	})
+}
+
+func (v *VPC) assignIPAddress(eniID, ip string, assign func(eni *aws.ElasticNetworkInterface, parsed net.IP)) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	eni := v.eni(eniID)
+	if eni == nil {
+		v.err = fmt.Errorf("unable to assign IP address to unknown network interface '%s'", eniID)
+		return v
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		v.err = fmt.Errorf("unable to assign invalid IP address '%s' to network interface '%s'", ip, eniID)
+		return v
+	}
+
+	assign(eni, parsed)
+
+	return v
+}
+
+// Instance adds a running EC2 instance to the specified subnet. The instance's ID is "i-" followed by the name, and the instance has a single network interface (with an ID of "eni-" followed by the name) that uses the next available IP address in the subnet and the specified security groups.
+func (v *VPC) Instance(name, subnetID string, securityGroupIDs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	s := v.subnet(subnetID)
+	if s == nil {
+		v.err = fmt.Errorf("unable to add instance '%s' to unknown subnet '%s'", name, subnetID)
+		return v
+	}
+
+	for _, sgID := range securityGroupIDs {
+		if v.securityGroup(sgID) == nil {
+			v.err = fmt.Errorf("unable to add instance '%s' with unknown security group '%s'", name, sgID)
+			return v
+		}
+	}
+
+	eni := aws.ElasticNetworkInterface{
+		ID:                   "eni-" + name,
+		SubnetID:             s.id,
+		VPCID:                v.id,
+		SecurityGroupIDs:     securityGroupIDs,
+		PrivateIPv4Addresses: []net.IP{s.nextIP()},
+	}
+
+	instance := aws.EC2Instance{
+		ID:      "i-" + name,
+		NameTag: name,
+		Tags:    map[string]string{"Name": name},
+		State:   "running",
+		NetworkInterfaceAttachments: []aws.NetworkInterfaceAttachment{
+			{
+				ID:                        "eni-attach-" + name,
+				ElasticNetworkInterfaceID: eni.ID,
+				DeviceIndex:               0,
+			},
+		},
+	}
+
+	v.enis = append(v.enis, eni)
+	v.instances = append(v.instances, instance)
+
+	return v
+}
+
+// InstanceTag adds a tag with the specified key and value to the named EC2 instance (see Instance).
+func (v *VPC) InstanceTag(name, key, value string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	instance := v.instance("i-" + name)
+	if instance == nil {
+		v.err = fmt.Errorf("unable to add tag to unknown instance '%s'", name)
+		return v
+	}
+
+	instance.Tags[key] = value
+
+	return v
+}
+
+// LoadBalancer adds an active load balancer of the specified type ("application" or "network") to the VPC. The load balancer's ID is an ARN that includes the name, and the load balancer has one network interface (with an ID of "eni-" followed by the name and the subnet ID) in each of the specified subnets. Security groups only apply to Application Load Balancers.
+func (v *VPC) LoadBalancer(name, lbType string, subnetIDs []string, securityGroupIDs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	for _, sgID := range securityGroupIDs {
+		if v.securityGroup(sgID) == nil {
+			v.err = fmt.Errorf("unable to add load balancer '%s' with unknown security group '%s'", name, sgID)
+			return v
+		}
+	}
+
+	lb := aws.LoadBalancer{
+		ID:               LoadBalancerARN(name, lbType),
+		LoadBalancerName: name,
+		Type:             lbType,
+		Scheme:           "internal",
+		State:            "active",
+		VPCID:            v.id,
+		SecurityGroupIDs: securityGroupIDs,
+	}
+
+	for _, subnetID := range subnetIDs {
+		s := v.subnet(subnetID)
+		if s == nil {
+			v.err = fmt.Errorf("unable to add load balancer '%s' to unknown subnet '%s'", name, subnetID)
+			return v
+		}
+
+		eni := aws.ElasticNetworkInterface{
+			ID:                   "eni-" + name + "-" + s.id,
+			SubnetID:             s.id,
+			VPCID:                v.id,
+			SecurityGroupIDs:     securityGroupIDs,
+			PrivateIPv4Addresses: []net.IP{s.nextIP()},
+		}
+
+		v.enis = append(v.enis, eni)
+		lb.ElasticNetworkInterfaceIDs = append(lb.ElasticNetworkInterfaceIDs, eni.ID)
+	}
+
+	v.loadBalancers = append(v.loadBalancers, lb)
+
+	return v
+}
+
+// Listener adds a listener with the specified protocol (e.g. "HTTPS") and port to the named load balancer.
+func (v *VPC) Listener(loadBalancerName, protocol string, port int64) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	lb := v.loadBalancer(loadBalancerName)
+	if lb == nil {
+		v.err = fmt.Errorf("unable to add listener to unknown load balancer '%s'", loadBalancerName)
+		return v
+	}
+
+	lb.Listeners = append(lb.Listeners, aws.LoadBalancerListener{
+		Protocol: protocol,
+		Port:     port,
+	})
+
+	return v
+}
+
+// TargetGroup adds an instance target group to the named load balancer, and registers the named instances (see Instance) as targets at the target group's port. Health checks use the traffic port unless changed via HealthCheckPort.
+func (v *VPC) TargetGroup(name, loadBalancerName, protocol string, port int64, instanceNames ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	lb := v.loadBalancer(loadBalancerName)
+	if lb == nil {
+		v.err = fmt.Errorf("unable to add target group '%s' to unknown load balancer '%s'", name, loadBalancerName)
+		return v
+	}
+
+	tg := aws.TargetGroup{
+		ID:                  TargetGroupARN(name),
+		TargetGroupName:     name,
+		Protocol:            protocol,
+		Port:                port,
+		TargetType:          aws.TargetGroupTargetTypeInstance,
+		VPCID:               v.id,
+		HealthCheckProtocol: protocol,
+		HealthCheckPort:     "traffic-port",
+	}
+
+	for _, instanceName := range instanceNames {
+		if v.instance("i-"+instanceName) == nil {
+			v.err = fmt.Errorf("unable to register unknown instance '%s' with target group '%s'", instanceName, name)
+			return v
+		}
+
+		tg.Targets = append(tg.Targets, aws.TargetGroupTarget{
+			ID:   "i-" + instanceName,
+			Port: port,
+		})
+	}
+
+	lb.TargetGroupIDs = append(lb.TargetGroupIDs, tg.ID)
+	v.targetGroups = append(v.targetGroups, tg)
+
+	return v
+}
+
+// HealthCheckPort sets the port used for health checks by the named target group.
+func (v *VPC) HealthCheckPort(targetGroupName string, port int64) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	for i := range v.targetGroups {
+		if v.targetGroups[i].TargetGroupName == targetGroupName {
+			v.targetGroups[i].HealthCheckPort = fmt.Sprint(port)
+			return v
+		}
+	}
+
+	v.err = fmt.Errorf("unable to set health check port for unknown target group '%s'", targetGroupName)
+	return v
+}
+
+// RDSInstance adds an available RDS instance to the specified subnet. The instance's ID is the name, and the instance has a single network interface (with an ID of "eni-" followed by the name) that uses the next available IP address in the subnet and the specified security groups.
+func (v *VPC) RDSInstance(name, engine, subnetID string, port int64, securityGroupIDs ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	s := v.subnet(subnetID)
+	if s == nil {
+		v.err = fmt.Errorf("unable to add RDS instance '%s' to unknown subnet '%s'", name, subnetID)
+		return v
+	}
+
+	for _, sgID := range securityGroupIDs {
+		if v.securityGroup(sgID) == nil {
+			v.err = fmt.Errorf("unable to add RDS instance '%s' with unknown security group '%s'", name, sgID)
+			return v
+		}
+	}
+
+	eni := aws.ElasticNetworkInterface{
+		ID:                   "eni-" + name,
+		SubnetID:             s.id,
+		VPCID:                v.id,
+		SecurityGroupIDs:     securityGroupIDs,
+		PrivateIPv4Addresses: []net.IP{s.nextIP()},
+	}
+
+	db := aws.RDSInstance{
+		ID:                         name,
+		ARN:                        "arn:aws:rds:us-east-1:000000000000:db:" + name,
+		Engine:                     engine,
+		Status:                     "available",
+		EndpointAddress:            name + ".fake.us-east-1.rds.amazonaws.com",
+		Port:                       port,
+		VPCID:                      v.id,
+		SubnetIDs:                  []string{s.id},
+		SecurityGroupIDs:           securityGroupIDs,
+		ElasticNetworkInterfaceIDs: []string{eni.ID},
+	}
+
+	v.enis = append(v.enis, eni)
+	v.rdsInstances = append(v.rdsInstances, db)
+
+	return v
+}
+
+// RDSInstanceStatus sets the status (e.g. "stopped") of the named RDS instance.
+func (v *VPC) RDSInstanceStatus(name, status string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	db := v.rdsInstance(name)
+	if db == nil {
+		v.err = fmt.Errorf("unable to set status for unknown RDS instance '%s'", name)
+		return v
+	}
+
+	db.Status = status
+
+	return v
+}
+
+// RDSCluster adds an Aurora cluster whose members are the named RDS instances (see RDSInstance). The first member is the cluster's writer, and the cluster uses the writer's engine and port.
+func (v *VPC) RDSCluster(name string, memberNames ...string) *VPC {
+	if v.err != nil {
+		return v
+	}
+
+	if len(memberNames) == 0 {
+		v.err = fmt.Errorf("unable to add RDS cluster '%s' without any members", name)
+		return v
+	}
+
+	cluster := aws.RDSCluster{
+		ID:                     name,
+		ARN:                    "arn:aws:rds:us-east-1:000000000000:cluster:" + name,
+		Status:                 "available",
+		EndpointAddress:        name + ".cluster-fake.us-east-1.rds.amazonaws.com",
+		ReaderEndpointAddress:  name + ".cluster-ro-fake.us-east-1.rds.amazonaws.com",
+		WriterMemberInstanceID: memberNames[0],
+	}
+
+	for _, memberName := range memberNames {
+		db := v.rdsInstance(memberName)
+		if db == nil {
+			v.err = fmt.Errorf("unable to add unknown RDS instance '%s' to RDS cluster '%s'", memberName, name)
+			return v
+		}
+
+		if cluster.Engine == "" {
+			cluster.Engine = db.Engine
+			cluster.Port = db.Port
+		}
+
+		db.ClusterID = name
+		cluster.MemberInstanceIDs = append(cluster.MemberInstanceIDs, db.ID)
+	}
+
+	v.rdsClusters = append(v.rdsClusters, cluster)
+
+	return v
+}
+
+// LoadBalancerARN returns the ID used by the VPC builder for a load balancer with the specified name and type.
+func LoadBalancerARN(name, lbType string) string {
+	prefix := "app"
+	if lbType == aws.LoadBalancerTypeNetwork {
+		prefix = "net"
+	}
+
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:000000000000:loadbalancer/%s/%s/0000000000000000", prefix, name)
+}
+
+// TargetGroupARN returns the ID used by the VPC builder for a target group with the specified name.
+func TargetGroupARN(name string) string {
+	return fmt.Sprintf("arn:aws:elasticloadbalancing:us-east-1:000000000000:targetgroup/%s/0000000000000000", name)
+}
+
+// Build returns a collection of all of the resources described by the builder, or the first error encountered while building.
+func (v *VPC) Build() (*reach.ResourceCollection, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	rc := reach.NewResourceCollection()
+
+	put := func(kind, id string, resource reach.Resource) {
+		rc.Put(reach.ResourceReference{
+			Domain: aws.ResourceDomainAWS,
+			Kind:   kind,
+			ID:     id,
+		}, resource)
+	}
+
+	put(aws.ResourceKindVPC, v.id, aws.VPC{
+		ID:        v.id,
+		IPv4CIDRs: []net.IPNet{*v.cidr},
+	}.ToResource())
+
+	mainRouteTable := aws.RouteTable{
+		ID:     v.mainRouteTableID(),
+		VPCID:  v.id,
+		Routes: []aws.RouteTableRoute{v.localRoute()},
+	}
+	put(aws.ResourceKindRouteTable, mainRouteTable.ID, mainRouteTable.ToResource())
+
+	for _, routeTable := range v.routeTables {
+		put(aws.ResourceKindRouteTable, routeTable.ID, routeTable.ToResource())
+	}
+
+	for _, igw := range v.igws {
+		put(aws.ResourceKindInternetGateway, igw.ID, igw.ToResource())
+	}
+
+	for _, eigw := range v.eigws {
+		put(aws.ResourceKindEgressOnlyInternetGateway, eigw.ID, eigw.ToResource())
+	}
+
+	for _, nat := range v.natGateways {
+		put(aws.ResourceKindNATGateway, nat.ID, nat.ToResource())
+	}
+
+	for _, vpce := range v.vpcEndpoints {
+		put(aws.ResourceKindVPCEndpoint, vpce.ID, vpce.ToResource())
+	}
+
+	for _, service := range v.awsServices {
+		put(aws.ResourceKindAWSService, service.Name, service.ToResource())
+	}
+
+	for _, prefixList := range v.prefixLists {
+		put(aws.ResourceKindPrefixList, prefixList.ID, prefixList.ToResource())
+	}
+
+	put(aws.ResourceKindNetworkACL, v.defaultNetworkACLID(), v.defaultNetworkACL().ToResource())
+
+	for _, networkACL := range v.networkACLs {
+		put(aws.ResourceKindNetworkACL, networkACL.ID, networkACL.ToResource())
+	}
+
+	for _, s := range v.subnets {
+		put(aws.ResourceKindSubnet, s.id, aws.Subnet{
+			ID:           s.id,
+			NetworkACLID: s.networkACLID,
+			RouteTableID: s.routeTableID,
+			VPCID:        v.id,
+		}.ToResource())
+	}
+
+	for _, sg := range v.securityGroups {
+		sg.OwnerID = v.ownerID
+		put(aws.ResourceKindSecurityGroup, sg.ID, sg.ToResource())
+		put(aws.ResourceKindSecurityGroupReference, sg.ID, aws.SecurityGroupReference{
+			ID:        sg.ID,
+			AccountID: v.ownerID,
+			GroupName: sg.GroupName,
+		}.ToResource())
+	}
+
+	for _, eni := range v.enis {
+		eni.OwnerID = v.ownerID
+		put(aws.ResourceKindElasticNetworkInterface, eni.ID, eni.ToResource())
+	}
+
+	for _, instance := range v.instances {
+		put(aws.ResourceKindEC2Instance, instance.ID, instance.ToResource())
+	}
+
+	for _, lb := range v.loadBalancers {
+		put(aws.ResourceKindLoadBalancer, lb.ID, lb.ToResource())
+	}
+
+	for _, tg := range v.targetGroups {
+		put(aws.ResourceKindTargetGroup, tg.ID, tg.ToResource())
+	}
+
+	for _, db := range v.rdsInstances {
+		put(aws.ResourceKindRDSInstance, db.ID, db.ToResource())
+	}
+
+	for _, cluster := range v.rdsClusters {
+		put(aws.ResourceKindRDSCluster, cluster.ID, cluster.ToResource())
+	}
+
+	return rc, nil
+}
+
+// Provider returns an in-memory resource provider for all of the resources described by the builder, or the first error encountered while building.
+func (v *VPC) Provider() (aws.ResourceProvider, error) {
+	rc, err := v.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceProvider(rc), nil
+}
+
+func (v *VPC) idSuffix() string {
+	return strings.TrimPrefix(v.id, "vpc-")
+}
+
+func (v *VPC) mainRouteTableID() string {
+	return "rtb-" + v.idSuffix()
+}
+
+func (v *VPC) localRoute() aws.RouteTableRoute {
+	return aws.RouteTableRoute{
+		Destination: v.cidr,
+		Target: aws.RouteTableRouteTarget{
+			Type: aws.RouteTableRouteTargetTypeLocal,
+			ID:   "local",
+		},
+		State: aws.RouteTableRouteStateActive,
+	}
+}
+
+func (v *VPC) defaultNetworkACLID() string {
+	return "acl-" + v.idSuffix()
+}
+
+func (v *VPC) defaultNetworkACL() aws.NetworkACL {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, anywhereIPv6, _ := net.ParseCIDR("::/0")
+
+	allowAll := []aws.NetworkACLRule{
+		{
+			Number:          100,
+			TrafficContent:  reach.NewTrafficContentForAllTraffic(),
+			TargetIPNetwork: anywhere,
+			Action:          aws.NetworkACLRuleActionAllow,
+		},
+		{
+			Number:          101,
+			TrafficContent:  reach.NewTrafficContentForAllTraffic(),
+			TargetIPNetwork: anywhereIPv6,
+			Action:          aws.NetworkACLRuleActionAllow,
+		},
+	}
+
+	return aws.NetworkACL{
+		ID:            v.defaultNetworkACLID(),
+		InboundRules:  allowAll,
+		OutboundRules: allowAll,
+	}
+}
+
+func (v *VPC) subnet(id string) *subnet {
+	for _, s := range v.subnets {
+		if s.id == id {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) securityGroup(id string) *aws.SecurityGroup {
+	for i := range v.securityGroups {
+		if v.securityGroups[i].ID == id {
+			return &v.securityGroups[i]
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) routeTable(id string) *aws.RouteTable {
+	for i := range v.routeTables {
+		if v.routeTables[i].ID == id {
+			return &v.routeTables[i]
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) eni(id string) *aws.ElasticNetworkInterface {
+	for i := range v.enis {
+		if v.enis[i].ID == id {
+			return &v.enis[i]
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) instance(id string) *aws.EC2Instance {
+	for i := range v.instances {
+		if v.instances[i].ID == id {
+			return &v.instances[i]
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) loadBalancer(name string) *aws.LoadBalancer {
+	for i := range v.loadBalancers {
+		if v.loadBalancers[i].LoadBalancerName == name {
+			return &v.loadBalancers[i]
+		}
+	}
+
+	return nil
+}
+
+func (v *VPC) rdsInstance(name string) *aws.RDSInstance {
+	for i := range v.rdsInstances {
+		if v.rdsInstances[i].ID == name {
+			return &v.rdsInstances[i]
+		}
+	}
+
+	return nil
+}
+
+func (s *subnet) nextIP() net.IP {
+	base := binary.BigEndian.Uint32(s.cidr.IP.To4())
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, base+s.nextHost)
+	s.nextHost++
+
+	return ip
+}
@@ -64,7 +64,7 @@ func FindEC2InstanceID(searchText string, provider ResourceProvider) (string, er
 			// prepare helpful error text
 			var matchedInstances []string
 
-			for _, matchIdx := range matchesOnID {
+			for _, matchIdx := range matchesOnName {
 				name := instances[matchIdx].NameTag
 				id := instances[matchIdx].ID
 
@@ -72,7 +72,7 @@ func FindEC2InstanceID(searchText string, provider ResourceProvider) (string, er
 			}
 
 			matches := strings.Join(matchedInstances, ", ")
-			return "", fmt.Errorf("error: search text matches multiple EC2 instances' name tags (matches for search text '%s': %s)", searchText, matches)
+			return "", fmt.Errorf("error: search text matches multiple EC2 instances' name tags (matches for search text '%s': %s); to analyze all of them, use a name glob (e.g. '%s*') or another selector", searchText, matches, searchText)
 		}
 	}
 
@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindLoadBalancerID looks up the ARN for a load balancer using a given resource provider based on the specified search text. The search text must exactly match either a load balancer's ARN or its name.
+func FindLoadBalancerID(searchText string, provider ResourceProvider) (string, error) {
+	loadBalancers, err := provider.AllLoadBalancers()
+	if err != nil {
+		return "", err
+	}
+
+	for _, lb := range loadBalancers {
+		if searchText == lb.ID || searchText == lb.LoadBalancerName {
+			return lb.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("error: search text '%s' did not match the ARN or name of any load balancers", searchText)
+}
+
+func isLoadBalancerARN(text string) bool {
+	return strings.HasPrefix(text, "arn:") && strings.Contains(text, ":loadbalancer/")
+}
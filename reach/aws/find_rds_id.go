@@ -0,0 +1,49 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FindRDSInstanceID looks up the identifier for an RDS instance using a given resource provider based on the specified search text. The search text must exactly match the instance's identifier, ARN or endpoint address.
+func FindRDSInstanceID(searchText string, provider ResourceProvider) (string, error) {
+	instances, err := provider.AllRDSInstances()
+	if err != nil {
+		return "", err
+	}
+
+	for _, db := range instances {
+		if matchesRDSIdentifier(searchText, db.ID, db.ARN, db.EndpointAddress) {
+			return db.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("error: search text '%s' did not match the identifier, ARN or endpoint of any RDS instances", searchText)
+}
+
+// FindRDSClusterID looks up the identifier for an Aurora cluster using a given resource provider based on the specified search text. The search text must exactly match the cluster's identifier, ARN or one of its endpoint addresses.
+func FindRDSClusterID(searchText string, provider ResourceProvider) (string, error) {
+	clusters, err := provider.AllRDSClusters()
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range clusters {
+		if matchesRDSIdentifier(searchText, c.ID, c.ARN, c.EndpointAddress, c.ReaderEndpointAddress) {
+			return c.ID, nil
+		}
+	}
+
+	return "", fmt.Errorf("error: search text '%s' did not match the identifier, ARN or endpoints of any Aurora clusters", searchText)
+}
+
+func matchesRDSIdentifier(searchText string, values ...string) bool {
+	for _, value := range values {
+		// DB identifiers and endpoint addresses are case-insensitive, and are stored by AWS in lowercase.
+		if value != "" && strings.EqualFold(searchText, value) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindInternetGateway specifies the unique name for the internet gateway kind of resource.
+const ResourceKindInternetGateway = "InternetGateway"
+
+// ResourceKindEgressOnlyInternetGateway specifies the unique name for the egress-only internet gateway kind of resource.
+const ResourceKindEgressOnlyInternetGateway = "EgressOnlyInternetGateway"
+
+// An InternetGateway resource representation.
+type InternetGateway struct {
+	ID          string
+	NameTag     string `json:"NameTag,omitempty"`
+	OwnerID     string `json:"OwnerID,omitempty"`
+	Attachments []InternetGatewayAttachment
+}
+
+// An EgressOnlyInternetGateway resource representation. An egress-only internet gateway carries IPv6 traffic only, and only for connections initiated from within the VPC.
+type EgressOnlyInternetGateway struct {
+	ID          string
+	NameTag     string `json:"NameTag,omitempty"`
+	Attachments []InternetGatewayAttachment
+}
+
+// An InternetGatewayAttachment describes the attachment of an internet gateway (or an egress-only internet gateway) to a VPC.
+type InternetGatewayAttachment struct {
+	VPCID string
+	State string
+}
+
+// ToResource returns the internet gateway converted to a generalized Reach resource.
+func (igw InternetGateway) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindInternetGateway,
+		Properties: igw,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the internet gateway.
+func (igw InternetGateway) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindInternetGateway,
+		ID:     igw.ID,
+	}
+}
+
+// IsAttachedTo returns a boolean indicating whether or not the internet gateway is attached to the specified VPC.
+func (igw InternetGateway) IsAttachedTo(vpcID string) bool {
+	return attachedTo(igw.Attachments, vpcID)
+}
+
+// Name returns the internet gateway's ID, and, if available, its name tag value.
+func (igw InternetGateway) Name() string {
+	return gatewayName(igw.ID, igw.NameTag)
+}
+
+// ToResource returns the egress-only internet gateway converted to a generalized Reach resource.
+func (eigw EgressOnlyInternetGateway) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindEgressOnlyInternetGateway,
+		Properties: eigw,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the egress-only internet gateway.
+func (eigw EgressOnlyInternetGateway) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindEgressOnlyInternetGateway,
+		ID:     eigw.ID,
+	}
+}
+
+// IsAttachedTo returns a boolean indicating whether or not the egress-only internet gateway is attached to the specified VPC.
+func (eigw EgressOnlyInternetGateway) IsAttachedTo(vpcID string) bool {
+	return attachedTo(eigw.Attachments, vpcID)
+}
+
+// Name returns the egress-only internet gateway's ID, and, if available, its name tag value.
+func (eigw EgressOnlyInternetGateway) Name() string {
+	return gatewayName(eigw.ID, eigw.NameTag)
+}
+
+// attachedTo returns a boolean indicating whether any of the attachments connects a gateway to the specified VPC. The AWS API reports an attached internet gateway's attachment state as "available", and an attached egress-only internet gateway's as "attached".
+func attachedTo(attachments []InternetGatewayAttachment, vpcID string) bool {
+	for _, attachment := range attachments {
+		if attachment.VPCID == vpcID && (attachment.State == "available" || attachment.State == "attached") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func gatewayName(id, nameTag string) string {
+	if name := strings.TrimSpace(nameTag); name != "" {
+		return fmt.Sprintf("\"%s\" (%s)", name, id)
+	}
+	return id
+}
@@ -0,0 +1,242 @@
+package aws
+
+import (
+	"math/big"
+	"net"
+	"sort"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ipNetworkBoundaries returns every CIDR block that could affect the analysis of traffic between the given network points and another IP address: the security group rules (including the entries of any prefix lists they refer to), network ACL rules and routes that apply to each network point's network interface, and to any NAT gateway that the network interface's subnet routes traffic through.
+func ipNetworkBoundaries(points []reach.NetworkPoint, rc *reach.ResourceCollection) []*net.IPNet {
+	var boundaries []*net.IPNet
+
+	for _, point := range points {
+		eni := ElasticNetworkInterfaceFromNetworkPoint(point, rc)
+		if eni == nil {
+			continue
+		}
+
+		for _, id := range eni.SecurityGroupIDs {
+			resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSecurityGroup, ID: id})
+			if resource == nil {
+				continue
+			}
+			sg := resource.Properties.(SecurityGroup)
+
+			for _, rules := range [][]SecurityGroupRule{sg.InboundRules, sg.OutboundRules} {
+				for _, rule := range rules {
+					boundaries = append(boundaries, rule.TargetIPNetworks...)
+
+					for _, plID := range rule.TargetPrefixListIDs {
+						if resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindPrefixList, ID: plID}); resource != nil {
+							boundaries = append(boundaries, resource.Properties.(PrefixList).IPNetworks()...)
+						}
+					}
+				}
+			}
+		}
+
+		routeTable := subnetBoundaries(eni.SubnetID, rc, &boundaries)
+		if routeTable == nil {
+			continue
+		}
+
+		for _, route := range routeTable.Routes {
+			if route.Target.Type != RouteTableRouteTargetTypeNATGateway {
+				continue
+			}
+
+			if resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindNATGateway, ID: route.Target.ID}); resource != nil {
+				subnetBoundaries(resource.Properties.(NATGateway).SubnetID, rc, &boundaries)
+			}
+		}
+	}
+
+	return boundaries
+}
+
+// subnetBoundaries appends the CIDR blocks of the network ACL rules and routes that apply to the subnet to boundaries, and returns the subnet's route table (or nil, if the route table isn't available).
+func subnetBoundaries(subnetID string, rc *reach.ResourceCollection, boundaries *[]*net.IPNet) *RouteTable {
+	subnetResource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSubnet, ID: subnetID})
+	if subnetResource == nil {
+		return nil
+	}
+	subnet := subnetResource.Properties.(Subnet)
+
+	if resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindNetworkACL, ID: subnet.NetworkACLID}); resource != nil {
+		networkACL := resource.Properties.(NetworkACL)
+
+		for _, rules := range [][]NetworkACLRule{networkACL.InboundRules, networkACL.OutboundRules} {
+			for _, rule := range rules {
+				*boundaries = append(*boundaries, rule.TargetIPNetwork)
+			}
+		}
+	}
+
+	resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindRouteTable, ID: subnet.RouteTableID})
+	if resource == nil {
+		return nil
+	}
+	routeTable := resource.Properties.(RouteTable)
+
+	for _, route := range routeTable.Routes {
+		*boundaries = append(*boundaries, route.Destination)
+	}
+
+	return &routeTable
+}
+
+// splitIPNetwork splits the network into the fewest ranges of adjacent addresses such that, for each range, every boundary CIDR block contains either all of the range or none of it. Rules and routes only refer to addresses via CIDR blocks, so the analysis result is the same for every address in one of these ranges. Boundaries of the other IP version are ignored.
+//
+// The network is cut wherever a boundary begins or ends. Each cut separates addresses inside a boundary from addresses outside of it, so no two adjacent ranges could be merged.
+func splitIPNetwork(network *net.IPNet, boundaries []*net.IPNet) []reach.IPRange {
+	first, last, bits, ok := ipNetworkBounds(network)
+	if !ok {
+		return nil
+	}
+
+	cuts := map[string]*big.Int{first.String(): first}
+
+	for _, boundary := range boundaries {
+		bFirst, bLast, bBits, ok := ipNetworkBounds(boundary)
+		if !ok || bBits != bits || bLast.Cmp(first) < 0 || bFirst.Cmp(last) > 0 {
+			continue
+		}
+
+		if bFirst.Cmp(first) > 0 {
+			cuts[bFirst.String()] = bFirst
+		}
+		if bLast.Cmp(last) < 0 {
+			next := new(big.Int).Add(bLast, big.NewInt(1))
+			cuts[next.String()] = next
+		}
+	}
+
+	starts := make([]*big.Int, 0, len(cuts))
+	for _, start := range cuts {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Cmp(starts[j]) < 0 })
+
+	ranges := make([]reach.IPRange, len(starts))
+
+	for i, start := range starts {
+		end := last
+		if i+1 < len(starts) {
+			end = new(big.Int).Sub(starts[i+1], big.NewInt(1))
+		}
+
+		ranges[i] = reach.NewIPRange(bigToIP(start, bits), bigToIP(end, bits))
+	}
+
+	return ranges
+}
+
+// ipNetworkBounds returns the first and last addresses of the network as integers, along with the number of bits in the network's addresses (32 for IPv4, 128 for IPv6).
+func ipNetworkBounds(network *net.IPNet) (first, last *big.Int, bits int, ok bool) {
+	if network == nil {
+		return nil, nil, 0, false
+	}
+
+	ip := network.IP.To4()
+	bits = 8 * net.IPv4len
+	if ip == nil {
+		ip = network.IP.To16()
+		bits = 8 * net.IPv6len
+	}
+	if ip == nil {
+		return nil, nil, 0, false
+	}
+
+	ones, size := network.Mask.Size()
+	if bits == 8*net.IPv4len && size == 8*net.IPv6len {
+		ones -= 8 * (net.IPv6len - net.IPv4len) // an IPv4 network with a 16-byte mask
+	}
+	if ones < 0 || ones > bits {
+		return nil, nil, 0, false
+	}
+
+	first = new(big.Int).SetBytes(ip.Mask(net.CIDRMask(ones, bits)))
+	last = new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))
+	last.Add(last, first).Sub(last, big.NewInt(1))
+
+	return first, last, bits, true
+}
+
+func bigToIP(n *big.Int, bits int) net.IP {
+	ip := make(net.IP, bits/8)
+	b := n.Bytes()
+	copy(ip[len(ip)-len(b):], b)
+	return ip
+}
+
+// internetNetworks are the networks that together contain every address on the internet: all of IPv4, and IPv6's global unicast addresses.
+var internetNetworks = mustParseCIDRs("0.0.0.0/0", "2000::/3")
+
+// nonPublicIPv4Networks are the IPv4 networks whose addresses are never routed on the internet, such as private, shared and link-local addresses.
+var nonPublicIPv4Networks = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"224.0.0.0/3",
+)
+
+// internetRanges splits the internet's address space into ranges, in the same way as splitIPNetwork. Boundaries that only contain non-public addresses are ignored, since they can't affect traffic to or from the internet, and ranges that only contain non-public addresses are omitted.
+func internetRanges(boundaries []*net.IPNet) []reach.IPRange {
+	var publicBoundaries []*net.IPNet
+	for _, boundary := range boundaries {
+		if boundary != nil && !withinNonPublicIPv4Network(boundary.IP, lastIP(boundary)) {
+			publicBoundaries = append(publicBoundaries, boundary)
+		}
+	}
+
+	var ranges []reach.IPRange
+	for _, network := range internetNetworks {
+		for _, ipRange := range splitIPNetwork(network, publicBoundaries) {
+			if !withinNonPublicIPv4Network(ipRange.First, ipRange.Last) {
+				ranges = append(ranges, ipRange)
+			}
+		}
+	}
+
+	return ranges
+}
+
+func withinNonPublicIPv4Network(first, last net.IP) bool {
+	for _, network := range nonPublicIPv4Networks {
+		if network.Contains(first) && network.Contains(last) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lastIP(network *net.IPNet) net.IP {
+	_, last, bits, ok := ipNetworkBounds(network)
+	if !ok {
+		return nil
+	}
+
+	return bigToIP(last, bits)
+}
+
+func mustParseCIDRs(values ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(values))
+
+	for i, value := range values {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+
+	return networks
+}
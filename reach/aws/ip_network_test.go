@@ -0,0 +1,141 @@
+package aws
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestSplitIPNetwork(t *testing.T) {
+	cases := []struct {
+		name           string
+		network        string
+		boundaries     []*net.IPNet
+		expectedRanges string
+	}{
+		{
+			"no boundaries",
+			"10.20.0.0/16",
+			nil,
+			"10.20.0.0/16",
+		},
+		{
+			"boundary contains the network",
+			"10.20.0.0/16",
+			parseCIDRs(t, "0.0.0.0/0", "10.0.0.0/8"),
+			"10.20.0.0/16",
+		},
+		{
+			"boundary within the network",
+			"10.20.0.0/16",
+			parseCIDRs(t, "0.0.0.0/0", "10.20.5.0/24"),
+			"10.20.0.0-10.20.4.255, 10.20.5.0/24, 10.20.6.0-10.20.255.255",
+		},
+		{
+			"boundary at the start of the network",
+			"10.20.0.0/16",
+			parseCIDRs(t, "10.20.0.0/17"),
+			"10.20.0.0/17, 10.20.128.0/17",
+		},
+		{
+			"nested boundaries",
+			"10.20.0.0/16",
+			parseCIDRs(t, "10.20.5.0/24", "10.20.5.0/25"),
+			"10.20.0.0-10.20.4.255, 10.20.5.0/25, 10.20.5.128/25, 10.20.6.0-10.20.255.255",
+		},
+		{
+			"boundaries outside of the network are ignored",
+			"10.20.0.0/16",
+			parseCIDRs(t, "192.168.0.0/16", "10.21.0.0/16"),
+			"10.20.0.0/16",
+		},
+		{
+			"entire IPv4 address space",
+			"0.0.0.0/0",
+			parseCIDRs(t, "255.255.255.0/24"),
+			"0.0.0.0-255.255.254.255, 255.255.255.0/24",
+		},
+		{
+			"IPv6 network",
+			"2001:db8::/32",
+			parseCIDRs(t, "::/0", "2001:db8:5::/48", "10.0.0.0/8"),
+			"2001:db8::-2001:db8:4:ffff:ffff:ffff:ffff:ffff, 2001:db8:5::/48, 2001:db8:6::-2001:db8:ffff:ffff:ffff:ffff:ffff:ffff",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, network, err := net.ParseCIDR(tc.network)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ranges []string
+			for _, r := range splitIPNetwork(network, tc.boundaries) {
+				ranges = append(ranges, r.String())
+			}
+
+			if actual := strings.Join(ranges, ", "); actual != tc.expectedRanges {
+				reach.DiffErrorf(t, "ranges", tc.expectedRanges, actual)
+			}
+		})
+	}
+}
+
+func TestInternetRanges(t *testing.T) {
+	cases := []struct {
+		name           string
+		boundaries     []*net.IPNet
+		expectedRanges string
+	}{
+		{
+			"no boundaries",
+			nil,
+			"0.0.0.0/0, 2000::/3",
+		},
+		{
+			"private boundaries are ignored",
+			parseCIDRs(t, "0.0.0.0/0", "10.0.0.0/16", "192.168.1.0/24"),
+			"0.0.0.0/0, 2000::/3",
+		},
+		{
+			"public boundaries split the internet",
+			parseCIDRs(t, "203.0.113.0/24", "2001:db8::/32"),
+			"0.0.0.0-203.0.112.255, 203.0.113.0/24, 203.0.114.0-255.255.255.255, 2000::-2001:db7:ffff:ffff:ffff:ffff:ffff:ffff, 2001:db8::/32, 2001:db9::-3fff:ffff:ffff:ffff:ffff:ffff:ffff:ffff",
+		},
+		{
+			"ranges of only private addresses are omitted",
+			parseCIDRs(t, "9.0.0.0/8", "11.0.0.0/8"),
+			"0.0.0.0-8.255.255.255, 9.0.0.0/8, 11.0.0.0/8, 12.0.0.0-255.255.255.255, 2000::/3",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var ranges []string
+			for _, r := range internetRanges(tc.boundaries) {
+				ranges = append(ranges, r.String())
+			}
+
+			if actual := strings.Join(ranges, ", "); actual != tc.expectedRanges {
+				reach.DiffErrorf(t, "ranges", tc.expectedRanges, actual)
+			}
+		})
+	}
+}
+
+func parseCIDRs(t *testing.T, values ...string) []*net.IPNet {
+	t.Helper()
+
+	var result []*net.IPNet
+	for _, value := range values {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result = append(result, network)
+	}
+	return result
+}
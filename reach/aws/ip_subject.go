@@ -0,0 +1,72 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// SubjectKindIPAddress specifies the unique name for the IP address kind of subject. An IP address that belongs to a network interface in AWS is analyzed as part of that network interface; any other IP address is analyzed as a network point outside of AWS.
+const SubjectKindIPAddress = "IPAddress"
+
+// SubjectKindIPNetwork specifies the unique name for the IP network (CIDR block) kind of subject. The network is analyzed as the fewest ranges of addresses that each have a uniform analysis result.
+const SubjectKindIPNetwork = "IPNetwork"
+
+// NewIPAddressSubject returns a new subject for the specified IP address (e.g. "203.0.113.10").
+func NewIPAddressSubject(ip string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, reach.NewSubjectError(fmt.Sprintf("invalid IP address: '%s'", ip))
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindIPAddress,
+		ID:     parsed.String(),
+		Role:   role,
+	}, nil
+}
+
+// NewIPNetworkSubject returns a new subject for the specified CIDR block (e.g. "10.20.0.0/16" or "2001:db8::/32").
+func NewIPNetworkSubject(cidr string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, reach.NewSubjectError(fmt.Sprintf("invalid CIDR block: '%s'", cidr))
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindIPNetwork,
+		ID:     network.String(),
+		Role:   role,
+	}, nil
+}
+
+// SubjectKindInternet specifies the unique name for the internet kind of subject. The internet is analyzed as ranges of public IP addresses outside of AWS, in the same way as an IP network subject.
+const SubjectKindInternet = "Internet"
+
+// InternetSubjectID is the identifier that refers to the internet as a subject.
+const InternetSubjectID = "internet"
+
+// NewInternetSubject returns a new subject for the internet.
+func NewInternetSubject(role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindInternet,
+		ID:     InternetSubjectID,
+		Role:   role,
+	}, nil
+}
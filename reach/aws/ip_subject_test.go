@@ -0,0 +1,39 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestNewSubjectForIPAddressesAndCIDRBlocks(t *testing.T) {
+	cases := []struct {
+		identifier   string
+		expectedKind string
+		expectedID   string
+	}{
+		{"203.0.113.10", SubjectKindIPAddress, "203.0.113.10"},
+		{"2001:db8::1", SubjectKindIPAddress, "2001:db8::1"},
+		{"10.20.0.0/16", SubjectKindIPNetwork, "10.20.0.0/16"},
+		{"10.20.5.7/16", SubjectKindIPNetwork, "10.20.0.0/16"},
+		{"2001:db8::/32", SubjectKindIPNetwork, "2001:db8::/32"},
+		{"Internet", SubjectKindInternet, InternetSubjectID},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.identifier, func(t *testing.T) {
+			subject, err := NewSubject(tc.identifier, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if subject.Kind != tc.expectedKind {
+				reach.DiffErrorf(t, "kind", tc.expectedKind, subject.Kind)
+			}
+
+			if subject.ID != tc.expectedID {
+				reach.DiffErrorf(t, "ID", tc.expectedID, subject.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+// ResourceKindLoadBalancer specifies the unique name for the load balancer kind of resource.
+const ResourceKindLoadBalancer = "LoadBalancer"
+
+// Load balancer types supported by Reach.
+const (
+	LoadBalancerTypeApplication = "application"
+	LoadBalancerTypeNetwork     = "network"
+)
+
+// A LoadBalancer resource representation, for an Application Load Balancer or a Network Load Balancer. The load balancer's ID is its ARN.
+type LoadBalancer struct {
+	ID                         string
+	LoadBalancerName           string
+	Type                       string
+	Scheme                     string
+	State                      string
+	VPCID                      string
+	SecurityGroupIDs           []string `json:"SecurityGroupIDs,omitempty"`
+	ElasticNetworkInterfaceIDs []string
+	Listeners                  []LoadBalancerListener
+	TargetGroupIDs             []string `json:"TargetGroupIDs,omitempty"`
+}
+
+// A LoadBalancerListener describes a protocol and port on which a load balancer accepts connections.
+type LoadBalancerListener struct {
+	Protocol string // e.g. "HTTPS", "TCP_UDP"
+	Port     int64
+}
+
+// ToResource returns the load balancer converted to a generalized Reach resource.
+func (lb LoadBalancer) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindLoadBalancer,
+		Properties: lb,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the load balancer.
+func (lb LoadBalancer) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindLoadBalancer,
+		ID:     lb.ID,
+	}
+}
+
+// Name returns the load balancer's name and type.
+func (lb LoadBalancer) Name() string {
+	return fmt.Sprintf("%s (%s load balancer)", lb.LoadBalancerName, lb.Type)
+}
+
+// Dependencies returns a collection of the load balancer's resource dependencies. The network interfaces and target groups are retrieved concurrently.
+func (lb LoadBalancer) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	var lookups []dependencyLookup
+
+	for _, id := range lb.ElasticNetworkInterfaceIDs {
+		id := id
+		lookups = append(lookups, func() (*reach.ResourceCollection, error) {
+			eni, err := provider.ElasticNetworkInterface(id)
+			if err != nil {
+				return nil, err
+			}
+
+			rc, err := eni.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(eni.ToResourceReference(), eni.ToResource())
+
+			return rc, nil
+		})
+	}
+
+	for _, id := range lb.TargetGroupIDs {
+		id := id
+		lookups = append(lookups, func() (*reach.ResourceCollection, error) {
+			targetGroup, err := provider.TargetGroup(id)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := reach.NewResourceCollection()
+			rc.Put(targetGroup.ToResourceReference(), targetGroup.ToResource())
+
+			return rc, nil
+		})
+	}
+
+	return resolveDependencies(lookups...)
+}
+
+// usesSecurityGroups returns a boolean indicating whether or not security groups filter the load balancer's traffic. Network Load Balancers don't have security groups.
+func (lb LoadBalancer) usesSecurityGroups() bool {
+	return lb.Type != LoadBalancerTypeNetwork
+}
+
+func (lb LoadBalancer) isActive() bool {
+	return lb.State == "active"
+}
+
+func (lb LoadBalancer) networkPoints(rc *reach.ResourceCollection) []reach.NetworkPoint {
+	var points []reach.NetworkPoint
+
+	for _, id := range lb.ElasticNetworkInterfaceIDs {
+		eni := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindElasticNetworkInterface,
+			ID:     id,
+		}).Properties.(ElasticNetworkInterface)
+		points = append(points, eni.getNetworkPoints(lb.ToResourceReference())...)
+	}
+
+	return points
+}
+
+// listenerTraffic returns the traffic that the load balancer accepts from clients, based on its listeners.
+func (lb LoadBalancer) listenerTraffic() (reach.TrafficContent, error) {
+	var segments []reach.TrafficContent
+
+	for _, listener := range lb.Listeners {
+		tc, err := trafficForLoadBalancerProtocol(listener.Protocol, listener.Port)
+		if err != nil {
+			return reach.TrafficContent{}, fmt.Errorf("unable to use listener on port %d: %v", listener.Port, err)
+		}
+
+		segments = append(segments, tc)
+	}
+
+	return reach.NewTrafficContentFromMergingMultiple(segments)
+}
+
+// LoadBalancerFromLineage returns the load balancer from the given lineage, or nil if the lineage doesn't contain a load balancer.
+func LoadBalancerFromLineage(lineage []reach.ResourceReference, rc *reach.ResourceCollection) *LoadBalancer {
+	for _, ref := range lineage {
+		if ref.Domain == ResourceDomainAWS && ref.Kind == ResourceKindLoadBalancer {
+			resource := rc.Get(ref)
+			if resource == nil {
+				return nil
+			}
+
+			lb := resource.Properties.(LoadBalancer)
+			return &lb
+		}
+	}
+
+	return nil
+}
+
+// trafficForLoadBalancerProtocol returns the traffic that a load balancer uses for the given listener or target group protocol at the given port.
+func trafficForLoadBalancerProtocol(protocol string, port int64) (reach.TrafficContent, error) {
+	if port < 0 || port > 65535 {
+		return reach.TrafficContent{}, fmt.Errorf("invalid port: %d", port)
+	}
+
+	ports, err := set.NewPortSetFromRange(uint16(port), uint16(port))
+	if err != nil {
+		return reach.TrafficContent{}, err
+	}
+
+	switch strings.ToUpper(protocol) {
+	case "HTTP", "HTTPS", "TCP", "TLS":
+		return reach.NewTrafficContentForPorts(reach.ProtocolTCP, ports), nil
+	case "UDP":
+		return reach.NewTrafficContentForPorts(reach.ProtocolUDP, ports), nil
+	case "TCP_UDP":
+		return reach.NewTrafficContentFromMergingMultiple([]reach.TrafficContent{
+			reach.NewTrafficContentForPorts(reach.ProtocolTCP, ports),
+			reach.NewTrafficContentForPorts(reach.ProtocolUDP, ports),
+		})
+	default:
+		return reach.TrafficContent{}, fmt.Errorf("unsupported load balancer protocol: '%s'", protocol)
+	}
+}
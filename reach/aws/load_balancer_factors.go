@@ -0,0 +1,137 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// FactorKindLoadBalancerListeners specifies the unique name for the load balancer listeners kind of factor.
+const FactorKindLoadBalancerListeners = "LoadBalancerListeners"
+
+// FactorKindLoadBalancerTargets specifies the unique name for the load balancer targets kind of factor.
+const FactorKindLoadBalancerTargets = "LoadBalancerTargets"
+
+type loadBalancerListenersFactor struct {
+	Active    bool
+	Listeners []LoadBalancerListener
+}
+
+type loadBalancerTargetsFactor struct {
+	Active  bool
+	Targets []loadBalancerTargetsFactorComponent
+}
+
+type loadBalancerTargetsFactorComponent struct {
+	TargetGroup reach.ResourceReference
+	Target      TargetGroupTarget
+	Traffic     reach.TrafficContent
+}
+
+// FactorDetails describes whether the load balancer is active, and lists its listeners.
+func (f loadBalancerListenersFactor) FactorDetails() reach.FactorDetails {
+	var listeners []string
+	for _, l := range f.Listeners {
+		listeners = append(listeners, fmt.Sprintf("%s %d", l.Protocol, l.Port))
+	}
+
+	return reach.FactorDetails{
+		Attributes: map[string]string{
+			"active":    strconv.FormatBool(f.Active),
+			"listeners": strings.Join(listeners, ", "),
+		},
+	}
+}
+
+// FactorDetails describes whether the load balancer is active, and each target that the load balancer forwards traffic to.
+func (f loadBalancerTargetsFactor) FactorDetails() reach.FactorDetails {
+	details := reach.FactorDetails{
+		Attributes: map[string]string{
+			"active": strconv.FormatBool(f.Active),
+		},
+	}
+
+	for _, c := range f.Targets {
+		details.Components = append(details.Components, reach.FactorComponent{
+			Resource:    c.TargetGroup,
+			Description: fmt.Sprintf("target %s on port %d", c.Target.ID, c.Target.Port),
+			Traffic:     c.Traffic,
+		})
+	}
+
+	return details
+}
+
+// newListenersFactor describes the traffic the load balancer accepts as a destination. A load balancer only accepts traffic on its listeners' ports, and only while it's active.
+func (lb LoadBalancer) newListenersFactor() (*reach.Factor, error) {
+	traffic := reach.NewTrafficContentForNoTraffic()
+
+	if lb.isActive() {
+		var err error
+		traffic, err = lb.listenerTraffic()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &reach.Factor{
+		Kind:          FactorKindLoadBalancerListeners,
+		Resource:      lb.ToResourceReference(),
+		Traffic:       traffic,
+		ReturnTraffic: reach.NewTrafficContentForAllTraffic(),
+		Properties: loadBalancerListenersFactor{
+			Active:    lb.isActive(),
+			Listeners: lb.Listeners,
+		},
+	}, nil
+}
+
+// newTargetsFactor describes the traffic the load balancer sends as a source. A load balancer only sends traffic (including health checks) to the targets registered with its target groups, so no traffic is allowed to any other destination.
+func (lb LoadBalancer) newTargetsFactor(rc *reach.ResourceCollection, destination reach.NetworkPoint) (*reach.Factor, error) {
+	var components []loadBalancerTargetsFactorComponent
+	var segments []reach.TrafficContent
+
+	if lb.isActive() {
+		for _, id := range lb.TargetGroupIDs {
+			ref := reach.ResourceReference{
+				Domain: ResourceDomainAWS,
+				Kind:   ResourceKindTargetGroup,
+				ID:     id,
+			}
+
+			targetGroup := rc.Get(ref).Properties.(TargetGroup)
+
+			for _, target := range targetGroup.targetsForNetworkPoint(destination, rc) {
+				traffic, err := targetGroup.trafficToTarget(target)
+				if err != nil {
+					return nil, err
+				}
+
+				components = append(components, loadBalancerTargetsFactorComponent{
+					TargetGroup: ref,
+					Target:      target,
+					Traffic:     traffic,
+				})
+				segments = append(segments, traffic)
+			}
+		}
+	}
+
+	traffic, err := reach.NewTrafficContentFromMergingMultiple(segments)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reach.Factor{
+		Kind:          FactorKindLoadBalancerTargets,
+		Resource:      lb.ToResourceReference(),
+		Traffic:       traffic,
+		ReturnTraffic: reach.NewTrafficContentForAllTraffic(),
+		Properties: loadBalancerTargetsFactor{
+			Active:  lb.isActive(),
+			Targets: components,
+		},
+	}, nil
+}
@@ -0,0 +1,24 @@
+package aws
+
+import "github.com/luhring/reach/reach"
+
+// SubjectKindLoadBalancer specifies the unique name for the load balancer kind of subject.
+const SubjectKindLoadBalancer = "LoadBalancer"
+
+// NewLoadBalancerSubject returns a new subject for the specified load balancer (identified by its ARN).
+func NewLoadBalancerSubject(id string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	if len(id) < 1 {
+		return nil, reach.NewSubjectError(reach.ErrSubjectIDValidation)
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindLoadBalancer,
+		ID:     id,
+		Role:   role,
+	}, nil
+}
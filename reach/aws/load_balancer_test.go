@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"net"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestTrafficForLoadBalancerProtocol(t *testing.T) {
+	port := func(protocol reach.Protocol) reach.TrafficContent {
+		ports, err := set.NewPortSetFromRange(8080, 8080)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return reach.NewTrafficContentForPorts(protocol, ports)
+	}
+
+	both, err := reach.NewTrafficContentFromMergingMultiple([]reach.TrafficContent{port(reach.ProtocolTCP), port(reach.ProtocolUDP)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		protocol      string
+		expected      reach.TrafficContent
+		expectedError bool
+	}{
+		{"HTTP", port(reach.ProtocolTCP), false},
+		{"https", port(reach.ProtocolTCP), false},
+		{"TLS", port(reach.ProtocolTCP), false},
+		{"UDP", port(reach.ProtocolUDP), false},
+		{"TCP_UDP", both, false},
+		{"GENEVE", reach.TrafficContent{}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.protocol, func(t *testing.T) {
+			traffic, err := trafficForLoadBalancerProtocol(tc.protocol, 8080)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected an error, but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if traffic.String() != tc.expected.String() {
+				reach.DiffErrorf(t, "traffic", tc.expected, traffic)
+			}
+		})
+	}
+}
+
+func TestTargetGroupTrafficToTargetIncludesHealthCheck(t *testing.T) {
+	tg := TargetGroup{
+		TargetGroupName: "web",
+		Protocol:        "HTTP",
+		Port:            80,
+		HealthCheckPort: "8081",
+	}
+
+	traffic, err := tg.trafficToTarget(TargetGroupTarget{ID: "i-1", Port: 8080})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "TCP 8080-8081\n"; traffic.String() != expected {
+		reach.DiffErrorf(t, "traffic", expected, traffic.String())
+	}
+}
+
+func TestLoadBalancerTargetsFactor(t *testing.T) {
+	instanceWithIP := func(name, ip string) (EC2Instance, ElasticNetworkInterface) {
+		eni := ElasticNetworkInterface{
+			ID:                   "eni-" + name,
+			PrivateIPv4Addresses: []net.IP{net.ParseIP(ip)},
+		}
+		instance := EC2Instance{
+			ID: "i-" + name,
+			NetworkInterfaceAttachments: []NetworkInterfaceAttachment{
+				{ID: "eni-attach-" + name, ElasticNetworkInterfaceID: eni.ID, DeviceIndex: 0},
+			},
+		}
+
+		return instance, eni
+	}
+
+	web, webENI := instanceWithIP("web", "10.0.1.10")
+	other, otherENI := instanceWithIP("other", "10.0.1.11")
+
+	tg := TargetGroup{
+		ID:              "arn:aws:elasticloadbalancing:us-east-1:000000000000:targetgroup/web/0000000000000000",
+		TargetGroupName: "web",
+		Protocol:        "HTTP",
+		Port:            8080,
+		TargetType:      TargetGroupTargetTypeInstance,
+		HealthCheckPort: "8081",
+		Targets:         []TargetGroupTarget{{ID: web.ID}},
+	}
+
+	rc := reach.NewResourceCollection()
+	for _, instance := range []EC2Instance{web, other} {
+		rc.Put(instance.ToResourceReference(), instance.ToResource())
+	}
+	for _, eni := range []ElasticNetworkInterface{webENI, otherENI} {
+		rc.Put(eni.ToResourceReference(), eni.ToResource())
+	}
+	rc.Put(tg.ToResourceReference(), tg.ToResource())
+
+	targetTraffic, err := tg.trafficToTarget(TargetGroupTarget{ID: web.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		state       string
+		destination reach.NetworkPoint
+		expected    reach.TrafficContent
+	}{
+		{
+			"registered target",
+			"active",
+			webENI.getNetworkPoints(web.ToResourceReference())[0],
+			targetTraffic,
+		},
+		{
+			"instance that isn't a registered target",
+			"active",
+			otherENI.getNetworkPoints(other.ToResourceReference())[0],
+			reach.NewTrafficContentForNoTraffic(),
+		},
+		{
+			"registered target of a load balancer that isn't active",
+			"provisioning",
+			webENI.getNetworkPoints(web.ToResourceReference())[0],
+			reach.NewTrafficContentForNoTraffic(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lb := LoadBalancer{
+				ID:             "arn:aws:elasticloadbalancing:us-east-1:000000000000:loadbalancer/app/alb/0000000000000000",
+				Type:           LoadBalancerTypeApplication,
+				State:          tc.state,
+				TargetGroupIDs: []string{tg.ID},
+			}
+
+			factor, err := lb.newTargetsFactor(rc, tc.destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if factor.Traffic.String() != tc.expected.String() {
+				reach.DiffErrorf(t, "traffic", tc.expected, factor.Traffic)
+			}
+		})
+	}
+}
+
+func TestLoadBalancerListenersFactor(t *testing.T) {
+	https, err := trafficForLoadBalancerProtocol("HTTPS", 443)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		state    string
+		expected reach.TrafficContent
+	}{
+		{"active load balancer", "active", https},
+		{"load balancer that isn't active", "provisioning", reach.NewTrafficContentForNoTraffic()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			lb := LoadBalancer{
+				Type:      LoadBalancerTypeApplication,
+				State:     tc.state,
+				Listeners: []LoadBalancerListener{{Protocol: "HTTPS", Port: 443}},
+			}
+
+			factor, err := lb.newListenersFactor()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if factor.Traffic.String() != tc.expected.String() {
+				reach.DiffErrorf(t, "traffic", tc.expected, factor.Traffic)
+			}
+		})
+	}
+}
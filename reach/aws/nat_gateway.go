@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindNATGateway specifies the unique name for the NAT gateway kind of resource.
+const ResourceKindNATGateway = "NATGateway"
+
+// NATGatewayStateAvailable is the state of a NAT gateway that's able to carry network traffic.
+const NATGatewayStateAvailable = "available"
+
+// A NATGateway resource representation.
+type NATGateway struct {
+	ID                        string
+	NameTag                   string `json:"NameTag,omitempty"`
+	State                     string
+	VPCID                     string
+	SubnetID                  string
+	ElasticNetworkInterfaceID string
+	PrivateIPv4Address        net.IP `json:"PrivateIPv4Address,omitempty"`
+	PublicIPv4Address         net.IP `json:"PublicIPv4Address,omitempty"`
+}
+
+// ToResource returns the NAT gateway converted to a generalized Reach resource.
+func (nat NATGateway) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindNATGateway,
+		Properties: nat,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the NAT gateway.
+func (nat NATGateway) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindNATGateway,
+		ID:     nat.ID,
+	}
+}
+
+// Dependencies returns a collection of the NAT gateway's resource dependencies: the network interface that the NAT gateway uses, and the subnet, network ACL, route table and internet gateways that network traffic passes through on its way to and from the NAT gateway. The route table's other dependencies aren't needed, since the NAT gateway only sends network traffic to the internet.
+func (nat NATGateway) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	rc := reach.NewResourceCollection()
+
+	eni, err := provider.ElasticNetworkInterface(nat.ElasticNetworkInterfaceID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(eni.ToResourceReference(), eni.ToResource())
+
+	subnet, err := provider.Subnet(nat.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindSubnet,
+		ID:     subnet.ID,
+	}, subnet.ToResource())
+
+	networkACL, err := provider.NetworkACL(subnet.NetworkACLID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(networkACL.ToResourceReference(), networkACL.ToResource())
+
+	routeTable, err := provider.RouteTable(subnet.RouteTableID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+
+	internetGateways, err := routeTable.internetGatewayDependencies(provider)
+	if err != nil {
+		return nil, err
+	}
+	rc.Merge(internetGateways)
+
+	return rc, nil
+}
+
+// IsAvailable returns a boolean indicating whether or not the NAT gateway is able to carry network traffic.
+func (nat NATGateway) IsAvailable() bool {
+	return nat.State == NATGatewayStateAvailable
+}
+
+// Name returns the NAT gateway's ID, and, if available, its name tag value.
+func (nat NATGateway) Name() string {
+	return gatewayName(nat.ID, nat.NameTag)
+}
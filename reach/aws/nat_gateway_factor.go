@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+// FactorKindNATGateway specifies the unique name for the NAT gateway kind of factor.
+const FactorKindNATGateway = "NATGateway"
+
+type natGatewayFactor struct {
+	NATGateway                reach.ResourceReference
+	State                     string
+	TranslatedSourceIPAddress net.IP `json:"TranslatedSourceIPAddress,omitempty"`
+	SameSubnet                bool
+	NetworkACL                reach.ResourceReference
+	SourceLeg                 natGatewayLeg
+	DestinationLeg            natGatewayLeg
+	RouteTable                reach.ResourceReference
+	MatchedRoute              *RouteTableRoute `json:"MatchedRoute,omitempty"`
+}
+
+// A natGatewayLeg holds the network ACL rules that apply to one leg of the path through a NAT gateway: either from the source to the NAT gateway, or from the NAT gateway to the destination.
+type natGatewayLeg struct {
+	RuleComponentsForwardDirection []networkACLRulesFactorComponent
+	RuleComponentsReturnDirection  []networkACLRulesFactorComponent
+}
+
+// FactorDetails describes the NAT gateway's state and translated source IP address, the route that sends traffic from the NAT gateway's subnet to the internet, and each network ACL rule that applies to the path through the NAT gateway.
+func (f natGatewayFactor) FactorDetails() reach.FactorDetails {
+	details := reach.FactorDetails{
+		Attributes: map[string]string{
+			"natGateway": f.NATGateway.ID,
+			"state":      f.State,
+			"sameSubnet": strconv.FormatBool(f.SameSubnet),
+			"networkACL": f.NetworkACL.ID,
+			"routeTable": f.RouteTable.ID,
+		},
+	}
+
+	if f.TranslatedSourceIPAddress != nil {
+		details.Attributes["translatedSourceIPAddress"] = f.TranslatedSourceIPAddress.String()
+	}
+
+	if f.MatchedRoute != nil {
+		details.Attributes["matchedRoute"] = f.MatchedRoute.String()
+	}
+
+	for _, leg := range []struct {
+		name string
+		leg  natGatewayLeg
+	}{
+		{"source to NAT gateway", f.SourceLeg},
+		{"NAT gateway to destination", f.DestinationLeg},
+	} {
+		details.Components = append(details.Components, networkACLRuleComponentDetails(leg.leg.RuleComponentsForwardDirection, leg.name+", forward traffic")...)
+		details.Components = append(details.Components, networkACLRuleComponentDetails(leg.leg.RuleComponentsReturnDirection, leg.name+", return traffic")...)
+	}
+
+	return details
+}
+
+// newNATGatewayFactor describes what happens to network traffic that the ENI sends through the specified NAT gateway. The NAT gateway replaces the source IP address with its own public IP address, so the network traffic crosses the network ACL of the NAT gateway's subnet twice: inbound from the ENI, and then outbound to the destination (and the reverse for return traffic). The NAT gateway's subnet must then route the traffic to an internet gateway. NAT gateways only carry TCP, UDP and ICMP traffic.
+func (eni ElasticNetworkInterface) newNATGatewayFactor(id string, rc *reach.ResourceCollection, p reach.Perspective) (*reach.Factor, error) {
+	ref := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindNATGateway,
+		ID:     id,
+	}
+
+	natResource := rc.Get(ref)
+	if natResource == nil {
+		return nil, fmt.Errorf("couldn't find NAT gateway: %s", id)
+	}
+	nat := natResource.Properties.(NATGateway)
+
+	natENIResource := rc.Get(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindElasticNetworkInterface,
+		ID:     nat.ElasticNetworkInterfaceID,
+	})
+	if natENIResource == nil {
+		return nil, fmt.Errorf("couldn't find network interface for NAT gateway %s: %s", nat.ID, nat.ElasticNetworkInterfaceID)
+	}
+	natENI := natENIResource.Properties.(ElasticNetworkInterface)
+	natENI.PublicIPv4Address = nat.PublicIPv4Address // the NAT gateway's Elastic IP address
+
+	subnetResource := rc.Get(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindSubnet,
+		ID:     nat.SubnetID,
+	})
+	if subnetResource == nil {
+		return nil, fmt.Errorf("couldn't find subnet: %s", nat.SubnetID)
+	}
+	subnet := subnetResource.Properties.(Subnet)
+
+	networkACLRef := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindNetworkACL,
+		ID:     subnet.NetworkACLID,
+	}
+	networkACLResource := rc.Get(networkACLRef)
+	if networkACLResource == nil {
+		return nil, fmt.Errorf("couldn't find network ACL: %s", subnet.NetworkACLID)
+	}
+	networkACL := networkACLResource.Properties.(NetworkACL)
+
+	routeTableRef := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRouteTable,
+		ID:     subnet.RouteTableID,
+	}
+	routeTableResource := rc.Get(routeTableRef)
+	if routeTableResource == nil {
+		return nil, fmt.Errorf("couldn't find route table: %s", subnet.RouteTableID)
+	}
+	routeTable := routeTableResource.Properties.(RouteTable)
+
+	props := natGatewayFactor{
+		NATGateway:                ref,
+		State:                     nat.State,
+		TranslatedSourceIPAddress: nat.PublicIPv4Address,
+		SameSubnet:                eni.SubnetID == nat.SubnetID,
+		NetworkACL:                networkACLRef,
+		RouteTable:                routeTableRef,
+		MatchedRoute:              routeTable.RouteForIP(p.Other.IPAddress),
+	}
+
+	protocols, err := natGatewayProtocols()
+	if err != nil {
+		return nil, err
+	}
+
+	forwardSegments := []reach.TrafficContent{protocols}
+	returnSegments := []reach.TrafficContent{protocols}
+
+	// First leg: from the ENI to the NAT gateway. Within a subnet, network traffic doesn't cross a network ACL.
+	if !props.SameSubnet {
+		traffic, components, err := networkACL.factorComponents(networkACLRuleDirectionInbound, p.Self.IPAddress)
+		if err != nil {
+			return nil, err
+		}
+		props.SourceLeg.RuleComponentsForwardDirection = components
+		forwardSegments = append(forwardSegments, traffic)
+
+		traffic, components, err = networkACL.factorComponents(networkACLRuleDirectionOutbound, p.Self.IPAddress)
+		if err != nil {
+			return nil, err
+		}
+		props.SourceLeg.RuleComponentsReturnDirection = components
+		returnSegments = append(returnSegments, traffic)
+	}
+
+	// Second leg: from the NAT gateway to the destination.
+	traffic, components, err := networkACL.factorComponents(networkACLRuleDirectionOutbound, p.Other.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	props.DestinationLeg.RuleComponentsForwardDirection = components
+	forwardSegments = append(forwardSegments, traffic)
+
+	traffic, components, err = networkACL.factorComponents(networkACLRuleDirectionInbound, p.Other.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+	props.DestinationLeg.RuleComponentsReturnDirection = components
+	returnSegments = append(returnSegments, traffic)
+
+	forwardTraffic := reach.NewTrafficContentForNoTraffic()
+	returnTraffic := reach.NewTrafficContentForNoTraffic()
+
+	route := props.MatchedRoute
+	if nat.IsAvailable() && route != nil && !route.IsBlackhole() && natENI.routeReachesOutsideAWS(*route, p.Other.IPAddress, reach.SubjectRoleSource, rc) {
+		forwardTraffic, err = reach.NewTrafficContentFromIntersectingMultiple(forwardSegments)
+		if err != nil {
+			return nil, err
+		}
+
+		returnTraffic, err = reach.NewTrafficContentFromIntersectingMultiple(returnSegments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &reach.Factor{
+		Kind:          FactorKindNATGateway,
+		Resource:      ref,
+		Traffic:       forwardTraffic,
+		ReturnTraffic: returnTraffic,
+		Properties:    props,
+	}, nil
+}
+
+// natGatewayProtocols returns the traffic that a NAT gateway is able to carry.
+func natGatewayProtocols() (reach.TrafficContent, error) {
+	return reach.NewTrafficContentFromMergingMultiple([]reach.TrafficContent{
+		reach.NewTrafficContentForPorts(reach.ProtocolTCP, set.NewFullPortSet()),
+		reach.NewTrafficContentForPorts(reach.ProtocolUDP, set.NewFullPortSet()),
+		reach.NewTrafficContentForICMP(reach.ProtocolICMPv4, set.NewFullICMPSet()),
+	})
+}
+
+// translatedSourceIPAddress returns the source IP address that the destination sees, if the given factors include a NAT gateway that translates the source IP address. Otherwise, it returns nil.
+func translatedSourceIPAddress(factors []reach.Factor) net.IP {
+	if f, _ := getNATGatewayFactor(factors); f != nil {
+		return f.Properties.(natGatewayFactor).TranslatedSourceIPAddress
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package aws
+
+import (
+	"net"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestNewNATGatewayFactor(t *testing.T) {
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	all := reach.NewTrafficContentForAllTraffic()
+	none := reach.NewTrafficContentForNoTraffic()
+	tcp := reach.NewTrafficContentForPorts(reach.ProtocolTCP, set.NewFullPortSet())
+
+	protocols, err := natGatewayProtocols()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowAll := []NetworkACLRule{{Number: 100, TrafficContent: all, TargetIPNetwork: mustParseCIDR("0.0.0.0/0"), Action: NetworkACLRuleActionAllow}}
+	allowTCP := []NetworkACLRule{{Number: 100, TrafficContent: tcp, TargetIPNetwork: mustParseCIDR("0.0.0.0/0"), Action: NetworkACLRuleActionAllow}}
+
+	igwRoute := RouteTableRoute{
+		Destination: mustParseCIDR("0.0.0.0/0"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeInternetGateway, ID: "igw-abc123"},
+		State:       RouteTableRouteStateActive,
+	}
+
+	eni := ElasticNetworkInterface{
+		ID:                   "eni-private",
+		SubnetID:             "subnet-private",
+		VPCID:                "vpc-abc123",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.2.10")},
+	}
+
+	newCollection := func(state string, networkACL NetworkACL, routes ...RouteTableRoute) *reach.ResourceCollection {
+		rc := reach.NewResourceCollection()
+
+		nat := NATGateway{
+			ID:                        "nat-abc123",
+			State:                     state,
+			VPCID:                     "vpc-abc123",
+			SubnetID:                  "subnet-public",
+			ElasticNetworkInterfaceID: "eni-nat",
+			PrivateIPv4Address:        net.ParseIP("10.0.1.100"),
+			PublicIPv4Address:         net.ParseIP("54.0.0.100"),
+		}
+		rc.Put(nat.ToResourceReference(), nat.ToResource())
+
+		natENI := ElasticNetworkInterface{
+			ID:                   "eni-nat",
+			SubnetID:             "subnet-public",
+			VPCID:                "vpc-abc123",
+			PrivateIPv4Addresses: []net.IP{nat.PrivateIPv4Address},
+		}
+		rc.Put(natENI.ToResourceReference(), natENI.ToResource())
+
+		subnet := Subnet{ID: "subnet-public", NetworkACLID: networkACL.ID, RouteTableID: "rtb-public", VPCID: "vpc-abc123"}
+		rc.Put(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSubnet, ID: subnet.ID}, subnet.ToResource())
+		rc.Put(networkACL.ToResourceReference(), networkACL.ToResource())
+
+		routeTable := RouteTable{ID: "rtb-public", VPCID: "vpc-abc123", Routes: routes}
+		rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+
+		igw := InternetGateway{
+			ID:          "igw-abc123",
+			Attachments: []InternetGatewayAttachment{{VPCID: "vpc-abc123", State: "available"}},
+		}
+		rc.Put(igw.ToResourceReference(), igw.ToResource())
+
+		return rc
+	}
+
+	cases := []struct {
+		name                  string
+		rc                    *reach.ResourceCollection
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+	}{
+		{
+			"available NAT gateway with a route to the internet",
+			newCollection(NATGatewayStateAvailable, NetworkACL{ID: "acl-abc123", InboundRules: allowAll, OutboundRules: allowAll}, igwRoute),
+			protocols,
+			protocols,
+		},
+		{
+			"NAT gateway that isn't available",
+			newCollection("deleted", NetworkACL{ID: "acl-abc123", InboundRules: allowAll, OutboundRules: allowAll}, igwRoute),
+			none,
+			none,
+		},
+		{
+			"no route from the NAT gateway's subnet to the internet",
+			newCollection(NATGatewayStateAvailable, NetworkACL{ID: "acl-abc123", InboundRules: allowAll, OutboundRules: allowAll}),
+			none,
+			none,
+		},
+		{
+			"network ACL in the NAT gateway's subnet",
+			newCollection(NATGatewayStateAvailable, NetworkACL{ID: "acl-abc123", InboundRules: allowAll, OutboundRules: allowTCP}, igwRoute),
+			tcp,
+			tcp,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := reach.Perspective{
+				Self:      reach.NetworkPoint{IPAddress: eni.PrivateIPv4Addresses[0]},
+				Other:     reach.NetworkPoint{IPAddress: net.ParseIP("203.0.113.10")},
+				SelfRole:  reach.SubjectRoleSource,
+				OtherRole: reach.SubjectRoleDestination,
+			}
+
+			factor, err := eni.newNATGatewayFactor("nat-abc123", tc.rc, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if factor.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, factor.Traffic)
+			}
+
+			if factor.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+				reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, factor.ReturnTraffic)
+			}
+
+			if ip := translatedSourceIPAddress([]reach.Factor{*factor}); !ip.Equal(net.ParseIP("54.0.0.100")) {
+				reach.DiffErrorf(t, "translated source IP address", "54.0.0.100", ip)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"encoding/json"
+	"fmt"
 	"net"
 
 	"github.com/luhring/reach/reach"
@@ -33,6 +34,25 @@ func (action NetworkACLRuleAction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(action.String())
 }
 
+// UnmarshalJSON parses the JSON representation of a NetworkACLRuleAction.
+func (action *NetworkACLRuleAction) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	switch s {
+	case NetworkACLRuleActionDeny.String():
+		*action = NetworkACLRuleActionDeny
+	case NetworkACLRuleActionAllow.String():
+		*action = NetworkACLRuleActionAllow
+	default:
+		return fmt.Errorf("unrecognized network ACL rule action: '%s'", s)
+	}
+
+	return nil
+}
+
 // An NetworkACLRule resource representation.
 type NetworkACLRule struct {
 	Number          int64
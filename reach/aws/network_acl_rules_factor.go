@@ -2,6 +2,7 @@ package aws
 
 import (
 	"fmt"
+	"net"
 	"sort"
 
 	"github.com/luhring/reach/reach"
@@ -17,6 +18,16 @@ type networkACLRulesFactor struct {
 	RuleComponentsReturnDirection  []networkACLRulesFactorComponent
 }
 
+// FactorDetails describes each network ACL rule that contributes forward or return traffic to the factor.
+func (f networkACLRulesFactor) FactorDetails() reach.FactorDetails {
+	return reach.FactorDetails{
+		Components: append(
+			networkACLRuleComponentDetails(f.RuleComponentsForwardDirection, "forward traffic"),
+			networkACLRuleComponentDetails(f.RuleComponentsReturnDirection, "return traffic")...,
+		),
+	}
+}
+
 func (eni ElasticNetworkInterface) newNetworkACLRulesFactor(
 	rc *reach.ResourceCollection,
 	p reach.Perspective,
@@ -70,11 +81,11 @@ func (eni ElasticNetworkInterface) newNetworkACLRulesFactor(
 }
 
 func (nacl NetworkACL) effectOnForwardTraffic(p reach.Perspective, awsP perspective) (reach.TrafficContent, []networkACLRulesFactorComponent, error) {
-	return nacl.factorComponents(awsP.networkACLRuleDirectionForForwardTraffic, p, awsP)
+	return nacl.factorComponents(awsP.networkACLRuleDirectionForForwardTraffic, p.Other.IPAddress)
 }
 
 func (nacl NetworkACL) effectOnReturnTraffic(p reach.Perspective, awsP perspective) (reach.TrafficContent, []networkACLRulesFactorComponent, error) {
-	return nacl.factorComponents(awsP.networkACLRuleDirectionForReturnTraffic, p, awsP)
+	return nacl.factorComponents(awsP.networkACLRuleDirectionForReturnTraffic, p.Other.IPAddress)
 }
 
 func (nacl NetworkACL) rulesForDirection(direction networkACLRuleDirection) []NetworkACLRule {
@@ -85,7 +96,8 @@ func (nacl NetworkACL) rulesForDirection(direction networkACLRuleDirection) []Ne
 	return nacl.InboundRules
 }
 
-func (nacl NetworkACL) factorComponents(direction networkACLRuleDirection, p reach.Perspective, awsP perspective) (reach.TrafficContent, []networkACLRulesFactorComponent, error) {
+// factorComponents returns the traffic that the network ACL allows in the given direction for packets to or from the given IP address, along with the rules that allow it.
+func (nacl NetworkACL) factorComponents(direction networkACLRuleDirection, ip net.IP) (reach.TrafficContent, []networkACLRulesFactorComponent, error) {
 	rules := nacl.rulesForDirection(direction)
 
 	sort.Slice(rules, func(i, j int) bool {
@@ -98,7 +110,7 @@ func (nacl NetworkACL) factorComponents(direction networkACLRuleDirection, p rea
 
 	for _, rule := range rules {
 		// Make sure rule matches
-		match := rule.matchByIP(p.Other.IPAddress)
+		match := rule.matchByIP(ip)
 		if match == nil {
 			continue // this rule doesn't match
 		}
@@ -1,6 +1,10 @@
 package aws
 
-import "github.com/luhring/reach/reach"
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+)
 
 type networkACLRulesFactorComponent struct {
 	NetworkACL    reach.ResourceReference
@@ -9,3 +13,18 @@ type networkACLRulesFactorComponent struct {
 	Match         networkACLRuleMatch
 	Traffic       reach.TrafficContent
 }
+
+// networkACLRuleComponentDetails describes each network ACL rule that contributes traffic in the given direction (e.g. "return traffic").
+func networkACLRuleComponentDetails(components []networkACLRulesFactorComponent, trafficDirection string) []reach.FactorComponent {
+	var result []reach.FactorComponent
+
+	for _, c := range components {
+		result = append(result, reach.FactorComponent{
+			Resource:    c.NetworkACL,
+			Description: fmt.Sprintf("%s rule #%d for IP CIDR block %s (%s)", c.RuleDirection, c.RuleNumber, c.Match.Requirement.String(), trafficDirection),
+			Traffic:     c.Traffic,
+		})
+	}
+
+	return result
+}
@@ -1,15 +1,57 @@
 package aws
 
 import (
+	"fmt"
+	"net"
+	"strings"
+
 	"github.com/luhring/reach/reach"
 )
 
-// NewSubject looks up an AWS resource using the given provider and returns it as a new subject.
+// NewSubject looks up an AWS resource using the given provider and returns it as a new subject. The identifier can be "internet", an IP address or a CIDR block, an AWS service in a region (e.g. "s3:us-east-1"), a VPC endpoint ID, an elastic network interface ID, a load balancer's ARN, or text that matches an EC2 instance (see FindEC2InstanceID). If the identifier doesn't match any EC2 instance, it's used to find a load balancer (by name), an RDS instance or an Aurora cluster (by identifier, ARN or endpoint address), in that order.
 func NewSubject(identifier string, provider ResourceProvider) (*reach.Subject, error) {
-	// We'll assume the identifier refers to an EC2 instance, even if it doesn't begin with 'i-'.
-	// Later, we might use this string to recognize different kinds of AWS resources.
+	if strings.EqualFold(identifier, InternetSubjectID) {
+		return NewInternetSubject(reach.SubjectRoleNone)
+	}
+
+	if net.ParseIP(identifier) != nil {
+		return NewIPAddressSubject(identifier, reach.SubjectRoleNone)
+	}
+
+	if _, _, err := net.ParseCIDR(identifier); err == nil {
+		return NewIPNetworkSubject(identifier, reach.SubjectRoleNone)
+	}
+
+	if serviceName, ok := AWSServiceName(identifier); ok {
+		return NewAWSServiceSubject(serviceName, reach.SubjectRoleNone)
+	}
+
+	if isVPCEndpointID(identifier) {
+		return newVPCEndpointSubjectFromIdentifier(identifier, provider)
+	}
+
+	if isElasticNetworkInterfaceID(identifier) {
+		return NewElasticNetworkInterfaceSubject(identifier, reach.SubjectRoleNone)
+	}
+
+	if isLoadBalancerARN(identifier) {
+		return newLoadBalancerSubjectFromIdentifier(identifier, provider)
+	}
+
 	ec2InstanceID, err := FindEC2InstanceID(identifier, provider)
 	if err != nil {
+		fallbacks := []func(identifier string, provider ResourceProvider) (*reach.Subject, error){
+			newLoadBalancerSubjectFromIdentifier,
+			newRDSInstanceSubjectFromIdentifier,
+			newRDSClusterSubjectFromIdentifier,
+		}
+
+		for _, fallback := range fallbacks {
+			if subject, fallbackErr := fallback(identifier, provider); fallbackErr == nil {
+				return subject, nil
+			}
+		}
+
 		return nil, err
 	}
 
@@ -20,3 +62,47 @@ func NewSubject(identifier string, provider ResourceProvider) (*reach.Subject, e
 
 	return subject, nil
 }
+
+// newVPCEndpointSubjectFromIdentifier returns a subject for an interface endpoint's network interfaces. A gateway endpoint doesn't have network interfaces, so its subject is the AWS service that the endpoint provides access to.
+func newVPCEndpointSubjectFromIdentifier(identifier string, provider ResourceProvider) (*reach.Subject, error) {
+	vpce, err := provider.VPCEndpoint(identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	switch vpce.Type {
+	case VPCEndpointTypeInterface:
+		return NewVPCEndpointSubject(vpce.ID, reach.SubjectRoleNone)
+	case VPCEndpointTypeGateway:
+		return NewAWSServiceSubject(vpce.ServiceName, reach.SubjectRoleNone)
+	default:
+		return nil, fmt.Errorf("unable to use VPC endpoint '%s': endpoints of type '%s' are not supported", vpce.ID, vpce.Type)
+	}
+}
+
+func newLoadBalancerSubjectFromIdentifier(identifier string, provider ResourceProvider) (*reach.Subject, error) {
+	id, err := FindLoadBalancerID(identifier, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLoadBalancerSubject(id, reach.SubjectRoleNone)
+}
+
+func newRDSInstanceSubjectFromIdentifier(identifier string, provider ResourceProvider) (*reach.Subject, error) {
+	id, err := FindRDSInstanceID(identifier, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRDSInstanceSubject(id, reach.SubjectRoleNone)
+}
+
+func newRDSClusterSubjectFromIdentifier(identifier string, provider ResourceProvider) (*reach.Subject, error) {
+	id, err := FindRDSClusterID(identifier, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewRDSClusterSubject(id, reach.SubjectRoleNone)
+}
@@ -0,0 +1,232 @@
+package aws
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// Prefixes of selectors, which can match any number of subjects.
+const (
+	tagSelectorPrefix              = "tag:"    // e.g. "tag:Role=web"
+	securityGroupSelectorPrefix    = "sg:"     // e.g. "sg:sg-0123"
+	subnetSelectorPrefix           = "subnet:" // e.g. "subnet:subnet-abc"
+	vpcSelectorPrefix              = "vpc:"    // e.g. "vpc:vpc-xyz"
+	autoScalingGroupSelectorPrefix = "asg:"    // e.g. "asg:my-autoscaling-group"
+)
+
+// autoScalingGroupNameTag is the tag that EC2 Auto Scaling adds to each instance it launches, with the name of the Auto Scaling group as the value.
+const autoScalingGroupNameTag = "aws:autoscaling:groupName"
+
+// globCharacters are the characters that make an identifier a name glob rather than a single subject.
+const globCharacters = "*?["
+
+// NewSubjects looks up the AWS resources that the given identifier refers to and returns them as new subjects. In addition to any identifier accepted by NewSubject, the identifier can be one of the following selectors, each of which can match any number of subjects (but at least one):
+//
+// A tag selector ("tag:Key=Value") matches the EC2 instances that have a tag with the given key and a value that matches the given glob pattern. The selector "tag:Key" matches the EC2 instances that have a tag with the given key, regardless of value.
+//
+// An Auto Scaling group selector ("asg:name") matches the EC2 instances launched by the Auto Scaling group with the given name.
+//
+// A security group selector ("sg:id"), subnet selector ("subnet:id") or VPC selector ("vpc:id") matches the network interfaces that use the given security group, or that are in the given subnet or VPC. A matching network interface that's attached to an EC2 instance is returned as the EC2 instance; any other matching network interface (e.g. one used by a load balancer or a NAT gateway) is returned on its own.
+//
+// A name glob (e.g. "web-*") matches the EC2 instances whose name tags match the glob pattern. See path.Match for the pattern syntax.
+func NewSubjects(identifier string, provider ResourceProvider) ([]*reach.Subject, error) {
+	var subjects []*reach.Subject
+	var err error
+
+	switch {
+	case strings.HasPrefix(identifier, tagSelectorPrefix):
+		key, pattern, parseErr := parseTagSelector(identifier)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		subjects, err = ec2InstanceSubjects(provider, func(instance EC2Instance) bool {
+			return instanceHasTag(instance, key, pattern)
+		})
+	case strings.HasPrefix(identifier, autoScalingGroupSelectorPrefix):
+		name := strings.TrimPrefix(identifier, autoScalingGroupSelectorPrefix)
+
+		subjects, err = ec2InstanceSubjects(provider, func(instance EC2Instance) bool {
+			return instance.Tags[autoScalingGroupNameTag] == name
+		})
+	case strings.HasPrefix(identifier, securityGroupSelectorPrefix):
+		id := strings.TrimPrefix(identifier, securityGroupSelectorPrefix)
+		subjects, err = elasticNetworkInterfaceSubjects(provider, provider.ElasticNetworkInterfacesForSecurityGroup, id)
+	case strings.HasPrefix(identifier, subnetSelectorPrefix):
+		id := strings.TrimPrefix(identifier, subnetSelectorPrefix)
+		subjects, err = elasticNetworkInterfaceSubjects(provider, provider.ElasticNetworkInterfacesForSubnet, id)
+	case strings.HasPrefix(identifier, vpcSelectorPrefix):
+		id := strings.TrimPrefix(identifier, vpcSelectorPrefix)
+		subjects, err = elasticNetworkInterfaceSubjects(provider, provider.ElasticNetworkInterfacesForVPC, id)
+	case strings.ContainsAny(identifier, globCharacters):
+		if _, matchErr := path.Match(identifier, ""); matchErr != nil {
+			return nil, fmt.Errorf("unable to use name glob '%s': %v", identifier, matchErr)
+		}
+
+		subjects, err = ec2InstanceSubjects(provider, func(instance EC2Instance) bool {
+			matched, _ := path.Match(identifier, instance.NameTag)
+			return matched
+		})
+	default:
+		subject, err := NewSubject(identifier, provider)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*reach.Subject{subject}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subjects) == 0 {
+		return nil, fmt.Errorf("error: '%s' did not match any EC2 instances or network interfaces", identifier)
+	}
+
+	return subjects, nil
+}
+
+// ec2InstanceSubjects returns a subject for each EC2 instance that matches, ordered by name tag and then by ID.
+func ec2InstanceSubjects(provider ResourceProvider, match func(instance EC2Instance) bool) ([]*reach.Subject, error) {
+	instances, err := provider.AllEC2Instances()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []EC2Instance
+
+	for _, instance := range instances {
+		if match(instance) {
+			matches = append(matches, instance)
+		}
+	}
+
+	return subjectsForEC2Instances(matches)
+}
+
+// elasticNetworkInterfaceSubjects returns a subject for each network interface returned by lookup for the given ID (e.g. of a security group). Network interfaces attached to an EC2 instance are returned as the EC2 instance (ordered by name tag and then by ID), followed by the remaining network interfaces (ordered by ID).
+func elasticNetworkInterfaceSubjects(provider ResourceProvider, lookup func(id string) ([]ElasticNetworkInterface, error), id string) ([]*reach.Subject, error) {
+	networkInterfaces, err := lookup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := provider.AllEC2Instances()
+	if err != nil {
+		return nil, err
+	}
+
+	instanceForENI := make(map[string]EC2Instance)
+	for _, instance := range instances {
+		for _, id := range instance.elasticNetworkInterfaceIDs() {
+			instanceForENI[id] = instance
+		}
+	}
+
+	var matchedInstances []EC2Instance
+	var matchedENIIDs []string
+	seenInstances := make(map[string]bool)
+
+	for _, eni := range networkInterfaces {
+		if instance, attached := instanceForENI[eni.ID]; attached {
+			if !seenInstances[instance.ID] {
+				seenInstances[instance.ID] = true
+				matchedInstances = append(matchedInstances, instance)
+			}
+			continue
+		}
+
+		matchedENIIDs = append(matchedENIIDs, eni.ID)
+	}
+
+	subjects, err := subjectsForEC2Instances(matchedInstances)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(matchedENIIDs)
+
+	for _, id := range matchedENIIDs {
+		subject, err := NewElasticNetworkInterfaceSubject(id, reach.SubjectRoleNone)
+		if err != nil {
+			return nil, err
+		}
+
+		subjects = append(subjects, subject)
+	}
+
+	return subjects, nil
+}
+
+func subjectsForEC2Instances(instances []EC2Instance) ([]*reach.Subject, error) {
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].NameTag != instances[j].NameTag {
+			return instances[i].NameTag < instances[j].NameTag
+		}
+		return instances[i].ID < instances[j].ID
+	})
+
+	subjects := make([]*reach.Subject, len(instances))
+
+	for i, instance := range instances {
+		subject, err := NewEC2InstanceSubject(instance.ID, reach.SubjectRoleNone)
+		if err != nil {
+			return nil, err
+		}
+
+		subjects[i] = subject
+	}
+
+	return subjects, nil
+}
+
+func instanceHasTag(instance EC2Instance, key, pattern string) bool {
+	value, exists := instance.Tags[key]
+	if !exists {
+		return false
+	}
+
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
+
+// parseTagSelector returns the tag key and value pattern of a tag selector. If the selector doesn't specify a value, the pattern matches any value.
+func parseTagSelector(selector string) (key, pattern string, err error) {
+	body := strings.TrimPrefix(selector, tagSelectorPrefix)
+
+	key, pattern = body, "*"
+	if i := strings.Index(body, "="); i >= 0 {
+		key, pattern = body[:i], body[i+1:]
+	}
+
+	if key == "" {
+		return "", "", fmt.Errorf("unable to use tag selector '%s': tag key must not be empty", selector)
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		return "", "", fmt.Errorf("unable to use tag selector '%s': %v", selector, err)
+	}
+
+	return key, pattern, nil
+}
+
+// SubjectName returns a display name for the subject, which includes the name tag of an EC2 instance or a network interface, if it has one.
+func SubjectName(subject *reach.Subject, provider ResourceProvider) string {
+	switch subject.Kind {
+	case SubjectKindEC2Instance:
+		if instance, err := provider.EC2Instance(subject.ID); err == nil {
+			return instance.Name()
+		}
+	case SubjectKindElasticNetworkInterface:
+		if eni, err := provider.ElasticNetworkInterface(subject.ID); err == nil {
+			return eni.Name()
+		}
+	}
+
+	return subject.ID
+}
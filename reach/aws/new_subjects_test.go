@@ -0,0 +1,182 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+// subjectsProvider provides fixed lists of EC2 instances and network interfaces. Methods not overridden here panic via the nil embedded interface.
+type subjectsProvider struct {
+	ResourceProvider
+	instances         []EC2Instance
+	networkInterfaces []ElasticNetworkInterface
+}
+
+func (p subjectsProvider) AllEC2Instances() ([]EC2Instance, error) {
+	return p.instances, nil
+}
+
+func (p subjectsProvider) ElasticNetworkInterfacesForSecurityGroup(id string) ([]ElasticNetworkInterface, error) {
+	return p.networkInterfacesWhere(func(eni ElasticNetworkInterface) bool {
+		for _, sgID := range eni.SecurityGroupIDs {
+			if sgID == id {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+func (p subjectsProvider) ElasticNetworkInterfacesForSubnet(id string) ([]ElasticNetworkInterface, error) {
+	return p.networkInterfacesWhere(func(eni ElasticNetworkInterface) bool { return eni.SubnetID == id }), nil
+}
+
+func (p subjectsProvider) ElasticNetworkInterfacesForVPC(id string) ([]ElasticNetworkInterface, error) {
+	return p.networkInterfacesWhere(func(eni ElasticNetworkInterface) bool { return eni.VPCID == id }), nil
+}
+
+func (p subjectsProvider) networkInterfacesWhere(match func(eni ElasticNetworkInterface) bool) []ElasticNetworkInterface {
+	var result []ElasticNetworkInterface
+	for _, eni := range p.networkInterfaces {
+		if match(eni) {
+			result = append(result, eni)
+		}
+	}
+	return result
+}
+
+func TestNewSubjects(t *testing.T) {
+	instance := func(id, name, eniID string, tags map[string]string) EC2Instance {
+		return EC2Instance{
+			ID:                          id,
+			NameTag:                     name,
+			Tags:                        tags,
+			NetworkInterfaceAttachments: []NetworkInterfaceAttachment{{ElasticNetworkInterfaceID: eniID}},
+		}
+	}
+	eni := func(id, subnetID, sgID string) ElasticNetworkInterface {
+		return ElasticNetworkInterface{ID: id, SubnetID: subnetID, VPCID: "vpc-1", SecurityGroupIDs: []string{sgID}}
+	}
+
+	provider := subjectsProvider{
+		instances: []EC2Instance{
+			instance("i-app-1", "app-1", "eni-app-1", map[string]string{"Role": "app"}),
+			instance("i-web-2", "web-2", "eni-web-2", map[string]string{"Role": "web", autoScalingGroupNameTag: "web-asg"}),
+			instance("i-web-1", "web-1", "eni-web-1", map[string]string{"Role": "web", "Team": "edge", autoScalingGroupNameTag: "web-asg"}),
+		},
+		networkInterfaces: []ElasticNetworkInterface{
+			eni("eni-web-1", "subnet-web", "sg-web"),
+			eni("eni-web-2", "subnet-web", "sg-web"),
+			eni("eni-lb", "subnet-web", "sg-web"), // e.g. a load balancer's network interface, which isn't attached to an instance
+			eni("eni-app-1", "subnet-app", "sg-app"),
+		},
+	}
+
+	cases := []struct {
+		name          string
+		identifier    string
+		expectedIDs   []string
+		expectedError bool
+	}{
+		{
+			"tag selector with value",
+			"tag:Role=web",
+			[]string{"i-web-1", "i-web-2"},
+			false,
+		},
+		{
+			"tag selector with value glob",
+			"tag:Role=a*",
+			[]string{"i-app-1"},
+			false,
+		},
+		{
+			"tag selector without value",
+			"tag:Team",
+			[]string{"i-web-1"},
+			false,
+		},
+		{
+			"tag selector with empty key",
+			"tag:=web",
+			nil,
+			true,
+		},
+		{
+			"Auto Scaling group selector",
+			"asg:web-asg",
+			[]string{"i-web-1", "i-web-2"},
+			false,
+		},
+		{
+			"security group selector with unattached network interface",
+			"sg:sg-web",
+			[]string{"i-web-1", "i-web-2", "eni-lb"},
+			false,
+		},
+		{
+			"security group selector without matches",
+			"sg:sg-missing",
+			nil,
+			true,
+		},
+		{
+			"subnet selector",
+			"subnet:subnet-app",
+			[]string{"i-app-1"},
+			false,
+		},
+		{
+			"VPC selector",
+			"vpc:vpc-1",
+			[]string{"i-app-1", "i-web-1", "i-web-2", "eni-lb"},
+			false,
+		},
+		{
+			"name glob",
+			"web-*",
+			[]string{"i-web-1", "i-web-2"},
+			false,
+		},
+		{
+			"name glob without matches",
+			"db-*",
+			nil,
+			true,
+		},
+		{
+			"malformed name glob",
+			"web-[",
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			subjects, err := NewSubjects(tc.identifier, provider)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("expected an error, but got subjects: %v", subjects)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []string
+			for _, subject := range subjects {
+				ids = append(ids, subject.ID)
+			}
+
+			if fmt.Sprint(ids) != fmt.Sprint(tc.expectedIDs) {
+				reach.DiffErrorf(t, "subject IDs", tc.expectedIDs, ids)
+			}
+		})
+	}
+}
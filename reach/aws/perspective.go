@@ -7,6 +7,7 @@ type perspective struct {
 	networkACLRuleDirectionForForwardTraffic networkACLRuleDirection
 	networkACLRulesForReturnTraffic          func(nacl NetworkACL) []NetworkACLRule
 	networkACLRuleDirectionForReturnTraffic  networkACLRuleDirection
+	routeTableAffectsForwardTraffic          bool
 }
 
 func newPerspectiveSourceOriented() perspective {
@@ -23,6 +24,7 @@ func newPerspectiveSourceOriented() perspective {
 			return nacl.InboundRules
 		},
 		networkACLRuleDirectionForReturnTraffic: networkACLRuleDirectionInbound,
+		routeTableAffectsForwardTraffic:         true,
 	}
 }
 
@@ -40,5 +42,6 @@ func newPerspectiveDestinationOriented() perspective {
 			return nacl.OutboundRules
 		},
 		networkACLRuleDirectionForReturnTraffic: networkACLRuleDirectionOutbound,
+		routeTableAffectsForwardTraffic:         false,
 	}
 }
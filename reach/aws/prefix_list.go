@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindPrefixList specifies the unique name for the managed prefix list kind of resource.
+const ResourceKindPrefixList = "PrefixList"
+
+// A PrefixList resource representation, for a managed prefix list (either customer-managed or AWS-managed). Security group rules can refer to a prefix list instead of to an IP CIDR block, in which case the rule applies to every CIDR block in the prefix list.
+type PrefixList struct {
+	ID             string
+	PrefixListName string
+	OwnerID        string `json:"OwnerID,omitempty"`
+	Entries        []PrefixListEntry
+}
+
+// A PrefixListEntry is a CIDR block in a managed prefix list.
+type PrefixListEntry struct {
+	CIDR        *net.IPNet
+	Description string `json:"Description,omitempty"`
+}
+
+// ToResource returns the prefix list converted to a generalized Reach resource.
+func (pl PrefixList) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindPrefixList,
+		Properties: pl,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the prefix list.
+func (pl PrefixList) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindPrefixList,
+		ID:     pl.ID,
+	}
+}
+
+// Name returns the prefix list's ID, and, if available, its name.
+func (pl PrefixList) Name() string {
+	if pl.PrefixListName != "" {
+		return fmt.Sprintf("%s (%s)", pl.PrefixListName, pl.ID)
+	}
+
+	return pl.ID
+}
+
+// IPNetworks returns the CIDR blocks of the prefix list's entries.
+func (pl PrefixList) IPNetworks() []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(pl.Entries))
+
+	for _, entry := range pl.Entries {
+		if entry.CIDR != nil {
+			networks = append(networks, entry.CIDR)
+		}
+	}
+
+	return networks
+}
+
+// entryForIP returns the first of the prefix list's entries that contains the given IP address, or nil if no entry contains it.
+func (pl PrefixList) entryForIP(ip net.IP) *PrefixListEntry {
+	for _, entry := range pl.Entries {
+		if entry.CIDR != nil && entry.CIDR.Contains(ip) {
+			entry := entry
+			return &entry
+		}
+	}
+
+	return nil
+}
+
+// String returns the entry's CIDR block, along with its description, if it has one.
+func (entry PrefixListEntry) String() string {
+	if entry.Description != "" {
+		return fmt.Sprintf("%s (%s)", entry.CIDR, entry.Description)
+	}
+
+	return entry.CIDR.String()
+}
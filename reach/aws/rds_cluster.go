@@ -0,0 +1,93 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindRDSCluster specifies the unique name for the RDS cluster kind of resource.
+const ResourceKindRDSCluster = "RDSCluster"
+
+// An RDSCluster resource representation, for an Aurora DB cluster. The cluster's ID is its DB cluster identifier.
+type RDSCluster struct {
+	ID                     string
+	ARN                    string
+	Engine                 string
+	Status                 string
+	EndpointAddress        string `json:"EndpointAddress,omitempty"`
+	ReaderEndpointAddress  string `json:"ReaderEndpointAddress,omitempty"`
+	Port                   int64
+	MemberInstanceIDs      []string
+	WriterMemberInstanceID string `json:"WriterMemberInstanceID,omitempty"`
+}
+
+// ToResource returns the RDS cluster converted to a generalized Reach resource.
+func (c RDSCluster) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindRDSCluster,
+		Properties: c,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the RDS cluster.
+func (c RDSCluster) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRDSCluster,
+		ID:     c.ID,
+	}
+}
+
+// Name returns the RDS cluster's identifier and engine.
+func (c RDSCluster) Name() string {
+	return fmt.Sprintf("%s (%s cluster)", c.ID, c.Engine)
+}
+
+// Dependencies returns a collection of the RDS cluster's resource dependencies, which are the cluster's member instances and their dependencies. The members are retrieved concurrently.
+func (c RDSCluster) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	lookups := make([]dependencyLookup, len(c.MemberInstanceIDs))
+
+	for i, id := range c.MemberInstanceIDs {
+		id := id
+		lookups[i] = func() (*reach.ResourceCollection, error) {
+			db, err := provider.RDSInstance(id)
+			if err != nil {
+				return nil, err
+			}
+
+			rc, err := db.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(db.ToResourceReference(), db.ToResource())
+
+			return rc, nil
+		}
+	}
+
+	return resolveDependencies(lookups...)
+}
+
+// networkPoints returns the network points of all of the cluster's member instances, since the cluster's endpoints can resolve to any member (e.g. after a failover). Each network point's lineage ends with the cluster.
+func (c RDSCluster) networkPoints(rc *reach.ResourceCollection) []reach.NetworkPoint {
+	var points []reach.NetworkPoint
+
+	for _, id := range c.MemberInstanceIDs {
+		db := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindRDSInstance,
+			ID:     id,
+		}).Properties.(RDSInstance)
+
+		for _, point := range db.networkPoints(rc) {
+			lineage := make([]reach.ResourceReference, len(point.Lineage), len(point.Lineage)+1)
+			copy(lineage, point.Lineage)
+			point.Lineage = append(lineage, c.ToResourceReference())
+
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
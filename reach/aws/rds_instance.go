@@ -0,0 +1,125 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindRDSInstance specifies the unique name for the RDS instance kind of resource.
+const ResourceKindRDSInstance = "RDSInstance"
+
+// An RDSInstance resource representation, for an RDS DB instance (including an instance that's a member of an Aurora cluster). The instance's ID is its DB instance identifier.
+type RDSInstance struct {
+	ID                         string
+	ARN                        string
+	Engine                     string
+	Status                     string
+	EndpointAddress            string `json:"EndpointAddress,omitempty"`
+	Port                       int64
+	VPCID                      string
+	SubnetIDs                  []string // from the DB subnet group
+	SecurityGroupIDs           []string
+	ElasticNetworkInterfaceIDs []string
+	ClusterID                  string `json:"ClusterID,omitempty"`
+}
+
+// rdsInstanceAvailableStatuses are the DB instance statuses in which the database accepts connections.
+var rdsInstanceAvailableStatuses = map[string]bool{
+	"available":                       true,
+	"backing-up":                      true,
+	"configuring-enhanced-monitoring": true,
+	"configuring-iam-database-auth":   true,
+	"configuring-log-exports":         true,
+	"converting-to-vpc":               true,
+	"maintenance":                     true,
+	"modifying":                       true,
+	"moving-to-vpc":                   true,
+	"renaming":                        true,
+	"resetting-master-credentials":    true,
+	"storage-optimization":            true,
+	"upgrading":                       true,
+}
+
+// ToResource returns the RDS instance converted to a generalized Reach resource.
+func (db RDSInstance) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindRDSInstance,
+		Properties: db,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the RDS instance.
+func (db RDSInstance) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRDSInstance,
+		ID:     db.ID,
+	}
+}
+
+// Name returns the RDS instance's identifier and engine.
+func (db RDSInstance) Name() string {
+	return fmt.Sprintf("%s (%s database)", db.ID, db.Engine)
+}
+
+// Dependencies returns a collection of the RDS instance's resource dependencies. The dependencies of each network interface are retrieved concurrently.
+func (db RDSInstance) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	lookups := make([]dependencyLookup, len(db.ElasticNetworkInterfaceIDs))
+
+	for i, id := range db.ElasticNetworkInterfaceIDs {
+		id := id
+		lookups[i] = func() (*reach.ResourceCollection, error) {
+			eni, err := provider.ElasticNetworkInterface(id)
+			if err != nil {
+				return nil, err
+			}
+
+			rc, err := eni.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(eni.ToResourceReference(), eni.ToResource())
+
+			return rc, nil
+		}
+	}
+
+	return resolveDependencies(lookups...)
+}
+
+func (db RDSInstance) isAvailable() bool {
+	return rdsInstanceAvailableStatuses[db.Status]
+}
+
+func (db RDSInstance) networkPoints(rc *reach.ResourceCollection) []reach.NetworkPoint {
+	var points []reach.NetworkPoint
+
+	for _, id := range db.ElasticNetworkInterfaceIDs {
+		eni := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindElasticNetworkInterface,
+			ID:     id,
+		}).Properties.(ElasticNetworkInterface)
+		points = append(points, eni.getNetworkPoints(db.ToResourceReference())...)
+	}
+
+	return points
+}
+
+// RDSInstanceFromLineage returns the RDS instance from the given lineage, or nil if the lineage doesn't contain an RDS instance.
+func RDSInstanceFromLineage(lineage []reach.ResourceReference, rc *reach.ResourceCollection) *RDSInstance {
+	for _, ref := range lineage {
+		if ref.Domain == ResourceDomainAWS && ref.Kind == ResourceKindRDSInstance {
+			resource := rc.Get(ref)
+			if resource == nil {
+				return nil
+			}
+
+			db := resource.Properties.(RDSInstance)
+			return &db
+		}
+	}
+
+	return nil
+}
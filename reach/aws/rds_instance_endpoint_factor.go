@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+// FactorKindRDSInstanceEndpoint specifies the unique name for the RDS instance endpoint kind of factor.
+const FactorKindRDSInstanceEndpoint = "RDSInstanceEndpoint"
+
+type rdsInstanceEndpointFactor struct {
+	Status    string
+	Available bool
+	Port      int64
+}
+
+// FactorDetails describes the RDS instance's status and port.
+func (f rdsInstanceEndpointFactor) FactorDetails() reach.FactorDetails {
+	return reach.FactorDetails{
+		Attributes: map[string]string{
+			"status":    f.Status,
+			"available": strconv.FormatBool(f.Available),
+			"port":      strconv.FormatInt(f.Port, 10),
+		},
+	}
+}
+
+// newEndpointFactor describes the traffic allowed by the RDS instance's status and, when the instance is the destination, by its port. A database only accepts connections on its port, so the analysis (and any assertion about it) is scoped to that port.
+func (db RDSInstance) newEndpointFactor(role reach.SubjectRole) (*reach.Factor, error) {
+	traffic := reach.NewTrafficContentForNoTraffic()
+	returnTraffic := reach.NewTrafficContentForNoTraffic()
+
+	if db.isAvailable() {
+		returnTraffic = reach.NewTrafficContentForAllTraffic()
+
+		if role == reach.SubjectRoleDestination {
+			var err error
+			traffic, err = db.portTraffic()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			traffic = reach.NewTrafficContentForAllTraffic()
+		}
+	}
+
+	return &reach.Factor{
+		Kind:          FactorKindRDSInstanceEndpoint,
+		Resource:      db.ToResourceReference(),
+		Traffic:       traffic,
+		ReturnTraffic: returnTraffic,
+		Properties: rdsInstanceEndpointFactor{
+			Status:    db.Status,
+			Available: db.isAvailable(),
+			Port:      db.Port,
+		},
+	}, nil
+}
+
+// portTraffic returns the TCP traffic on which the RDS instance accepts connections.
+func (db RDSInstance) portTraffic() (reach.TrafficContent, error) {
+	if db.Port < 1 || db.Port > 65535 {
+		return reach.TrafficContent{}, fmt.Errorf("unable to use port for RDS instance '%s': invalid port: %d", db.ID, db.Port)
+	}
+
+	ports, err := set.NewPortSetFromRange(uint16(db.Port), uint16(db.Port))
+	if err != nil {
+		return reach.TrafficContent{}, err
+	}
+
+	return reach.NewTrafficContentForPorts(reach.ProtocolTCP, ports), nil
+}
@@ -0,0 +1,103 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestRDSInstanceEndpointFactor(t *testing.T) {
+	postgres, err := set.NewPortSetFromRange(5432, 5432)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name                  string
+		status                string
+		port                  int64
+		role                  reach.SubjectRole
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+		expectedError         bool
+	}{
+		{
+			"destination: DB port only",
+			"available",
+			5432,
+			reach.SubjectRoleDestination,
+			reach.NewTrafficContentForPorts(reach.ProtocolTCP, postgres),
+			reach.NewTrafficContentForAllTraffic(),
+			false,
+		},
+		{
+			"source: all traffic",
+			"available",
+			5432,
+			reach.SubjectRoleSource,
+			reach.NewTrafficContentForAllTraffic(),
+			reach.NewTrafficContentForAllTraffic(),
+			false,
+		},
+		{
+			"destination: modifying instance still accepts connections",
+			"modifying",
+			5432,
+			reach.SubjectRoleDestination,
+			reach.NewTrafficContentForPorts(reach.ProtocolTCP, postgres),
+			reach.NewTrafficContentForAllTraffic(),
+			false,
+		},
+		{
+			"destination: stopped instance",
+			"stopped",
+			5432,
+			reach.SubjectRoleDestination,
+			reach.NewTrafficContentForNoTraffic(),
+			reach.NewTrafficContentForNoTraffic(),
+			false,
+		},
+		{
+			"destination: invalid port",
+			"available",
+			0,
+			reach.SubjectRoleDestination,
+			reach.TrafficContent{},
+			reach.TrafficContent{},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := RDSInstance{
+				ID:     "db",
+				Engine: "postgres",
+				Status: tc.status,
+				Port:   tc.port,
+			}
+
+			factor, err := db.newEndpointFactor(tc.role)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Error("expected an error, but got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if factor.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, factor.Traffic)
+			}
+
+			if factor.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+				reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, factor.ReturnTraffic)
+			}
+		})
+	}
+}
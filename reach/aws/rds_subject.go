@@ -0,0 +1,36 @@
+package aws
+
+import "github.com/luhring/reach/reach"
+
+// SubjectKindRDSInstance specifies the unique name for the RDS instance kind of subject.
+const SubjectKindRDSInstance = "RDSInstance"
+
+// SubjectKindRDSCluster specifies the unique name for the RDS (Aurora) cluster kind of subject.
+const SubjectKindRDSCluster = "RDSCluster"
+
+// NewRDSInstanceSubject returns a new subject for the specified RDS instance (identified by its DB instance identifier).
+func NewRDSInstanceSubject(id string, role reach.SubjectRole) (*reach.Subject, error) {
+	return newRDSSubject(SubjectKindRDSInstance, id, role)
+}
+
+// NewRDSClusterSubject returns a new subject for the specified Aurora cluster (identified by its DB cluster identifier).
+func NewRDSClusterSubject(id string, role reach.SubjectRole) (*reach.Subject, error) {
+	return newRDSSubject(SubjectKindRDSCluster, id, role)
+}
+
+func newRDSSubject(kind, id string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	if len(id) < 1 {
+		return nil, reach.NewSubjectError(reach.ErrSubjectIDValidation)
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   kind,
+		ID:     id,
+		Role:   role,
+	}, nil
+}
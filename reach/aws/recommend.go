@@ -0,0 +1,379 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/mgutz/ansi"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/helper"
+)
+
+// A RequiredFlow is network traffic that must be allowed from a source to a destination, along with the analysis of all of the traffic currently allowed between them.
+type RequiredFlow struct {
+	Name     string
+	Analysis *reach.Analysis
+	Traffic  reach.TrafficContent
+}
+
+// A RuleRecommendation describes a security group rule that allows more than the required flows need from it, either because it allows more traffic than they require, or because its target (e.g. an IP CIDR block) includes more than the required flows' sources or destinations. The replacements are the rules that would allow only what's required. A recommendation without replacements means that no required flow needs the rule.
+type RuleRecommendation struct {
+	SecurityGroup     reach.ResourceReference
+	SecurityGroupName string
+	Rule              string
+	Allowed           reach.TrafficContent
+	Required          reach.TrafficContent
+	Excess            reach.TrafficContent
+	NarrowerTarget    string   `json:"NarrowerTarget,omitempty"`
+	Replacements      []string `json:"Replacements,omitempty"`
+	Flows             []string `json:"Flows,omitempty"`
+}
+
+// ruleUsage is what the required flows need from one target (e.g. one IP CIDR block) of one security group rule.
+type ruleUsage struct {
+	securityGroup reach.ResourceReference
+	direction     securityGroupRuleDirection
+	ruleIndex     int
+	match         securityGroupRuleMatch
+	allowed       reach.TrafficContent
+	required      reach.TrafficContent
+	flows         []string
+	counterparts  []ruleCounterpart
+}
+
+// A ruleCounterpart is the other end of a required flow that uses a rule: the destination, for an outbound rule, or the source, for an inbound rule.
+type ruleCounterpart struct {
+	ip  net.IP
+	eni *ElasticNetworkInterface
+}
+
+// Recommend finds the security group rules that apply to the required flows and allow more than the flows need, and suggests replacement rules that allow only the required traffic, to only the required sources or destinations. Only the rules that apply to the analyses of the required flows are considered, so a rule that's needed by some other flow will be reported as well, and should be added to the required flows.
+func Recommend(flows []RequiredFlow) ([]RuleRecommendation, error) {
+	usages := make(map[string]*ruleUsage)
+	resources := reach.NewResourceCollection()
+
+	for _, flow := range flows {
+		resources.Merge(flow.Analysis.Resources)
+
+		for _, v := range flow.Analysis.NetworkVectors {
+			sourceCounterpart := ruleCounterpart{
+				ip:  v.Source.IPAddress,
+				eni: ElasticNetworkInterfaceFromNetworkPoint(v.Source, flow.Analysis.Resources),
+			}
+			if v.TranslatedSourceIPAddress != nil {
+				sourceCounterpart = ruleCounterpart{ip: v.TranslatedSourceIPAddress}
+			}
+
+			destinationCounterpart := ruleCounterpart{
+				ip:  v.Destination.IPAddress,
+				eni: ElasticNetworkInterfaceFromNetworkPoint(v.Destination, flow.Analysis.Resources),
+			}
+
+			if err := addRuleUsages(usages, flow, v.Source.Factors, destinationCounterpart); err != nil {
+				return nil, err
+			}
+
+			if err := addRuleUsages(usages, flow, v.Destination.Factors, sourceCounterpart); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var recommendations []RuleRecommendation
+
+	for _, usage := range usages {
+		recommendation, err := usage.recommendation(resources)
+		if err != nil {
+			return nil, err
+		}
+
+		if recommendation != nil {
+			recommendations = append(recommendations, *recommendation)
+		}
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].SecurityGroup.ID != recommendations[j].SecurityGroup.ID {
+			return recommendations[i].SecurityGroup.ID < recommendations[j].SecurityGroup.ID
+		}
+		return recommendations[i].Rule < recommendations[j].Rule
+	})
+
+	return recommendations, nil
+}
+
+func addRuleUsages(usages map[string]*ruleUsage, flow RequiredFlow, factors []reach.Factor, counterpart ruleCounterpart) error {
+	for _, factor := range factors {
+		if factor.Kind != FactorKindSecurityGroupRules {
+			continue
+		}
+
+		props, ok := factor.Properties.(securityGroupRulesFactor)
+		if !ok {
+			return fmt.Errorf("unable to recommend security group rules: unexpected factor properties: %T", factor.Properties)
+		}
+
+		for _, component := range props.RuleComponents {
+			key := fmt.Sprintf("%s/%s/%d/%s", component.SecurityGroup.ID, component.RuleDirection, component.RuleIndex, component.Match.target())
+
+			usage, exists := usages[key]
+			if !exists {
+				usage = &ruleUsage{
+					securityGroup: component.SecurityGroup,
+					direction:     component.RuleDirection,
+					ruleIndex:     component.RuleIndex,
+					match:         component.Match,
+					allowed:       component.Traffic,
+					required:      reach.NewTrafficContentForNoTraffic(),
+				}
+				usages[key] = usage
+			}
+
+			used, err := flow.Traffic.Intersect(component.Traffic)
+			if err != nil {
+				return err
+			}
+
+			if used.None() {
+				continue
+			}
+
+			required, err := usage.required.Merge(used)
+			if err != nil {
+				return err
+			}
+			usage.required = required
+
+			if len(usage.flows) == 0 || usage.flows[len(usage.flows)-1] != flow.Name {
+				usage.flows = append(usage.flows, flow.Name)
+			}
+
+			usage.counterparts = append(usage.counterparts, counterpart)
+		}
+	}
+
+	return nil
+}
+
+// recommendation returns the recommendation for the rule, or nil if the rule allows only what the required flows need.
+func (u ruleUsage) recommendation(rc *reach.ResourceCollection) (*RuleRecommendation, error) {
+	excess, err := u.allowed.Subtract(u.required)
+	if err != nil {
+		return nil, err
+	}
+
+	var sg *SecurityGroup
+	if resource := rc.Get(u.securityGroup); resource != nil {
+		if properties, ok := resource.Properties.(SecurityGroup); ok {
+			sg = &properties
+		}
+	}
+
+	narrowerTarget := ""
+	if !u.required.None() {
+		narrowerTarget = u.narrowerTarget(sg)
+	}
+
+	if excess.None() && narrowerTarget == "" {
+		return nil, nil
+	}
+
+	recommendation := &RuleRecommendation{
+		SecurityGroup:     u.securityGroup,
+		SecurityGroupName: u.securityGroup.ID,
+		Rule:              fmt.Sprintf("%s rule #%d for %s", u.direction, u.ruleIndex+1, u.match.target()),
+		Allowed:           u.allowed,
+		Required:          u.required,
+		Excess:            excess,
+		NarrowerTarget:    narrowerTarget,
+		Flows:             u.flows,
+	}
+
+	if sg != nil {
+		recommendation.SecurityGroupName = sg.Name()
+	}
+
+	if !u.required.None() {
+		target := narrowerTarget
+		if target == "" {
+			target = u.match.target()
+		}
+
+		preposition := "from"
+		if u.direction == securityGroupRuleDirectionOutbound {
+			preposition = "to"
+		}
+
+		for _, traffic := range strings.Split(u.required.Summary(), "; ") {
+			recommendation.Replacements = append(recommendation.Replacements, fmt.Sprintf("%s %s %s %s", u.direction, traffic, preposition, target))
+		}
+	}
+
+	return recommendation, nil
+}
+
+// narrowerTarget returns a target that includes every counterpart of the required flows but less than the rule's IP CIDR block, or an empty string if there isn't one (or the rule's target isn't an IP CIDR block). A security group that every counterpart shares is preferred, and is otherwise the smallest IP CIDR block that contains each counterpart's IP address.
+func (u ruleUsage) narrowerTarget(sg *SecurityGroup) string {
+	if u.match.Basis != securityGroupRuleMatchBasisIP {
+		return ""
+	}
+
+	network, ok := u.match.Requirement.(*net.IPNet)
+	if !ok {
+		return ""
+	}
+
+	if sg != nil {
+		if id := u.sharedSecurityGroupID(sg.VPCID); id != "" {
+			return fmt.Sprintf("security group %s", id)
+		}
+	}
+
+	var ips []net.IP
+	for _, c := range u.counterparts {
+		ips = append(ips, c.ip)
+	}
+
+	covering := coveringNetwork(ips)
+	if covering == nil {
+		return ""
+	}
+
+	ruleOnes, ruleBits := network.Mask.Size()
+	ones, bits := covering.Mask.Size()
+	if bits != ruleBits || ones <= ruleOnes {
+		return ""
+	}
+
+	return fmt.Sprintf("IP CIDR block %s", covering)
+}
+
+// sharedSecurityGroupID returns the ID of a security group (the lowest, if there are several) attached to the network interface of every counterpart, if every counterpart has a network interface in the given VPC.
+func (u ruleUsage) sharedSecurityGroupID(vpcID string) string {
+	var shared []string
+
+	for i, c := range u.counterparts {
+		if c.eni == nil || c.eni.VPCID != vpcID {
+			return ""
+		}
+
+		if i == 0 {
+			shared = append([]string{}, c.eni.SecurityGroupIDs...)
+			continue
+		}
+
+		attached := make(map[string]bool)
+		for _, id := range c.eni.SecurityGroupIDs {
+			attached[id] = true
+		}
+
+		var remaining []string
+		for _, id := range shared {
+			if attached[id] {
+				remaining = append(remaining, id)
+			}
+		}
+		shared = remaining
+	}
+
+	if len(shared) == 0 {
+		return ""
+	}
+
+	sort.Strings(shared)
+	return shared[0]
+}
+
+// coveringNetwork returns the smallest CIDR block that contains all of the given IP addresses, or nil if there aren't any, or if they aren't all of the same IP version.
+func coveringNetwork(ips []net.IP) *net.IPNet {
+	if len(ips) == 0 {
+		return nil
+	}
+
+	normalize := func(ip net.IP) net.IP {
+		if v4 := ip.To4(); v4 != nil {
+			return v4
+		}
+		return ip.To16()
+	}
+
+	first := normalize(ips[0])
+	if first == nil {
+		return nil
+	}
+	bits := len(first) * 8
+
+	for ones := bits; ones >= 0; ones-- {
+		mask := net.CIDRMask(ones, bits)
+		network := &net.IPNet{IP: first.Mask(mask), Mask: mask}
+
+		containsAll := true
+		for _, ip := range ips[1:] {
+			ip = normalize(ip)
+			if ip == nil || len(ip) != len(first) {
+				return nil
+			}
+
+			if !network.Contains(ip) {
+				containsAll = false
+				break
+			}
+		}
+
+		if containsAll {
+			return network
+		}
+	}
+
+	return nil
+}
+
+// ExcessSummary returns a single-line summary of the excess traffic. If the rule allows all traffic, the excess is summarized as all traffic except the required traffic, rather than as every other IP protocol.
+func (r RuleRecommendation) ExcessSummary() string {
+	if r.Allowed.All() && !r.Required.None() {
+		return fmt.Sprintf("all traffic except %s", r.Required.Summary())
+	}
+
+	return r.Excess.Summary()
+}
+
+// String returns the text representation of the RuleRecommendation, listing the excess traffic (prefixed with "-") and the replacement rules (prefixed with "+").
+func (r RuleRecommendation) String() string {
+	output := fmt.Sprintf("%s in security group %s\n", r.Rule, r.SecurityGroupName)
+
+	var body string
+	body += fmt.Sprintf("allows: %s\n", r.Allowed.Summary())
+	body += fmt.Sprintf("required: %s\n", r.Required.Summary())
+
+	if !r.Excess.None() {
+		body += "traffic not needed by any required flow:\n"
+		for _, line := range strings.Split(r.ExcessSummary(), "; ") {
+			body += helper.Indent(ansi.Color("- "+line, "red")+"\n", 2)
+		}
+	}
+
+	if r.NarrowerTarget != "" {
+		body += fmt.Sprintf("the required flows only need %s\n", r.NarrowerTarget)
+	}
+
+	if len(r.Replacements) == 0 {
+		body += ansi.Color("suggestion: remove this rule", "yellow") + "\n"
+	} else {
+		body += "suggested replacement:\n"
+		for _, replacement := range r.Replacements {
+			body += helper.Indent(ansi.Color("+ "+replacement, "green")+"\n", 2)
+		}
+	}
+
+	if len(r.Flows) > 0 {
+		body += "used by:\n"
+		for _, flow := range r.Flows {
+			body += helper.Indent("- "+flow+"\n", 2)
+		}
+	}
+
+	return output + helper.Indent(body, 2)
+}
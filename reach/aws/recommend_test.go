@@ -0,0 +1,220 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestRecommend(t *testing.T) {
+	const vpcID = "vpc-abc123"
+
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	tcp := func(first, last uint16) reach.TrafficContent {
+		ports, err := set.NewPortSetFromRange(first, last)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reach.NewTrafficContentForPorts(reach.ProtocolTCP, ports)
+	}
+
+	eni := func(id, ip string, securityGroupIDs ...string) ElasticNetworkInterface {
+		return ElasticNetworkInterface{
+			ID:                   id,
+			SubnetID:             "subnet-1",
+			VPCID:                vpcID,
+			SecurityGroupIDs:     securityGroupIDs,
+			PrivateIPv4Addresses: []net.IP{net.ParseIP(ip)},
+		}
+	}
+
+	securityGroups := []SecurityGroup{
+		{ID: "sg-web", VPCID: vpcID},
+		{ID: "sg-app", VPCID: vpcID},
+		{ID: "sg-db", VPCID: vpcID},
+	}
+
+	rule := func(securityGroupID string, direction securityGroupRuleDirection, index int, match securityGroupRuleMatch, traffic reach.TrafficContent) securityGroupRulesFactorComponent {
+		return securityGroupRulesFactorComponent{
+			SecurityGroup: reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSecurityGroup, ID: securityGroupID},
+			RuleDirection: direction,
+			RuleIndex:     index,
+			Match:         match,
+			Traffic:       traffic,
+		}
+	}
+
+	cidr := func(block string) securityGroupRuleMatch {
+		return securityGroupRuleMatch{Basis: securityGroupRuleMatchBasisIP, Requirement: mustParseCIDR(block)}
+	}
+
+	sgRef := func(securityGroupID string) securityGroupRuleMatch {
+		return securityGroupRuleMatch{Basis: securityGroupRuleMatchBasisSGRef, Requirement: securityGroupID}
+	}
+
+	factor := func(rules []securityGroupRulesFactorComponent) []reach.Factor {
+		if len(rules) == 0 {
+			return nil
+		}
+
+		return []reach.Factor{
+			{
+				Kind:     FactorKindSecurityGroupRules,
+				Resource: rules[0].SecurityGroup,
+				Properties: securityGroupRulesFactor{
+					RuleComponents: rules,
+				},
+			},
+		}
+	}
+
+	// flow returns a required flow of the given traffic from source to destination, whose analysis has a single network vector with the given outbound rules of the source and inbound rules of the destination.
+	flow := func(name string, traffic reach.TrafficContent, source, destination ElasticNetworkInterface, outbound, inbound []securityGroupRulesFactorComponent) RequiredFlow {
+		resources := reach.NewResourceCollection()
+		resources.Put(source.ToResourceReference(), source.ToResource())
+		resources.Put(destination.ToResourceReference(), destination.ToResource())
+		for _, sg := range securityGroups {
+			resources.Put(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSecurityGroup, ID: sg.ID}, sg.ToResource())
+		}
+
+		vector := reach.NetworkVector{
+			Source: reach.NetworkPoint{
+				IPAddress: source.PrivateIPv4Addresses[0],
+				Lineage:   []reach.ResourceReference{source.ToResourceReference()},
+				Factors:   factor(outbound),
+			},
+			Destination: reach.NetworkPoint{
+				IPAddress: destination.PrivateIPv4Addresses[0],
+				Lineage:   []reach.ResourceReference{destination.ToResourceReference()},
+				Factors:   factor(inbound),
+			},
+		}
+
+		return RequiredFlow{
+			Name:     name,
+			Analysis: reach.NewAnalysis(nil, resources, []reach.NetworkVector{vector}),
+			Traffic:  traffic,
+		}
+	}
+
+	web1 := eni("eni-web-1", "10.0.1.10", "sg-web")
+	web2 := eni("eni-web-2", "10.0.1.11", "sg-web")
+	app := eni("eni-app", "10.0.1.20", "sg-app")
+	db := eni("eni-db", "10.0.2.10", "sg-db")
+
+	postgres := tcp(5432, 5432)
+	inbound := securityGroupRuleDirectionInbound
+	outbound := securityGroupRuleDirectionOutbound
+
+	cases := []struct {
+		name     string
+		flows    []RequiredFlow
+		expected []string
+	}{
+		{
+			"rule allows only what's required",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, sgRef("sg-web"), postgres),
+				}),
+			},
+			nil,
+		},
+		{
+			"narrower port range",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, sgRef("sg-web"), tcp(0, 65535)),
+				}),
+			},
+			[]string{
+				"sg-db: inbound rule #1 for security group sg-web -> inbound TCP 5432 from security group sg-web (excess: TCP 0-5431, 5433-65535; narrower target: none; flows: web-1 to db)",
+			},
+		},
+		{
+			"narrower IP CIDR block",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, cidr("10.0.0.0/16"), postgres),
+				}),
+				flow("app to db", postgres, app, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, cidr("10.0.0.0/16"), postgres),
+				}),
+			},
+			[]string{
+				"sg-db: inbound rule #1 for IP CIDR block 10.0.0.0/16 -> inbound TCP 5432 from IP CIDR block 10.0.1.0/27 (excess: (none); narrower target: IP CIDR block 10.0.1.0/27; flows: web-1 to db, app to db)",
+			},
+		},
+		{
+			"security group reference in place of an IP CIDR block",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, cidr("10.0.0.0/16"), postgres),
+				}),
+				flow("web-2 to db", postgres, web2, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, cidr("10.0.0.0/16"), postgres),
+				}),
+			},
+			[]string{
+				"sg-db: inbound rule #1 for IP CIDR block 10.0.0.0/16 -> inbound TCP 5432 from security group sg-web (excess: (none); narrower target: security group sg-web; flows: web-1 to db, web-2 to db)",
+			},
+		},
+		{
+			"outbound rule",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, []securityGroupRulesFactorComponent{
+					rule("sg-web", outbound, 0, cidr("0.0.0.0/0"), reach.NewTrafficContentForAllTraffic()),
+				}, nil),
+			},
+			[]string{
+				"sg-web: outbound rule #1 for IP CIDR block 0.0.0.0/0 -> outbound TCP 5432 to security group sg-db (excess: all traffic except TCP 5432; narrower target: security group sg-db; flows: web-1 to db)",
+			},
+		},
+		{
+			"unused rule",
+			[]RequiredFlow{
+				flow("web-1 to db", postgres, web1, db, nil, []securityGroupRulesFactorComponent{
+					rule("sg-db", inbound, 0, sgRef("sg-web"), postgres),
+					rule("sg-db", inbound, 1, cidr("10.0.1.0/24"), tcp(22, 22)),
+				}),
+			},
+			[]string{
+				"sg-db: inbound rule #2 for IP CIDR block 10.0.1.0/24 ->  (excess: TCP 22; narrower target: none; flows: )",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			recommendations, err := Recommend(tc.flows)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var actual []string
+			for _, r := range recommendations {
+				narrowerTarget := r.NarrowerTarget
+				if narrowerTarget == "" {
+					narrowerTarget = "none"
+				}
+
+				actual = append(actual, fmt.Sprintf("%s: %s -> %s (excess: %s; narrower target: %s; flows: %s)", r.SecurityGroup.ID, r.Rule, strings.Join(r.Replacements, ", "), r.ExcessSummary(), narrowerTarget, strings.Join(r.Flows, ", ")))
+			}
+
+			if expected, actual := strings.Join(tc.expected, "\n"), strings.Join(actual, "\n"); expected != actual {
+				reach.DiffErrorf(t, "recommendations", expected, actual)
+			}
+		})
+	}
+}
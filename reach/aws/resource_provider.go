@@ -1,14 +1,40 @@
 package aws
 
-// The ResourceProvider interface wraps all of the necessary methods for accessing AWS-specific resources.
+import "net"
+
+// The ResourceProvider interface wraps all of the necessary methods for accessing AWS-specific resources. Dependencies are resolved concurrently, so implementations must be safe for concurrent use, and implementations that make requests to an API should limit how many of those requests are in progress at once.
 type ResourceProvider interface {
+	AllAWSServices() ([]AWSService, error)
 	AllEC2Instances() ([]EC2Instance, error)
+	AllElasticNetworkInterfaces() ([]ElasticNetworkInterface, error)
+	AllLoadBalancers() ([]LoadBalancer, error)
+	AllRDSClusters() ([]RDSCluster, error)
+	AllRDSInstances() ([]RDSInstance, error)
+	AllVPCEndpoints() ([]VPCEndpoint, error)
+	AWSService(name string) (*AWSService, error)
 	EC2Instance(id string) (*EC2Instance, error)
+	EgressOnlyInternetGateway(id string) (*EgressOnlyInternetGateway, error)
 	ElasticNetworkInterface(id string) (*ElasticNetworkInterface, error)
+	ElasticNetworkInterfaceForIPAddress(ip net.IP) (*ElasticNetworkInterface, error) // returns nil (without an error) if no network interface has the address
+	ElasticNetworkInterfacesForSecurityGroup(id string) ([]ElasticNetworkInterface, error)
+	ElasticNetworkInterfacesForSubnet(id string) ([]ElasticNetworkInterface, error)
+	ElasticNetworkInterfacesForVPC(id string) ([]ElasticNetworkInterface, error)
+	InternetGateway(id string) (*InternetGateway, error)
+	LoadBalancer(id string) (*LoadBalancer, error)
+	ManagedPrefixList(id string) (*PrefixList, error)
+	NATGateway(id string) (*NATGateway, error)
 	NetworkACL(id string) (*NetworkACL, error)
+	RDSCluster(id string) (*RDSCluster, error)
+	RDSInstance(id string) (*RDSInstance, error)
 	RouteTable(id string) (*RouteTable, error)
 	SecurityGroup(id string) (*SecurityGroup, error)
 	SecurityGroupReference(id, accountID string) (*SecurityGroupReference, error)
 	Subnet(id string) (*Subnet, error)
+	TargetGroup(id string) (*TargetGroup, error)
+	TransitGateway(id string) (*TransitGateway, error)
+	TransitGatewayRouteTable(id string) (*TransitGatewayRouteTable, error)
+	TransitGatewayVPCAttachment(transitGatewayID, vpcID string) (*TransitGatewayAttachment, error) // returns nil (without an error) if the VPC isn't attached to the transit gateway
 	VPC(id string) (*VPC, error)
+	VPCEndpoint(id string) (*VPCEndpoint, error)
+	VPCPeeringConnection(id string) (*VPCPeeringConnection, error)
 }
@@ -1,6 +1,10 @@
 package aws
 
-import "github.com/luhring/reach/reach"
+import (
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
 
 // ResourceKindRouteTable specifies the unique name for the route table kind of resource.
 const ResourceKindRouteTable = "RouteTable"
@@ -20,6 +24,15 @@ func (rt RouteTable) ToResource() reach.Resource {
 	}
 }
 
+// ToResourceReference returns a resource reference to uniquely identify the route table.
+func (rt RouteTable) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRouteTable,
+		ID:     rt.ID,
+	}
+}
+
 // Dependencies returns a collection of the route table's resource dependencies.
 func (rt RouteTable) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
 	rc := reach.NewResourceCollection()
@@ -34,7 +47,145 @@ func (rt RouteTable) Dependencies(provider ResourceProvider) (*reach.ResourceCol
 		ID:     vpc.ID,
 	}, vpc.ToResource())
 
-	// TODO: Figure out dependencies from RouteTableRoute (i.e. route targets)
+	internetGateways, err := rt.internetGatewayDependencies(provider)
+	if err != nil {
+		return nil, err
+	}
+	rc.Merge(internetGateways)
+
+	for _, route := range rt.Routes {
+		if route.Target.Type == RouteTableRouteTargetTypeVPCPeeringConnection {
+			pcx, err := provider.VPCPeeringConnection(route.Target.ID)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(reach.ResourceReference{
+				Domain: ResourceDomainAWS,
+				Kind:   ResourceKindVPCPeeringConnection,
+				ID:     pcx.ID,
+			}, pcx.ToResource())
+		}
+
+		if route.Target.Type == RouteTableRouteTargetTypeNATGateway {
+			nat, err := provider.NATGateway(route.Target.ID)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(nat.ToResourceReference(), nat.ToResource())
+
+			natDependencies, err := nat.Dependencies(provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Merge(natDependencies)
+		}
+
+		if route.Target.Type == RouteTableRouteTargetTypeVPCEndpoint {
+			vpce, err := provider.VPCEndpoint(route.Target.ID)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(vpce.ToResourceReference(), vpce.ToResource())
+
+			// Only a gateway endpoint's dependencies are needed to follow the route, and they don't lead back to the route table.
+			if vpce.Type == VPCEndpointTypeGateway {
+				vpceDependencies, err := vpce.Dependencies(provider)
+				if err != nil {
+					return nil, err
+				}
+				rc.Merge(vpceDependencies)
+			}
+		}
+
+		if route.Target.Type == RouteTableRouteTargetTypeTransitGateway {
+			tgwDependencies, err := rt.transitGatewayDependencies(route.Target.ID, provider)
+			if err != nil {
+				return nil, err
+			}
+			rc.Merge(tgwDependencies)
+		}
+
+		// TODO: Figure out dependencies from other kinds of route targets
+	}
+
+	return rc, nil
+}
+
+// internetGatewayDependencies returns the internet gateways and egress-only internet gateways that the route table's routes send network traffic to.
+func (rt RouteTable) internetGatewayDependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	rc := reach.NewResourceCollection()
+
+	for _, route := range rt.Routes {
+		switch route.Target.Type {
+		case RouteTableRouteTargetTypeInternetGateway:
+			igw, err := provider.InternetGateway(route.Target.ID)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(igw.ToResourceReference(), igw.ToResource())
+		case RouteTableRouteTargetTypeEgressOnlyInternetGateway:
+			eigw, err := provider.EgressOnlyInternetGateway(route.Target.ID)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(eigw.ToResourceReference(), eigw.ToResource())
+		}
+	}
+
+	return rc, nil
+}
+
+// transitGatewayDependencies returns the resources needed to follow network traffic from the route table's VPC through the specified transit gateway.
+func (rt RouteTable) transitGatewayDependencies(transitGatewayID string, provider ResourceProvider) (*reach.ResourceCollection, error) {
+	rc := reach.NewResourceCollection()
+
+	tgw, err := provider.TransitGateway(transitGatewayID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGateway,
+		ID:     tgw.ID,
+	}, tgw.ToResource())
+
+	attachment, err := provider.TransitGatewayVPCAttachment(tgw.ID, rt.VPCID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return rc, nil // the transit gateway can't receive traffic from a VPC that isn't attached to it
+	}
+	rc.Put(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGatewayAttachment,
+		ID:     attachment.ID,
+	}, attachment.ToResource())
+
+	attachmentDependencies, err := attachment.Dependencies(provider)
+	if err != nil {
+		return nil, err
+	}
+	rc.Merge(attachmentDependencies)
 
 	return rc, nil
 }
+
+// RouteForIP returns the route that the route table would use to send network traffic to the specified IP address, using longest prefix matching. If no route's destination contains the IP address, RouteForIP returns nil.
+func (rt RouteTable) RouteForIP(ip net.IP) *RouteTableRoute {
+	var bestMatch *RouteTableRoute
+
+	for i := range rt.Routes {
+		route := rt.Routes[i]
+
+		if route.Destination == nil || !route.Destination.Contains(ip) {
+			continue
+		}
+
+		if bestMatch == nil || route.prefixLength() > bestMatch.prefixLength() {
+			bestMatch = &route
+		}
+	}
+
+	return bestMatch
+}
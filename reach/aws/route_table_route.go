@@ -1,11 +1,62 @@
 package aws
 
-import "net"
+import (
+	"fmt"
+	"net"
+)
 
-// A RouteTableRoute resource representation.
+// RouteTableRouteState describes whether or not a route is able to deliver network traffic to its target.
+type RouteTableRouteState string
+
+// The possible states of a route table route.
+const (
+	RouteTableRouteStateActive    RouteTableRouteState = "active"
+	RouteTableRouteStateBlackhole RouteTableRouteState = "blackhole"
+)
+
+// A RouteTableRoute resource representation. A route whose destination is a prefix list is represented as one route for each of the prefix list's CIDR blocks, each of which records the prefix list's ID.
 type RouteTableRoute struct {
-	Destination *net.IPNet
-	Target      interface{} // TODO: Figure this out -- this is not the normal Reach 'target'
-	States      string
-	Propagated  bool
+	Destination             *net.IPNet
+	DestinationPrefixListID string `json:"DestinationPrefixListID,omitempty"`
+	Target                  RouteTableRouteTarget
+	State                   RouteTableRouteState
+	Propagated              bool
+}
+
+// IsBlackhole returns a boolean indicating whether or not the route's target is unable to receive network traffic (e.g. because the target has been deleted).
+func (route RouteTableRoute) IsBlackhole() bool {
+	return route.State == RouteTableRouteStateBlackhole
+}
+
+// String returns the string representation of the route.
+func (route RouteTableRoute) String() string {
+	destination := "[unknown destination]"
+	if route.Destination != nil {
+		destination = route.Destination.String()
+	}
+
+	if route.DestinationPrefixListID != "" {
+		destination = fmt.Sprintf("%s (%s)", route.DestinationPrefixListID, destination)
+	}
+
+	return fmt.Sprintf("%s -> %s (%s)", destination, route.Target, route.State)
+}
+
+func (route RouteTableRoute) prefixLength() int {
+	if route.Destination == nil {
+		return -1
+	}
+
+	ones, _ := route.Destination.Mask.Size()
+	return ones
+}
+
+// sendsToInternet returns a boolean indicating whether or not the route sends network traffic toward the internet, through an internet gateway, an egress-only internet gateway or a NAT gateway.
+func (route RouteTableRoute) sendsToInternet() bool {
+	switch route.Target.Type {
+	case RouteTableRouteTargetTypeInternetGateway, RouteTableRouteTargetTypeEgressOnlyInternetGateway, RouteTableRouteTargetTypeNATGateway:
+		return true
+	default:
+		return false
+	}
 }
@@ -0,0 +1,41 @@
+package aws
+
+import "fmt"
+
+// RouteTableRouteTargetType specifies the kind of resource to which a route sends network traffic.
+type RouteTableRouteTargetType string
+
+// The kinds of resources that can be the target of a route.
+const (
+	RouteTableRouteTargetTypeLocal                     RouteTableRouteTargetType = "local"
+	RouteTableRouteTargetTypeInternetGateway           RouteTableRouteTargetType = "InternetGateway"
+	RouteTableRouteTargetTypeEgressOnlyInternetGateway RouteTableRouteTargetType = "EgressOnlyInternetGateway"
+	RouteTableRouteTargetTypeVirtualPrivateGateway     RouteTableRouteTargetType = "VirtualPrivateGateway"
+	RouteTableRouteTargetTypeNATGateway                RouteTableRouteTargetType = "NATGateway"
+	RouteTableRouteTargetTypeVPCPeeringConnection      RouteTableRouteTargetType = "VPCPeeringConnection"
+	RouteTableRouteTargetTypeTransitGateway            RouteTableRouteTargetType = "TransitGateway"
+	RouteTableRouteTargetTypeLocalGateway              RouteTableRouteTargetType = "LocalGateway"
+	RouteTableRouteTargetTypeVPCEndpoint               RouteTableRouteTargetType = "VPCEndpoint"
+	RouteTableRouteTargetTypeElasticNetworkInterface   RouteTableRouteTargetType = "ElasticNetworkInterface"
+	RouteTableRouteTargetTypeEC2Instance               RouteTableRouteTargetType = "EC2Instance"
+	RouteTableRouteTargetTypeUnknown                   RouteTableRouteTargetType = "unknown"
+)
+
+// A RouteTableRouteTarget identifies the resource to which a route sends network traffic.
+type RouteTableRouteTarget struct {
+	Type RouteTableRouteTargetType
+	ID   string `json:"ID,omitempty"`
+}
+
+// String returns the string representation of the route target.
+func (target RouteTableRouteTarget) String() string {
+	if target.Type == RouteTableRouteTargetTypeLocal {
+		return string(RouteTableRouteTargetTypeLocal)
+	}
+
+	if target.ID == "" {
+		return string(target.Type)
+	}
+
+	return fmt.Sprintf("%s %s", target.Type, target.ID)
+}
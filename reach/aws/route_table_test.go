@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestRouteForIP(t *testing.T) {
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	localRoute := RouteTableRoute{
+		Destination: mustParseCIDR("10.0.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeLocal, ID: "local"},
+		State:       RouteTableRouteStateActive,
+	}
+	peeringRoute := RouteTableRoute{
+		Destination: mustParseCIDR("10.1.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeVPCPeeringConnection, ID: "pcx-abc123"},
+		State:       RouteTableRouteStateBlackhole,
+	}
+	defaultRoute := RouteTableRoute{
+		Destination: mustParseCIDR("0.0.0.0/0"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeInternetGateway, ID: "igw-abc123"},
+		State:       RouteTableRouteStateActive,
+	}
+	narrowRoute := RouteTableRoute{
+		Destination: mustParseCIDR("10.0.5.0/24"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeElasticNetworkInterface, ID: "eni-abc123"},
+		State:       RouteTableRouteStateActive,
+	}
+
+	cases := []struct {
+		name          string
+		routes        []RouteTableRoute
+		ip            string
+		expectedRoute *RouteTableRoute
+	}{
+		{
+			name:          "no routes",
+			routes:        nil,
+			ip:            "10.0.1.1",
+			expectedRoute: nil,
+		},
+		{
+			name:          "no matching route",
+			routes:        []RouteTableRoute{localRoute, peeringRoute},
+			ip:            "192.168.1.1",
+			expectedRoute: nil,
+		},
+		{
+			name:          "single matching route",
+			routes:        []RouteTableRoute{localRoute, peeringRoute},
+			ip:            "10.1.2.3",
+			expectedRoute: &peeringRoute,
+		},
+		{
+			name:          "more specific route wins over default route",
+			routes:        []RouteTableRoute{defaultRoute, localRoute},
+			ip:            "10.0.1.1",
+			expectedRoute: &localRoute,
+		},
+		{
+			name:          "most specific route wins regardless of order",
+			routes:        []RouteTableRoute{narrowRoute, defaultRoute, localRoute},
+			ip:            "10.0.5.20",
+			expectedRoute: &narrowRoute,
+		},
+		{
+			name:          "IPv4 route doesn't match IPv6 address",
+			routes:        []RouteTableRoute{defaultRoute},
+			ip:            "2600:1f18::1",
+			expectedRoute: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rt := RouteTable{
+				ID:     "rtb-abc123",
+				Routes: tc.routes,
+			}
+
+			route := rt.RouteForIP(net.ParseIP(tc.ip))
+
+			if !reflect.DeepEqual(tc.expectedRoute, route) {
+				reach.DiffErrorf(t, "route", tc.expectedRoute, route)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// FactorKindRouteTables specifies the unique name for the route tables kind of factor.
+const FactorKindRouteTables = "RouteTables"
+
+type routeTablesFactor struct {
+	RouteTable         reach.ResourceReference
+	MatchedRoute       *RouteTableRoute    `json:"MatchedRoute,omitempty"`
+	TransitGatewayPath *transitGatewayPath `json:"TransitGatewayPath,omitempty"`
+}
+
+// FactorDetails describes the route table and the route that matched the other network point, including the route through a transit gateway, if any.
+func (f routeTablesFactor) FactorDetails() reach.FactorDetails {
+	details := reach.FactorDetails{
+		Attributes: map[string]string{
+			"routeTable": f.RouteTable.ID,
+		},
+	}
+
+	if f.MatchedRoute != nil {
+		details.Attributes["matchedRoute"] = f.MatchedRoute.String()
+	}
+
+	if path := f.TransitGatewayPath; path != nil {
+		details.Attributes["transitGateway"] = path.TransitGateway.ID
+
+		if path.Attachment != nil {
+			details.Attributes["transitGatewayAttachment"] = path.Attachment.ID
+		}
+
+		if path.RouteTable != nil {
+			details.Attributes["transitGatewayRouteTable"] = path.RouteTable.ID
+		}
+
+		if path.MatchedRoute != nil {
+			details.Attributes["transitGatewayMatchedRoute"] = path.MatchedRoute.String()
+		}
+	}
+
+	return details
+}
+
+func (eni ElasticNetworkInterface) newRouteTablesFactor(
+	rc *reach.ResourceCollection,
+	p reach.Perspective,
+	awsP perspective,
+	targetENI *ElasticNetworkInterface,
+) (*reach.Factor, error) {
+	subnetResource := rc.Get(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindSubnet,
+		ID:     eni.SubnetID,
+	})
+	if subnetResource == nil {
+		return nil, fmt.Errorf("couldn't find subnet: %s", eni.SubnetID)
+	}
+	subnet := subnetResource.Properties.(Subnet)
+
+	ref := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRouteTable,
+		ID:     subnet.RouteTableID,
+	}
+
+	routeTableResource := rc.Get(ref)
+	if routeTableResource == nil {
+		return nil, fmt.Errorf("couldn't find route table: %s", subnet.RouteTableID)
+	}
+	routeTable := routeTableResource.Properties.(RouteTable)
+
+	route := routeTable.RouteForIP(p.Other.IPAddress)
+
+	var reaches bool
+	var tgwPath *transitGatewayPath
+	if route != nil && !route.IsBlackhole() {
+		reaches, tgwPath = eni.routeReaches(*route, targetENI, p.Other.IPAddress, p.SelfRole, rc)
+	}
+
+	// Routing only decides whether traffic leaving this network point can find its way to the other network point. So for the source, the route table affects forward traffic, and for the destination, it affects return traffic.
+	routedTraffic := reach.NewTrafficContentForNoTraffic()
+	if reaches {
+		routedTraffic = reach.NewTrafficContentForAllTraffic()
+	}
+
+	traffic := reach.NewTrafficContentForAllTraffic()
+	returnTraffic := reach.NewTrafficContentForAllTraffic()
+
+	if awsP.routeTableAffectsForwardTraffic {
+		traffic = routedTraffic
+	} else {
+		returnTraffic = routedTraffic
+
+		// Traffic from outside of AWS arrives through the same kind of gateway that carries the return traffic, so when that's an internet gateway, a NAT gateway or a gateway VPC endpoint, the gateway also decides whether the traffic can arrive at all.
+		if targetENI == nil && route != nil && (route.sendsToInternet() || route.Target.Type == RouteTableRouteTargetTypeVPCEndpoint) {
+			traffic = routedTraffic
+		}
+	}
+
+	props := routeTablesFactor{
+		RouteTable:         ref,
+		MatchedRoute:       route,
+		TransitGatewayPath: tgwPath,
+	}
+
+	return &reach.Factor{
+		Kind:          FactorKindRouteTables,
+		Resource:      eni.ToResourceReference(),
+		Traffic:       traffic,
+		ReturnTraffic: returnTraffic,
+		Properties:    props,
+	}, nil
+}
+
+// matchedRoute returns the route that the route tables factor's route table uses for network traffic to the other network point, or nil if no route matches.
+func matchedRoute(factor reach.Factor) *RouteTableRoute {
+	return factor.Properties.(routeTablesFactor).MatchedRoute
+}
+
+// routeReaches determines whether the route's target is able to deliver network traffic from the ENI to the target ENI, or to the IP address if the target ENI is nil or the route sends the traffic over the internet through a NAT gateway (see routeReachesOutsideAWS). Within a VPC, any active route will do. Across VPCs, the route must use either an active VPC peering connection between the two VPCs, or a transit gateway that routes the traffic to the target ENI's VPC. When the route uses a transit gateway, routeReaches also returns the path taken through the transit gateway.
+func (eni ElasticNetworkInterface) routeReaches(route RouteTableRoute, targetENI *ElasticNetworkInterface, ip net.IP, selfRole reach.SubjectRole, rc *reach.ResourceCollection) (bool, *transitGatewayPath) {
+	if targetENI == nil || (route.Target.Type == RouteTableRouteTargetTypeNATGateway && viaInternet(targetENI, ip)) {
+		return eni.routeReachesOutsideAWS(route, ip, selfRole, rc), nil
+	}
+
+	if sameVPC(&eni, targetENI) {
+		return true, nil
+	}
+
+	switch route.Target.Type {
+	case RouteTableRouteTargetTypeVPCPeeringConnection:
+		pcxResource := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindVPCPeeringConnection,
+			ID:     route.Target.ID,
+		})
+		if pcxResource == nil {
+			return false, nil
+		}
+		pcx := pcxResource.Properties.(VPCPeeringConnection)
+
+		return pcx.IsActive() && pcx.Connects(eni.VPCID, targetENI.VPCID), nil
+	case RouteTableRouteTargetTypeTransitGateway:
+		path, delivered := newTransitGatewayPath(route.Target.ID, eni.VPCID, targetENI.VPCID, ip, rc)
+		return delivered, &path
+	default:
+		return false, nil
+	}
+}
+
+// routeReachesOutsideAWS determines whether the route's target is able to carry network traffic between the ENI and an IP address outside of AWS. An internet gateway must be attached to the ENI's VPC, and for IPv4 traffic the ENI must have a public IPv4 address, since the internet gateway translates between the ENI's private and public IPv4 addresses. An egress-only internet gateway only carries IPv6 traffic, and only for connections that the ENI initiates. Likewise, a NAT gateway only carries connections that the ENI initiates (the NAT gateway factor describes the rest of its path), and so does a gateway VPC endpoint, which must be available and in the ENI's VPC. A local route delivers the traffic to the IP address within the VPC, and a virtual private gateway or a local gateway delivers it to the network on the other side of the gateway. Any other kind of route target isn't known to deliver traffic outside of AWS.
+func (eni ElasticNetworkInterface) routeReachesOutsideAWS(route RouteTableRoute, ip net.IP, selfRole reach.SubjectRole, rc *reach.ResourceCollection) bool {
+	switch route.Target.Type {
+	case RouteTableRouteTargetTypeInternetGateway:
+		igwResource := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindInternetGateway,
+			ID:     route.Target.ID,
+		})
+		if igwResource == nil || !igwResource.Properties.(InternetGateway).IsAttachedTo(eni.VPCID) {
+			return false
+		}
+
+		if ip.To4() != nil {
+			return eni.PublicIPv4Address != nil
+		}
+		return len(eni.IPv6Addresses) > 0
+	case RouteTableRouteTargetTypeEgressOnlyInternetGateway:
+		eigwResource := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindEgressOnlyInternetGateway,
+			ID:     route.Target.ID,
+		})
+		if eigwResource == nil || !eigwResource.Properties.(EgressOnlyInternetGateway).IsAttachedTo(eni.VPCID) {
+			return false
+		}
+
+		return ip.To4() == nil && len(eni.IPv6Addresses) > 0 && selfRole == reach.SubjectRoleSource
+	case RouteTableRouteTargetTypeNATGateway:
+		return selfRole == reach.SubjectRoleSource
+	case RouteTableRouteTargetTypeVPCEndpoint:
+		vpceResource := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindVPCEndpoint,
+			ID:     route.Target.ID,
+		})
+		if vpceResource == nil {
+			return false
+		}
+		vpce := vpceResource.Properties.(VPCEndpoint)
+
+		return vpce.Type == VPCEndpointTypeGateway && vpce.IsAvailable() && vpce.VPCID == eni.VPCID && selfRole == reach.SubjectRoleSource
+	case RouteTableRouteTargetTypeLocal,
+		RouteTableRouteTargetTypeVirtualPrivateGateway,
+		RouteTableRouteTargetTypeLocalGateway:
+		return true
+	default:
+		return false
+	}
+}
+
+// viaInternet returns a boolean indicating whether network traffic to the IP address travels over the internet, which is the case when the IP address is outside of AWS (the target ENI is nil) or is the target ENI's public IPv4 address.
+func viaInternet(targetENI *ElasticNetworkInterface, ip net.IP) bool {
+	return targetENI == nil || (targetENI.PublicIPv4Address != nil && targetENI.PublicIPv4Address.Equal(ip))
+}
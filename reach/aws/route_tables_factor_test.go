@@ -0,0 +1,548 @@
+package aws
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestNewRouteTablesFactor(t *testing.T) {
+	type resource interface {
+		ToResource() reach.Resource
+		ToResourceReference() reach.ResourceReference
+	}
+
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	localRoute := RouteTableRoute{
+		Destination: mustParseCIDR("10.0.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeLocal, ID: "local"},
+		State:       RouteTableRouteStateActive,
+	}
+	peeringRoute := RouteTableRoute{
+		Destination: mustParseCIDR("192.168.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeVPCPeeringConnection, ID: "pcx-abc123"},
+		State:       RouteTableRouteStateActive,
+	}
+	tgwRoute := RouteTableRoute{
+		Destination: mustParseCIDR("192.168.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeTransitGateway, ID: "tgw-abc123"},
+		State:       RouteTableRouteStateActive,
+	}
+	applianceRoute := RouteTableRoute{
+		Destination: mustParseCIDR("192.168.0.0/16"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeElasticNetworkInterface, ID: "eni-appliance"},
+		State:       RouteTableRouteStateActive,
+	}
+	blackholeRoute := RouteTableRoute{
+		Destination: mustParseCIDR("10.0.2.0/24"),
+		Target:      RouteTableRouteTarget{Type: RouteTableRouteTargetTypeElasticNetworkInterface, ID: "eni-deleted"},
+		State:       RouteTableRouteStateBlackhole,
+	}
+
+	eni := ElasticNetworkInterface{
+		ID:                   "eni-self",
+		SubnetID:             "subnet-self",
+		VPCID:                "vpc-abc123",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.1.10")},
+	}
+	sameVPCENI := ElasticNetworkInterface{
+		ID:                   "eni-other",
+		SubnetID:             "subnet-other",
+		VPCID:                "vpc-abc123",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.2.10")},
+	}
+	peerVPCENI := ElasticNetworkInterface{
+		ID:                   "eni-peer",
+		SubnetID:             "subnet-peer",
+		VPCID:                "vpc-peer",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("192.168.1.10")},
+	}
+
+	peeringConnection := func(status, accepterVPCID string) VPCPeeringConnection {
+		return VPCPeeringConnection{
+			ID:             "pcx-abc123",
+			Status:         status,
+			RequesterVPCID: eni.VPCID,
+			AccepterVPCID:  accepterVPCID,
+		}
+	}
+
+	tgw := TransitGateway{ID: "tgw-abc123", State: TransitGatewayStateAvailable}
+	tgwAttachment := func(id, vpcID, routeTableID string) TransitGatewayAttachment {
+		return TransitGatewayAttachment{
+			ID:                     id,
+			TransitGatewayID:       tgw.ID,
+			ResourceType:           TransitGatewayAttachmentResourceTypeVPC,
+			ResourceID:             vpcID,
+			State:                  TransitGatewayAttachmentStateAvailable,
+			AssociatedRouteTableID: routeTableID,
+		}
+	}
+	selfAttachment := tgwAttachment("tgw-attach-self", eni.VPCID, "tgw-rtb-abc123")
+	peerAttachment := tgwAttachment("tgw-attach-peer", peerVPCENI.VPCID, "")
+	tgwRouteTable := func(routes ...TransitGatewayRoute) TransitGatewayRouteTable {
+		return TransitGatewayRouteTable{ID: "tgw-rtb-abc123", TransitGatewayID: tgw.ID, Routes: routes}
+	}
+	tgwRouteToPeer := TransitGatewayRoute{
+		Destination: mustParseCIDR("192.168.0.0/16"),
+		Attachments: []TransitGatewayRouteAttachment{
+			{ID: "tgw-attach-peer", ResourceType: TransitGatewayAttachmentResourceTypeVPC, ResourceID: peerVPCENI.VPCID},
+		},
+		State: TransitGatewayRouteStateActive,
+	}
+	tgwBlackholeRoute := TransitGatewayRoute{
+		Destination: mustParseCIDR("192.168.1.0/24"),
+		State:       "blackhole",
+	}
+
+	newCollection := func(routes []RouteTableRoute, resources ...resource) *reach.ResourceCollection {
+		rc := reach.NewResourceCollection()
+
+		subnet := Subnet{ID: eni.SubnetID, RouteTableID: "rtb-abc123", VPCID: eni.VPCID}
+		rc.Put(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSubnet, ID: subnet.ID}, subnet.ToResource())
+
+		routeTable := RouteTable{ID: "rtb-abc123", VPCID: eni.VPCID, Routes: routes}
+		rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+
+		for _, r := range resources {
+			rc.Put(r.ToResourceReference(), r.ToResource())
+		}
+
+		return rc
+	}
+
+	newPerspective := func(selfRole reach.SubjectRole, target ElasticNetworkInterface) reach.Perspective {
+		otherRole := reach.SubjectRoleDestination
+		if selfRole == reach.SubjectRoleDestination {
+			otherRole = reach.SubjectRoleSource
+		}
+
+		return reach.Perspective{
+			Self:      reach.NetworkPoint{IPAddress: eni.PrivateIPv4Addresses[0]},
+			Other:     reach.NetworkPoint{IPAddress: target.PrivateIPv4Addresses[0]},
+			SelfRole:  selfRole,
+			OtherRole: otherRole,
+		}
+	}
+
+	all := reach.NewTrafficContentForAllTraffic()
+	none := reach.NewTrafficContentForNoTraffic()
+
+	cases := []struct {
+		name                  string
+		rc                    *reach.ResourceCollection
+		selfRole              reach.SubjectRole
+		target                ElasticNetworkInterface
+		expectedRoute         *RouteTableRoute
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+	}{
+		{
+			name:                  "local route",
+			rc:                    newCollection([]RouteTableRoute{localRoute, peeringRoute}),
+			selfRole:              reach.SubjectRoleSource,
+			target:                sameVPCENI,
+			expectedRoute:         &localRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "no matching route for source",
+			rc:                    newCollection([]RouteTableRoute{peeringRoute}),
+			selfRole:              reach.SubjectRoleSource,
+			target:                sameVPCENI,
+			expectedRoute:         nil,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "no matching route for destination",
+			rc:                    newCollection([]RouteTableRoute{peeringRoute}),
+			selfRole:              reach.SubjectRoleDestination,
+			target:                sameVPCENI,
+			expectedRoute:         nil,
+			expectedTraffic:       all,
+			expectedReturnTraffic: none,
+		},
+		{
+			name:                  "more specific blackhole route for source",
+			rc:                    newCollection([]RouteTableRoute{localRoute, blackholeRoute}),
+			selfRole:              reach.SubjectRoleSource,
+			target:                sameVPCENI,
+			expectedRoute:         &blackholeRoute,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "more specific blackhole route for destination",
+			rc:                    newCollection([]RouteTableRoute{localRoute, blackholeRoute}),
+			selfRole:              reach.SubjectRoleDestination,
+			target:                sameVPCENI,
+			expectedRoute:         &blackholeRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: none,
+		},
+		{
+			name:                  "active peering connection to the target's VPC",
+			rc:                    newCollection([]RouteTableRoute{localRoute, peeringRoute}, peeringConnection(VPCPeeringConnectionStatusActive, peerVPCENI.VPCID)),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &peeringRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "pending peering connection to the target's VPC",
+			rc:                    newCollection([]RouteTableRoute{localRoute, peeringRoute}, peeringConnection("pending-acceptance", peerVPCENI.VPCID)),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &peeringRoute,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "active peering connection to a different VPC",
+			rc:                    newCollection([]RouteTableRoute{localRoute, peeringRoute}, peeringConnection(VPCPeeringConnectionStatusActive, "vpc-other")),
+			selfRole:              reach.SubjectRoleDestination,
+			target:                peerVPCENI,
+			expectedRoute:         &peeringRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: none,
+		},
+		{
+			name:                  "peering connection missing from the collection",
+			rc:                    newCollection([]RouteTableRoute{localRoute, peeringRoute}),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &peeringRoute,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "transit gateway route to the target's VPC",
+			rc:                    newCollection([]RouteTableRoute{localRoute, tgwRoute}, tgw, selfAttachment, peerAttachment, tgwRouteTable(tgwRouteToPeer)),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &tgwRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "more specific transit gateway blackhole route",
+			rc:                    newCollection([]RouteTableRoute{localRoute, tgwRoute}, tgw, selfAttachment, peerAttachment, tgwRouteTable(tgwRouteToPeer, tgwBlackholeRoute)),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &tgwRoute,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+		{
+			name:                  "VPC not attached to the transit gateway",
+			rc:                    newCollection([]RouteTableRoute{localRoute, tgwRoute}, tgw, peerAttachment, tgwRouteTable(tgwRouteToPeer)),
+			selfRole:              reach.SubjectRoleDestination,
+			target:                peerVPCENI,
+			expectedRoute:         &tgwRoute,
+			expectedTraffic:       all,
+			expectedReturnTraffic: none,
+		},
+		{
+			name:                  "route to another VPC through a network interface",
+			rc:                    newCollection([]RouteTableRoute{localRoute, applianceRoute}),
+			selfRole:              reach.SubjectRoleSource,
+			target:                peerVPCENI,
+			expectedRoute:         &applianceRoute,
+			expectedTraffic:       none,
+			expectedReturnTraffic: all,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			awsP := newPerspectiveSourceOriented()
+			if tc.selfRole == reach.SubjectRoleDestination {
+				awsP = newPerspectiveDestinationOriented()
+			}
+
+			target := tc.target
+			factor, err := eni.newRouteTablesFactor(tc.rc, newPerspective(tc.selfRole, target), awsP, &target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if route := factor.Properties.(routeTablesFactor).MatchedRoute; !reflect.DeepEqual(tc.expectedRoute, route) {
+				reach.DiffErrorf(t, "matched route", tc.expectedRoute, route)
+			}
+
+			if factor.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, factor.Traffic)
+			}
+
+			if factor.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+				reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, factor.ReturnTraffic)
+			}
+		})
+	}
+}
+
+func TestNewRouteTablesFactorOutsideAWS(t *testing.T) {
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	route := func(cidr string, targetType RouteTableRouteTargetType, id string) RouteTableRoute {
+		return RouteTableRoute{
+			Destination: mustParseCIDR(cidr),
+			Target:      RouteTableRouteTarget{Type: targetType, ID: id},
+			State:       RouteTableRouteStateActive,
+		}
+	}
+
+	igwRoute := route("0.0.0.0/0", RouteTableRouteTargetTypeInternetGateway, "igw-abc123")
+	igwIPv6Route := route("::/0", RouteTableRouteTargetTypeInternetGateway, "igw-abc123")
+	eigwRoute := route("::/0", RouteTableRouteTargetTypeEgressOnlyInternetGateway, "eigw-abc123")
+	vgwRoute := route("0.0.0.0/0", RouteTableRouteTargetTypeVirtualPrivateGateway, "vgw-abc123")
+	peeringRoute := route("0.0.0.0/0", RouteTableRouteTargetTypeVPCPeeringConnection, "pcx-abc123")
+	unknownRoute := route("0.0.0.0/0", RouteTableRouteTargetTypeUnknown, "")
+	vpceRoute := route("52.216.0.0/15", RouteTableRouteTargetTypeVPCEndpoint, "vpce-abc123")
+
+	public := ElasticNetworkInterface{
+		ID:                   "eni-public",
+		SubnetID:             "subnet-self",
+		VPCID:                "vpc-abc123",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.1.10")},
+		PublicIPv4Address:    net.ParseIP("54.0.0.10"),
+		IPv6Addresses:        []net.IP{net.ParseIP("2600:1f18::10")},
+	}
+	private := ElasticNetworkInterface{
+		ID:                   "eni-private",
+		SubnetID:             "subnet-self",
+		VPCID:                "vpc-abc123",
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.1.11")},
+	}
+
+	igw := InternetGateway{
+		ID:          "igw-abc123",
+		Attachments: []InternetGatewayAttachment{{VPCID: "vpc-abc123", State: "available"}},
+	}
+	detachedIGW := InternetGateway{ID: "igw-abc123"}
+	eigw := EgressOnlyInternetGateway{
+		ID:          "eigw-abc123",
+		Attachments: []InternetGatewayAttachment{{VPCID: "vpc-abc123", State: "attached"}},
+	}
+	gatewayEndpoint := func(state, vpcID string) VPCEndpoint {
+		return VPCEndpoint{
+			ID:          "vpce-abc123",
+			Type:        VPCEndpointTypeGateway,
+			ServiceName: "com.amazonaws.us-east-1.s3",
+			State:       state,
+			VPCID:       vpcID,
+		}
+	}
+
+	newCollection := func(routes []RouteTableRoute, gateways ...reach.Resource) *reach.ResourceCollection {
+		rc := reach.NewResourceCollection()
+
+		subnet := Subnet{ID: "subnet-self", RouteTableID: "rtb-abc123", VPCID: "vpc-abc123"}
+		rc.Put(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindSubnet, ID: subnet.ID}, subnet.ToResource())
+
+		routeTable := RouteTable{ID: "rtb-abc123", VPCID: "vpc-abc123", Routes: routes}
+		rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+
+		for _, g := range gateways {
+			switch properties := g.Properties.(type) {
+			case InternetGateway:
+				rc.Put(properties.ToResourceReference(), g)
+			case EgressOnlyInternetGateway:
+				rc.Put(properties.ToResourceReference(), g)
+			case VPCEndpoint:
+				rc.Put(properties.ToResourceReference(), g)
+			}
+		}
+
+		return rc
+	}
+
+	all := reach.NewTrafficContentForAllTraffic()
+	none := reach.NewTrafficContentForNoTraffic()
+
+	cases := []struct {
+		name                  string
+		rc                    *reach.ResourceCollection
+		eni                   ElasticNetworkInterface
+		selfRole              reach.SubjectRole
+		ip                    string
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+	}{
+		{
+			"internet gateway, to IPv4 address",
+			newCollection([]RouteTableRoute{igwRoute}, igw.ToResource()),
+			public,
+			reach.SubjectRoleSource,
+			"203.0.113.10",
+			all,
+			all,
+		},
+		{
+			"internet gateway, from IPv4 address",
+			newCollection([]RouteTableRoute{igwRoute}, igw.ToResource()),
+			public,
+			reach.SubjectRoleDestination,
+			"203.0.113.10",
+			all,
+			all,
+		},
+		{
+			"internet gateway, without a public IPv4 address",
+			newCollection([]RouteTableRoute{igwRoute}, igw.ToResource()),
+			private,
+			reach.SubjectRoleDestination,
+			"203.0.113.10",
+			none,
+			none,
+		},
+		{
+			"internet gateway, to IPv6 address",
+			newCollection([]RouteTableRoute{igwIPv6Route}, igw.ToResource()),
+			public,
+			reach.SubjectRoleSource,
+			"2001:db8::1",
+			all,
+			all,
+		},
+		{
+			"internet gateway not attached to the VPC",
+			newCollection([]RouteTableRoute{igwRoute}, detachedIGW.ToResource()),
+			public,
+			reach.SubjectRoleSource,
+			"203.0.113.10",
+			none,
+			all,
+		},
+		{
+			"egress-only internet gateway, to IPv6 address",
+			newCollection([]RouteTableRoute{eigwRoute}, eigw.ToResource()),
+			public,
+			reach.SubjectRoleSource,
+			"2001:db8::1",
+			all,
+			all,
+		},
+		{
+			"egress-only internet gateway, from IPv6 address",
+			newCollection([]RouteTableRoute{eigwRoute}, eigw.ToResource()),
+			public,
+			reach.SubjectRoleDestination,
+			"2001:db8::1",
+			none,
+			none,
+		},
+		{
+			"virtual private gateway",
+			newCollection([]RouteTableRoute{vgwRoute}),
+			private,
+			reach.SubjectRoleSource,
+			"192.168.1.10",
+			all,
+			all,
+		},
+		{
+			"gateway VPC endpoint, to AWS service",
+			newCollection([]RouteTableRoute{vpceRoute}, gatewayEndpoint("available", "vpc-abc123").ToResource()),
+			private,
+			reach.SubjectRoleSource,
+			"52.216.0.10",
+			all,
+			all,
+		},
+		{
+			"gateway VPC endpoint, from AWS service",
+			newCollection([]RouteTableRoute{vpceRoute}, gatewayEndpoint("available", "vpc-abc123").ToResource()),
+			private,
+			reach.SubjectRoleDestination,
+			"52.216.0.10",
+			none,
+			none,
+		},
+		{
+			"gateway VPC endpoint that isn't available",
+			newCollection([]RouteTableRoute{vpceRoute}, gatewayEndpoint("pending", "vpc-abc123").ToResource()),
+			private,
+			reach.SubjectRoleSource,
+			"52.216.0.10",
+			none,
+			all,
+		},
+		{
+			"gateway VPC endpoint in another VPC",
+			newCollection([]RouteTableRoute{vpceRoute}, gatewayEndpoint("available", "vpc-other").ToResource()),
+			private,
+			reach.SubjectRoleSource,
+			"52.216.0.10",
+			none,
+			all,
+		},
+		{
+			"VPC peering connection",
+			newCollection([]RouteTableRoute{peeringRoute}),
+			private,
+			reach.SubjectRoleSource,
+			"192.168.1.10",
+			none,
+			all,
+		},
+		{
+			"unknown route target",
+			newCollection([]RouteTableRoute{unknownRoute}),
+			private,
+			reach.SubjectRoleSource,
+			"192.168.1.10",
+			none,
+			all,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			awsP := newPerspectiveSourceOriented()
+			otherRole := reach.SubjectRoleDestination
+			if tc.selfRole == reach.SubjectRoleDestination {
+				awsP = newPerspectiveDestinationOriented()
+				otherRole = reach.SubjectRoleSource
+			}
+
+			p := reach.Perspective{
+				Self:      reach.NetworkPoint{IPAddress: tc.eni.PrivateIPv4Addresses[0]},
+				Other:     reach.NetworkPoint{IPAddress: net.ParseIP(tc.ip)},
+				SelfRole:  tc.selfRole,
+				OtherRole: otherRole,
+			}
+
+			factor, err := tc.eni.newRouteTablesFactor(tc.rc, p, awsP, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if factor.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, factor.Traffic)
+			}
+
+			if factor.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+				reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, factor.ReturnTraffic)
+			}
+		})
+	}
+}
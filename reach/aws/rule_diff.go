@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ruleContribution is the traffic that one rule (or several rules with the same target) contributed to a factor.
+type ruleContribution struct {
+	description   string
+	traffic       reach.TrafficContent
+	returnTraffic bool // whether the rule applies to return traffic, which isn't compared with a traffic query
+}
+
+// AttributeRuleDiffs adds rule diffs to the diff's security group rules and network ACL rules factors, showing which rules account for the change in each factor's traffic. Security group rules are identified by their target rather than their position, since adding or removing a rule shifts the positions of the rules after it. Network ACL rules are identified by their rule number. Changes to forward traffic outside of the diff's traffic query are ignored.
+func AttributeRuleDiffs(diff *reach.AnalysisDiff, before, after *reach.Analysis) error {
+	for i := range diff.Vectors {
+		for j := range diff.Vectors[i].Factors {
+			fd := &diff.Vectors[i].Factors[j]
+
+			var contributions func(factor *reach.Factor, rc *reach.ResourceCollection) (map[string]ruleContribution, error)
+
+			switch fd.Kind {
+			case FactorKindSecurityGroupRules:
+				contributions = securityGroupRuleContributions
+			case FactorKindNetworkACLRules:
+				contributions = networkACLRuleContributions
+			default:
+				continue
+			}
+
+			beforeContributions, err := contributions(fd.Before, before.Resources)
+			if err != nil {
+				return err
+			}
+
+			afterContributions, err := contributions(fd.After, after.Resources)
+			if err != nil {
+				return err
+			}
+
+			fd.Rules, err = ruleDiffs(*diff, beforeContributions, afterContributions)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ruleDiffs compares the contributions of each rule, ordered by description, and returns the rules whose contributions changed within the diff's traffic query.
+func ruleDiffs(diff reach.AnalysisDiff, before, after map[string]ruleContribution) ([]reach.RuleDiff, error) {
+	keys := make(map[string]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+
+	var diffs []reach.RuleDiff
+
+	for key := range keys {
+		b, inBefore := before[key]
+		a, inAfter := after[key]
+
+		if !inBefore {
+			b.traffic = reach.NewTrafficContentForNoTraffic()
+		}
+		if !inAfter {
+			a.traffic = reach.NewTrafficContentForNoTraffic()
+		}
+
+		if !a.returnTraffic && !b.returnTraffic {
+			var err error
+
+			if b.traffic, err = diff.ScopeToQuery(b.traffic); err != nil {
+				return nil, err
+			}
+			if a.traffic, err = diff.ScopeToQuery(a.traffic); err != nil {
+				return nil, err
+			}
+		}
+
+		gained, err := a.traffic.Subtract(b.traffic)
+		if err != nil {
+			return nil, err
+		}
+
+		lost, err := b.traffic.Subtract(a.traffic)
+		if err != nil {
+			return nil, err
+		}
+
+		if gained.None() && lost.None() {
+			continue
+		}
+
+		description := a.description
+		if !inAfter {
+			description = b.description
+		}
+
+		diffs = append(diffs, reach.RuleDiff{
+			Description: description,
+			Gained:      gained,
+			Lost:        lost,
+		})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Description < diffs[j].Description
+	})
+
+	return diffs, nil
+}
+
+func securityGroupRuleContributions(factor *reach.Factor, rc *reach.ResourceCollection) (map[string]ruleContribution, error) {
+	result := make(map[string]ruleContribution)
+
+	if factor == nil {
+		return result, nil
+	}
+
+	props, ok := factor.Properties.(securityGroupRulesFactor)
+	if !ok {
+		return nil, fmt.Errorf("unable to attribute changes to security group rules: unexpected factor properties: %T", factor.Properties)
+	}
+
+	for _, component := range props.RuleComponents {
+		sgName := component.SecurityGroup.ID
+		if resource := rc.Get(component.SecurityGroup); resource != nil {
+			sgName = resource.Properties.(SecurityGroup).Name()
+		}
+
+		target := component.Match.target()
+		key := fmt.Sprintf("%s/%s/%s", component.SecurityGroup.ID, component.RuleDirection, target)
+
+		contribution, exists := result[key]
+		if !exists {
+			contribution = ruleContribution{
+				description: fmt.Sprintf("%s rule in security group %s for %s", component.RuleDirection, sgName, target),
+				traffic:     reach.NewTrafficContentForNoTraffic(),
+			}
+		}
+
+		merged, err := contribution.traffic.Merge(component.Traffic)
+		if err != nil {
+			return nil, err
+		}
+		contribution.traffic = merged
+
+		result[key] = contribution
+	}
+
+	return result, nil
+}
+
+func networkACLRuleContributions(factor *reach.Factor, _ *reach.ResourceCollection) (map[string]ruleContribution, error) {
+	result := make(map[string]ruleContribution)
+
+	if factor == nil {
+		return result, nil
+	}
+
+	props, ok := factor.Properties.(networkACLRulesFactor)
+	if !ok {
+		return nil, fmt.Errorf("unable to attribute changes to network ACL rules: unexpected factor properties: %T", factor.Properties)
+	}
+
+	add := func(components []networkACLRulesFactorComponent, trafficDirection string, returnTraffic bool) error {
+		for _, component := range components {
+			key := fmt.Sprintf("%s/%s/%s/%d", trafficDirection, component.NetworkACL.ID, component.RuleDirection, component.RuleNumber)
+
+			contribution, exists := result[key]
+			if !exists {
+				contribution = ruleContribution{
+					description: fmt.Sprintf(
+						"%s rule #%d in network ACL %s for IP CIDR block %s (%s)",
+						component.RuleDirection,
+						component.RuleNumber,
+						component.NetworkACL.ID,
+						component.Match.Requirement.String(),
+						trafficDirection,
+					),
+					traffic:       reach.NewTrafficContentForNoTraffic(),
+					returnTraffic: returnTraffic,
+				}
+			}
+
+			merged, err := contribution.traffic.Merge(component.Traffic)
+			if err != nil {
+				return err
+			}
+			contribution.traffic = merged
+
+			result[key] = contribution
+		}
+
+		return nil
+	}
+
+	if err := add(props.RuleComponentsForwardDirection, "forward traffic", false); err != nil {
+		return nil, err
+	}
+
+	if err := add(props.RuleComponentsReturnDirection, "return traffic", true); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
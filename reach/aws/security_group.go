@@ -14,6 +14,7 @@ type SecurityGroup struct {
 	ID            string
 	NameTag       string
 	GroupName     string
+	OwnerID       string `json:"OwnerID,omitempty"`
 	VPCID         string
 	InboundRules  []SecurityGroupRule
 	OutboundRules []SecurityGroupRule
@@ -57,6 +58,14 @@ func (sg SecurityGroup) Dependencies(provider ResourceProvider) (*reach.Resource
 				ID:     sgRef.ID,
 			}, sgRef.ToResource())
 		}
+
+		for _, id := range rule.TargetPrefixListIDs {
+			prefixList, err := provider.ManagedPrefixList(id)
+			if err != nil {
+				return nil, err
+			}
+			rc.Put(prefixList.ToResourceReference(), prefixList.ToResource())
+		}
 	}
 
 	return rc, nil
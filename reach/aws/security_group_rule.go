@@ -12,6 +12,7 @@ type SecurityGroupRule struct {
 	TargetSecurityGroupReferenceID        string       `json:"TargetSecurityGroupReferenceID,omitempty"`
 	TargetSecurityGroupReferenceAccountID string       `json:"TargetSecurityGroupReferenceAccountID,omitempty"`
 	TargetIPNetworks                      []*net.IPNet `json:"TargetIPNetworks,omitempty"`
+	TargetPrefixListIDs                   []string     `json:"TargetPrefixListIDs,omitempty"`
 }
 
 func (rule SecurityGroupRule) matchByIP(ip net.IP) *securityGroupRuleMatch {
@@ -28,10 +29,33 @@ func (rule SecurityGroupRule) matchByIP(ip net.IP) *securityGroupRuleMatch {
 	return nil
 }
 
+// matchByPrefixList returns a match if any entry of the prefix lists specified by the rule contains the given IP address. Prefix lists that aren't in the resource collection can't produce a match.
+func (rule SecurityGroupRule) matchByPrefixList(ip net.IP, rc *reach.ResourceCollection) *securityGroupRuleMatch {
+	for _, id := range rule.TargetPrefixListIDs {
+		resource := rc.Get(reach.ResourceReference{Domain: ResourceDomainAWS, Kind: ResourceKindPrefixList, ID: id})
+		if resource == nil {
+			continue
+		}
+
+		if entry := resource.Properties.(PrefixList).entryForIP(ip); entry != nil {
+			return &securityGroupRuleMatch{
+				Basis: securityGroupRuleMatchBasisPrefixList,
+				Requirement: securityGroupRulePrefixListRequirement{
+					PrefixListID: id,
+					Entry:        *entry,
+				},
+				Value: ip,
+			}
+		}
+	}
+
+	return nil
+}
+
 func (rule SecurityGroupRule) matchBySecurityGroup(eni *ElasticNetworkInterface) *securityGroupRuleMatch {
-	if eni != nil {
+	if eni != nil && rule.matchesSecurityGroupAccount(eni.OwnerID) {
 		for _, targetENISecurityGroupID := range eni.SecurityGroupIDs {
-			if rule.TargetSecurityGroupReferenceID == targetENISecurityGroupID { // TODO: Handle SG Account ID
+			if rule.TargetSecurityGroupReferenceID == targetENISecurityGroupID {
 				return &securityGroupRuleMatch{
 					Basis:       securityGroupRuleMatchBasisSGRef,
 					Requirement: rule.TargetSecurityGroupReferenceID,
@@ -43,3 +67,12 @@ func (rule SecurityGroupRule) matchBySecurityGroup(eni *ElasticNetworkInterface)
 
 	return nil
 }
+
+// matchesSecurityGroupAccount determines whether the account that owns a target security group could be the account specified by the rule. Rules that refer to security groups in peered VPCs identify those groups by both account ID and group ID.
+func (rule SecurityGroupRule) matchesSecurityGroupAccount(accountID string) bool {
+	if rule.TargetSecurityGroupReferenceAccountID == "" || accountID == "" {
+		return true // not enough information to rule out a match
+	}
+
+	return rule.TargetSecurityGroupReferenceAccountID == accountID
+}
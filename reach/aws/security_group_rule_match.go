@@ -1,7 +1,29 @@
 package aws
 
+import "fmt"
+
 type securityGroupRuleMatch struct {
 	Basis       securityGroupRuleMatchBasis
 	Requirement interface{}
 	Value       interface{}
 }
+
+// securityGroupRulePrefixListRequirement identifies the prefix list entry that caused a security group rule to match.
+type securityGroupRulePrefixListRequirement struct {
+	PrefixListID string
+	Entry        PrefixListEntry
+}
+
+// target describes what the rule specified that made the rule match, e.g. "IP CIDR block 0.0.0.0/0".
+func (m securityGroupRuleMatch) target() string {
+	switch m.Basis {
+	case securityGroupRuleMatchBasisIP:
+		return fmt.Sprintf("IP CIDR block %s", m.Requirement)
+	case securityGroupRuleMatchBasisPrefixList:
+		return fmt.Sprintf("prefix list %s", m.Requirement.(securityGroupRulePrefixListRequirement).PrefixListID)
+	case securityGroupRuleMatchBasisSGRef:
+		return fmt.Sprintf("security group %s", m.Requirement)
+	default:
+		return fmt.Sprintf("%s %v", m.Basis, m.Requirement)
+	}
+}
@@ -4,6 +4,7 @@ type securityGroupRuleMatchBasis string
 
 const securityGroupRuleMatchBasisIP securityGroupRuleMatchBasis = "IP"
 const securityGroupRuleMatchBasisSGRef securityGroupRuleMatchBasis = "SecurityGroupReference"
+const securityGroupRuleMatchBasisPrefixList securityGroupRuleMatchBasis = "PrefixList"
 
 // String returns the string representation of a security group rule match.
 func (basis securityGroupRuleMatchBasis) String() string {
@@ -12,6 +13,8 @@ func (basis securityGroupRuleMatchBasis) String() string {
 		return "IP address"
 	case securityGroupRuleMatchBasisSGRef:
 		return "attached security group"
+	case securityGroupRuleMatchBasisPrefixList:
+		return "prefix list entry"
 	default:
 		return "[unknown match basis]"
 	}
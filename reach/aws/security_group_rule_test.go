@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"net"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestSecurityGroupRuleMatchByPrefixList(t *testing.T) {
+	mustParseCIDR := func(s string) *net.IPNet {
+		_, network, err := net.ParseCIDR(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	prefixList := PrefixList{
+		ID:             "pl-corp",
+		PrefixListName: "corporate-networks",
+		Entries: []PrefixListEntry{
+			{CIDR: mustParseCIDR("198.51.100.0/24"), Description: "office"},
+			{CIDR: mustParseCIDR("192.168.5.0/24")},
+		},
+	}
+
+	rc := reach.NewResourceCollection()
+	rc.Put(prefixList.ToResourceReference(), prefixList.ToResource())
+
+	cases := []struct {
+		name          string
+		prefixListIDs []string
+		ip            string
+		expectedEntry string // empty when no match is expected
+	}{
+		{
+			"IP address in entry with description",
+			[]string{"pl-corp"},
+			"198.51.100.10",
+			"198.51.100.0/24 (office)",
+		},
+		{
+			"IP address in entry without description",
+			[]string{"pl-corp"},
+			"192.168.5.1",
+			"192.168.5.0/24",
+		},
+		{
+			"IP address outside every entry",
+			[]string{"pl-corp"},
+			"203.0.113.10",
+			"",
+		},
+		{
+			"prefix list missing from resource collection",
+			[]string{"pl-unknown"},
+			"198.51.100.10",
+			"",
+		},
+		{
+			"missing prefix list followed by known prefix list",
+			[]string{"pl-unknown", "pl-corp"},
+			"198.51.100.10",
+			"198.51.100.0/24 (office)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := SecurityGroupRule{TargetPrefixListIDs: tc.prefixListIDs}
+
+			match := rule.matchByPrefixList(net.ParseIP(tc.ip), rc)
+
+			if tc.expectedEntry == "" {
+				if match != nil {
+					t.Errorf("expected no match, but got match with requirement: %v", match.Requirement)
+				}
+				return
+			}
+
+			if match == nil {
+				t.Fatal("expected a match, but got nil")
+			}
+
+			if match.Basis != securityGroupRuleMatchBasisPrefixList {
+				t.Errorf("expected basis %v, but got %v", securityGroupRuleMatchBasisPrefixList, match.Basis)
+			}
+
+			requirement := match.Requirement.(securityGroupRulePrefixListRequirement)
+			if requirement.PrefixListID != "pl-corp" {
+				t.Errorf("expected prefix list ID pl-corp, but got %s", requirement.PrefixListID)
+			}
+			if entry := requirement.Entry.String(); entry != tc.expectedEntry {
+				t.Errorf("expected entry %q, but got %q", tc.expectedEntry, entry)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package aws
 
 import (
+	"fmt"
+
 	"github.com/luhring/reach/reach"
 )
 
@@ -11,6 +13,21 @@ type securityGroupRulesFactor struct {
 	RuleComponents []securityGroupRulesFactorComponent
 }
 
+// FactorDetails describes each security group rule that contributes traffic to the factor.
+func (f securityGroupRulesFactor) FactorDetails() reach.FactorDetails {
+	var details reach.FactorDetails
+
+	for _, c := range f.RuleComponents {
+		details.Components = append(details.Components, reach.FactorComponent{
+			Resource:    c.SecurityGroup,
+			Description: fmt.Sprintf("%s rule #%d for %s", c.RuleDirection, c.RuleIndex+1, c.Match.target()),
+			Traffic:     c.Traffic,
+		})
+	}
+
+	return details
+}
+
 func (eni ElasticNetworkInterface) newSecurityGroupRulesFactor(
 	rc *reach.ResourceCollection,
 	p reach.Perspective,
@@ -35,6 +52,11 @@ func (eni ElasticNetworkInterface) newSecurityGroupRulesFactor(
 			// check ip match
 			match = rule.matchByIP(p.Other.IPAddress)
 
+			// check prefix list match (only if we don't already have a match)
+			if match == nil {
+				match = rule.matchByPrefixList(p.Other.IPAddress, rc)
+			}
+
 			// check SG ref match (only if we don't already have a match)
 			if match == nil {
 				match = rule.matchBySecurityGroup(targetENI)
@@ -0,0 +1,109 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// decodeProperties decodes the kind-specific properties of a resource into the type used by that kind of resource.
+func decodeProperties(ref reach.ResourceReference, data json.RawMessage) (interface{}, error) {
+	if ref.Domain != aws.ResourceDomainAWS {
+		return nil, fmt.Errorf("unsupported resource domain: '%s'", ref.Domain)
+	}
+
+	switch ref.Kind {
+	case aws.ResourceKindAWSService:
+		var properties aws.AWSService
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindEC2Instance:
+		var properties aws.EC2Instance
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindEgressOnlyInternetGateway:
+		var properties aws.EgressOnlyInternetGateway
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindElasticNetworkInterface:
+		var properties aws.ElasticNetworkInterface
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindInternetGateway:
+		var properties aws.InternetGateway
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindLoadBalancer:
+		var properties aws.LoadBalancer
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindNATGateway:
+		var properties aws.NATGateway
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindNetworkACL:
+		var properties aws.NetworkACL
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindPrefixList:
+		var properties aws.PrefixList
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindRDSCluster:
+		var properties aws.RDSCluster
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindRDSInstance:
+		var properties aws.RDSInstance
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindRouteTable:
+		var properties aws.RouteTable
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindSecurityGroup:
+		var properties aws.SecurityGroup
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindSecurityGroupReference:
+		var properties aws.SecurityGroupReference
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindSubnet:
+		var properties aws.Subnet
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindTargetGroup:
+		var properties aws.TargetGroup
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindTransitGateway:
+		var properties aws.TransitGateway
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindTransitGatewayAttachment:
+		var properties aws.TransitGatewayAttachment
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindTransitGatewayRouteTable:
+		var properties aws.TransitGatewayRouteTable
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindVPC:
+		var properties aws.VPC
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindVPCEndpoint:
+		var properties aws.VPCEndpoint
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	case aws.ResourceKindVPCPeeringConnection:
+		var properties aws.VPCPeeringConnection
+		err := json.Unmarshal(data, &properties)
+		return properties, err
+	default:
+		return nil, fmt.Errorf("unsupported resource kind: '%s'", ref.Kind)
+	}
+}
@@ -0,0 +1,419 @@
+package snapshot
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// ResourceProvider implements an AWS resource provider using the resources recorded in a Snapshot.
+type ResourceProvider struct {
+	snapshot *Snapshot
+}
+
+// NewResourceProvider returns a reference to a new ResourceProvider for the given Snapshot.
+func NewResourceProvider(snapshot *Snapshot) *ResourceProvider {
+	return &ResourceProvider{
+		snapshot: snapshot,
+	}
+}
+
+// AllEC2Instances returns all EC2 instances in the snapshot.
+func (provider *ResourceProvider) AllEC2Instances() ([]aws.EC2Instance, error) {
+	var instances []aws.EC2Instance
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindEC2Instance) {
+		instances = append(instances, resource.Properties.(aws.EC2Instance))
+	}
+
+	return instances, nil
+}
+
+// AllElasticNetworkInterfaces returns all elastic network interfaces in the snapshot.
+func (provider *ResourceProvider) AllElasticNetworkInterfaces() ([]aws.ElasticNetworkInterface, error) {
+	var networkInterfaces []aws.ElasticNetworkInterface
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindElasticNetworkInterface) {
+		networkInterfaces = append(networkInterfaces, resource.Properties.(aws.ElasticNetworkInterface))
+	}
+
+	return networkInterfaces, nil
+}
+
+// AllLoadBalancers returns all load balancers in the snapshot.
+func (provider *ResourceProvider) AllLoadBalancers() ([]aws.LoadBalancer, error) {
+	var loadBalancers []aws.LoadBalancer
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindLoadBalancer) {
+		loadBalancers = append(loadBalancers, resource.Properties.(aws.LoadBalancer))
+	}
+
+	return loadBalancers, nil
+}
+
+// AllRDSClusters returns all Aurora clusters in the snapshot.
+func (provider *ResourceProvider) AllRDSClusters() ([]aws.RDSCluster, error) {
+	var clusters []aws.RDSCluster
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindRDSCluster) {
+		clusters = append(clusters, resource.Properties.(aws.RDSCluster))
+	}
+
+	return clusters, nil
+}
+
+// AllRDSInstances returns all RDS instances in the snapshot.
+func (provider *ResourceProvider) AllRDSInstances() ([]aws.RDSInstance, error) {
+	var instances []aws.RDSInstance
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindRDSInstance) {
+		instances = append(instances, resource.Properties.(aws.RDSInstance))
+	}
+
+	return instances, nil
+}
+
+// AllVPCEndpoints returns all VPC endpoints in the snapshot.
+func (provider *ResourceProvider) AllVPCEndpoints() ([]aws.VPCEndpoint, error) {
+	var endpoints []aws.VPCEndpoint
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindVPCEndpoint) {
+		endpoints = append(endpoints, resource.Properties.(aws.VPCEndpoint))
+	}
+
+	return endpoints, nil
+}
+
+// AllAWSServices returns all AWS services in the snapshot.
+func (provider *ResourceProvider) AllAWSServices() ([]aws.AWSService, error) {
+	var services []aws.AWSService
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindAWSService) {
+		services = append(services, resource.Properties.(aws.AWSService))
+	}
+
+	return services, nil
+}
+
+// AWSService returns the AWS service from the snapshot matching the given service name.
+func (provider *ResourceProvider) AWSService(name string) (*aws.AWSService, error) {
+	properties, err := provider.get(aws.ResourceKindAWSService, "AWS service", name)
+	if err != nil {
+		return nil, err
+	}
+
+	service := properties.(aws.AWSService)
+	return &service, nil
+}
+
+// EC2Instance returns the EC2 instance from the snapshot matching the given ID.
+func (provider *ResourceProvider) EC2Instance(id string) (*aws.EC2Instance, error) {
+	properties, err := provider.get(aws.ResourceKindEC2Instance, "EC2 instance", id)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := properties.(aws.EC2Instance)
+	return &instance, nil
+}
+
+// EgressOnlyInternetGateway returns the egress-only internet gateway from the snapshot matching the given ID.
+func (provider *ResourceProvider) EgressOnlyInternetGateway(id string) (*aws.EgressOnlyInternetGateway, error) {
+	properties, err := provider.get(aws.ResourceKindEgressOnlyInternetGateway, "egress-only internet gateway", id)
+	if err != nil {
+		return nil, err
+	}
+
+	eigw := properties.(aws.EgressOnlyInternetGateway)
+	return &eigw, nil
+}
+
+// ElasticNetworkInterface returns the elastic network interface from the snapshot matching the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterface(id string) (*aws.ElasticNetworkInterface, error) {
+	properties, err := provider.get(aws.ResourceKindElasticNetworkInterface, "elastic network interface", id)
+	if err != nil {
+		return nil, err
+	}
+
+	eni := properties.(aws.ElasticNetworkInterface)
+	return &eni, nil
+}
+
+// ElasticNetworkInterfaceForIPAddress returns the elastic network interface from the snapshot that has the given IP address, or nil if there isn't one.
+func (provider *ResourceProvider) ElasticNetworkInterfaceForIPAddress(ip net.IP) (*aws.ElasticNetworkInterface, error) {
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindElasticNetworkInterface) {
+		eni := resource.Properties.(aws.ElasticNetworkInterface)
+
+		if eni.HasIPAddress(ip) {
+			return &eni, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// ElasticNetworkInterfacesForSecurityGroup returns the elastic network interfaces from the snapshot that use the security group with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForSecurityGroup(id string) ([]aws.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfaces(func(eni aws.ElasticNetworkInterface) bool {
+		for _, sgID := range eni.SecurityGroupIDs {
+			if sgID == id {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
+
+// ElasticNetworkInterfacesForSubnet returns the elastic network interfaces from the snapshot in the subnet with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForSubnet(id string) ([]aws.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfaces(func(eni aws.ElasticNetworkInterface) bool {
+		return eni.SubnetID == id
+	}), nil
+}
+
+// ElasticNetworkInterfacesForVPC returns the elastic network interfaces from the snapshot in the VPC with the given ID.
+func (provider *ResourceProvider) ElasticNetworkInterfacesForVPC(id string) ([]aws.ElasticNetworkInterface, error) {
+	return provider.elasticNetworkInterfaces(func(eni aws.ElasticNetworkInterface) bool {
+		return eni.VPCID == id
+	}), nil
+}
+
+// InternetGateway returns the internet gateway from the snapshot matching the given ID.
+func (provider *ResourceProvider) InternetGateway(id string) (*aws.InternetGateway, error) {
+	properties, err := provider.get(aws.ResourceKindInternetGateway, "internet gateway", id)
+	if err != nil {
+		return nil, err
+	}
+
+	igw := properties.(aws.InternetGateway)
+	return &igw, nil
+}
+
+// LoadBalancer returns the load balancer from the snapshot matching the given ID.
+func (provider *ResourceProvider) LoadBalancer(id string) (*aws.LoadBalancer, error) {
+	properties, err := provider.get(aws.ResourceKindLoadBalancer, "load balancer", id)
+	if err != nil {
+		return nil, err
+	}
+
+	lb := properties.(aws.LoadBalancer)
+	return &lb, nil
+}
+
+// ManagedPrefixList returns the managed prefix list from the snapshot matching the given ID.
+func (provider *ResourceProvider) ManagedPrefixList(id string) (*aws.PrefixList, error) {
+	properties, err := provider.get(aws.ResourceKindPrefixList, "managed prefix list", id)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixList := properties.(aws.PrefixList)
+	return &prefixList, nil
+}
+
+// NATGateway returns the NAT gateway from the snapshot matching the given ID.
+func (provider *ResourceProvider) NATGateway(id string) (*aws.NATGateway, error) {
+	properties, err := provider.get(aws.ResourceKindNATGateway, "NAT gateway", id)
+	if err != nil {
+		return nil, err
+	}
+
+	nat := properties.(aws.NATGateway)
+	return &nat, nil
+}
+
+// NetworkACL returns the network ACL from the snapshot matching the given ID.
+func (provider *ResourceProvider) NetworkACL(id string) (*aws.NetworkACL, error) {
+	properties, err := provider.get(aws.ResourceKindNetworkACL, "network ACL", id)
+	if err != nil {
+		return nil, err
+	}
+
+	networkACL := properties.(aws.NetworkACL)
+	return &networkACL, nil
+}
+
+// RDSCluster returns the Aurora cluster from the snapshot matching the given ID.
+func (provider *ResourceProvider) RDSCluster(id string) (*aws.RDSCluster, error) {
+	properties, err := provider.get(aws.ResourceKindRDSCluster, "RDS cluster", id)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := properties.(aws.RDSCluster)
+	return &cluster, nil
+}
+
+// RDSInstance returns the RDS instance from the snapshot matching the given ID.
+func (provider *ResourceProvider) RDSInstance(id string) (*aws.RDSInstance, error) {
+	properties, err := provider.get(aws.ResourceKindRDSInstance, "RDS instance", id)
+	if err != nil {
+		return nil, err
+	}
+
+	db := properties.(aws.RDSInstance)
+	return &db, nil
+}
+
+// RouteTable returns the route table from the snapshot matching the given ID.
+func (provider *ResourceProvider) RouteTable(id string) (*aws.RouteTable, error) {
+	properties, err := provider.get(aws.ResourceKindRouteTable, "route table", id)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTable := properties.(aws.RouteTable)
+	return &routeTable, nil
+}
+
+// SecurityGroup returns the security group from the snapshot matching the given ID.
+func (provider *ResourceProvider) SecurityGroup(id string) (*aws.SecurityGroup, error) {
+	properties, err := provider.get(aws.ResourceKindSecurityGroup, "security group", id)
+	if err != nil {
+		return nil, err
+	}
+
+	sg := properties.(aws.SecurityGroup)
+	return &sg, nil
+}
+
+// SecurityGroupReference returns the security group reference from the snapshot matching the given ID. As with the AWS API provider, a group in another AWS account that's missing from the snapshot is still returned (with just its ID and account ID), so that rules referring to it can be matched.
+func (provider *ResourceProvider) SecurityGroupReference(id, accountID string) (*aws.SecurityGroupReference, error) {
+	properties, err := provider.get(aws.ResourceKindSecurityGroupReference, "security group reference", id)
+	if err != nil {
+		if accountID != "" {
+			return &aws.SecurityGroupReference{
+				ID:        id,
+				AccountID: accountID,
+			}, nil
+		}
+
+		return nil, err
+	}
+
+	sgRef := properties.(aws.SecurityGroupReference)
+	if sgRef.AccountID == "" {
+		sgRef.AccountID = accountID
+	}
+
+	return &sgRef, nil
+}
+
+// Subnet returns the subnet from the snapshot matching the given ID.
+func (provider *ResourceProvider) Subnet(id string) (*aws.Subnet, error) {
+	properties, err := provider.get(aws.ResourceKindSubnet, "subnet", id)
+	if err != nil {
+		return nil, err
+	}
+
+	subnet := properties.(aws.Subnet)
+	return &subnet, nil
+}
+
+// TargetGroup returns the target group from the snapshot matching the given ID.
+func (provider *ResourceProvider) TargetGroup(id string) (*aws.TargetGroup, error) {
+	properties, err := provider.get(aws.ResourceKindTargetGroup, "target group", id)
+	if err != nil {
+		return nil, err
+	}
+
+	targetGroup := properties.(aws.TargetGroup)
+	return &targetGroup, nil
+}
+
+// TransitGateway returns the transit gateway from the snapshot matching the given ID.
+func (provider *ResourceProvider) TransitGateway(id string) (*aws.TransitGateway, error) {
+	properties, err := provider.get(aws.ResourceKindTransitGateway, "transit gateway", id)
+	if err != nil {
+		return nil, err
+	}
+
+	tgw := properties.(aws.TransitGateway)
+	return &tgw, nil
+}
+
+// TransitGatewayRouteTable returns the transit gateway route table from the snapshot matching the given ID.
+func (provider *ResourceProvider) TransitGatewayRouteTable(id string) (*aws.TransitGatewayRouteTable, error) {
+	properties, err := provider.get(aws.ResourceKindTransitGatewayRouteTable, "transit gateway route table", id)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTable := properties.(aws.TransitGatewayRouteTable)
+	return &routeTable, nil
+}
+
+// TransitGatewayVPCAttachment returns the attachment from the snapshot that connects the given VPC to the given transit gateway, or nil if the snapshot doesn't have one.
+func (provider *ResourceProvider) TransitGatewayVPCAttachment(transitGatewayID, vpcID string) (*aws.TransitGatewayAttachment, error) {
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindTransitGatewayAttachment) {
+		attachment := resource.Properties.(aws.TransitGatewayAttachment)
+
+		if attachment.TransitGatewayID == transitGatewayID &&
+			attachment.ResourceType == aws.TransitGatewayAttachmentResourceTypeVPC &&
+			attachment.ResourceID == vpcID {
+			return &attachment, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// VPC returns the VPC from the snapshot matching the given ID.
+func (provider *ResourceProvider) VPC(id string) (*aws.VPC, error) {
+	properties, err := provider.get(aws.ResourceKindVPC, "VPC", id)
+	if err != nil {
+		return nil, err
+	}
+
+	vpc := properties.(aws.VPC)
+	return &vpc, nil
+}
+
+// VPCEndpoint returns the VPC endpoint from the snapshot matching the given ID.
+func (provider *ResourceProvider) VPCEndpoint(id string) (*aws.VPCEndpoint, error) {
+	properties, err := provider.get(aws.ResourceKindVPCEndpoint, "VPC endpoint", id)
+	if err != nil {
+		return nil, err
+	}
+
+	vpce := properties.(aws.VPCEndpoint)
+	return &vpce, nil
+}
+
+// VPCPeeringConnection returns the VPC peering connection from the snapshot matching the given ID.
+func (provider *ResourceProvider) VPCPeeringConnection(id string) (*aws.VPCPeeringConnection, error) {
+	properties, err := provider.get(aws.ResourceKindVPCPeeringConnection, "VPC peering connection", id)
+	if err != nil {
+		return nil, err
+	}
+
+	pcx := properties.(aws.VPCPeeringConnection)
+	return &pcx, nil
+}
+
+func (provider *ResourceProvider) elasticNetworkInterfaces(match func(eni aws.ElasticNetworkInterface) bool) []aws.ElasticNetworkInterface {
+	var networkInterfaces []aws.ElasticNetworkInterface
+
+	for _, resource := range provider.snapshot.Resources.GetAll(aws.ResourceDomainAWS, aws.ResourceKindElasticNetworkInterface) {
+		if eni := resource.Properties.(aws.ElasticNetworkInterface); match(eni) {
+			networkInterfaces = append(networkInterfaces, eni)
+		}
+	}
+
+	return networkInterfaces
+}
+
+func (provider *ResourceProvider) get(kind, entity, id string) (interface{}, error) {
+	resource := provider.snapshot.Resources.Get(reach.ResourceReference{
+		Domain: aws.ResourceDomainAWS,
+		Kind:   kind,
+		ID:     id,
+	})
+	if resource == nil {
+		return nil, fmt.Errorf("snapshot does not contain a %s for ID '%s'", entity, id)
+	}
+
+	return resource.Properties, nil
+}
@@ -0,0 +1,175 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// FormatVersion is the version of the snapshot file format written by this package. Snapshots with a different version can't be read.
+const FormatVersion = 1
+
+// A Snapshot is a point-in-time record of AWS resources, suitable for saving to disk and analyzing later without access to AWS.
+type Snapshot struct {
+	Version   int
+	CreatedAt time.Time
+	Resources *reach.ResourceCollection
+}
+
+// resourceWorkers is the maximum number of top-level resources whose dependencies are retrieved at the same time.
+const resourceWorkers = 4
+
+// A dependent is a top-level resource (one that can be a subject of an analysis) that's recorded in a snapshot along with its dependencies.
+type dependent interface {
+	ToResourceReference() reach.ResourceReference
+	ToResource() reach.Resource
+	Dependencies(provider aws.ResourceProvider) (*reach.ResourceCollection, error)
+}
+
+// New creates a Snapshot of every EC2 instance, load balancer, RDS instance, Aurora cluster, elastic network interface, VPC endpoint and AWS service available via the given provider, along with all of the resources on which each of them depends.
+func New(provider aws.ResourceProvider) (*Snapshot, error) {
+	var dependents []dependent
+
+	instances, err := provider.AllEC2Instances()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, instance := range instances {
+		dependents = append(dependents, instance)
+	}
+
+	loadBalancers, err := provider.AllLoadBalancers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, lb := range loadBalancers {
+		dependents = append(dependents, lb)
+	}
+
+	rdsInstances, err := provider.AllRDSInstances()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, db := range rdsInstances {
+		dependents = append(dependents, db)
+	}
+
+	rdsClusters, err := provider.AllRDSClusters()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, cluster := range rdsClusters {
+		dependents = append(dependents, cluster)
+	}
+
+	networkInterfaces, err := provider.AllElasticNetworkInterfaces()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, eni := range networkInterfaces {
+		dependents = append(dependents, eni)
+	}
+
+	vpcEndpoints, err := provider.AllVPCEndpoints()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, vpce := range vpcEndpoints {
+		dependents = append(dependents, vpce)
+	}
+
+	rc := reach.NewResourceCollection()
+
+	// AWS services don't have any dependencies of their own.
+	services, err := provider.AllAWSServices()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+	for _, service := range services {
+		rc.Put(service.ToResourceReference(), service.ToResource())
+	}
+
+	err = reach.ForEachConcurrently(len(dependents), resourceWorkers, func(i int) error {
+		d := dependents[i]
+		rc.Put(d.ToResourceReference(), d.ToResource())
+
+		dependencies, err := d.Dependencies(provider)
+		if err != nil {
+			return fmt.Errorf("unable to get dependencies for %s '%s': %v", d.ToResourceReference().Kind, d.ToResourceReference().ID, err)
+		}
+		rc.Merge(dependencies)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot: %v", err)
+	}
+
+	return &Snapshot{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Resources: rc,
+	}, nil
+}
+
+// Write writes the JSON representation of the Snapshot to w.
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(s)
+}
+
+// Read reads a Snapshot from the JSON representation found in r.
+func Read(r io.Reader) (*Snapshot, error) {
+	var document struct {
+		Version   int
+		CreatedAt time.Time
+		Resources map[string]map[string]map[string]struct {
+			Kind       string
+			Properties json.RawMessage
+		}
+	}
+
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot: %v", err)
+	}
+
+	if document.Version != FormatVersion {
+		return nil, fmt.Errorf("unable to read snapshot: unsupported snapshot version %d (expected %d)", document.Version, FormatVersion)
+	}
+
+	rc := reach.NewResourceCollection()
+
+	for domain, kinds := range document.Resources {
+		for kind, resources := range kinds {
+			for id, resource := range resources {
+				ref := reach.ResourceReference{
+					Domain: domain,
+					Kind:   kind,
+					ID:     id,
+				}
+
+				properties, err := decodeProperties(ref, resource.Properties)
+				if err != nil {
+					return nil, fmt.Errorf("unable to read snapshot: unable to decode resource %s: %v", ref, err)
+				}
+
+				rc.Put(ref, reach.Resource{
+					Kind:       resource.Kind,
+					Properties: properties,
+				})
+			}
+		}
+	}
+
+	return &Snapshot{
+		Version:   document.Version,
+		CreatedAt: document.CreatedAt,
+		Resources: rc,
+	}, nil
+}
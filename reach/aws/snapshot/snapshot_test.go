@@ -0,0 +1,303 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	ssh, err := set.NewPortSetFromRange(22, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	echoRequest, err := set.NewICMPSetFromICMPType(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance := aws.EC2Instance{
+		ID:      "i-abc123",
+		NameTag: "web",
+		State:   "running",
+		NetworkInterfaceAttachments: []aws.NetworkInterfaceAttachment{
+			{ID: "eni-attach-abc123", ElasticNetworkInterfaceID: "eni-abc123"},
+		},
+	}
+	sg := aws.SecurityGroup{
+		ID:    "sg-abc123",
+		VPCID: "vpc-abc123",
+		InboundRules: []aws.SecurityGroupRule{
+			{
+				TrafficContent:   reach.NewTrafficContentForPorts(reach.ProtocolTCP, ssh),
+				TargetIPNetworks: []*net.IPNet{mustParseCIDR("10.0.0.0/8")},
+			},
+			{
+				TrafficContent:   reach.NewTrafficContentForICMP(reach.ProtocolICMPv4, echoRequest),
+				TargetIPNetworks: []*net.IPNet{mustParseCIDR("0.0.0.0/0")},
+			},
+		},
+		OutboundRules: []aws.SecurityGroupRule{
+			{
+				TrafficContent:   reach.NewTrafficContentForAllTraffic(),
+				TargetIPNetworks: []*net.IPNet{mustParseCIDR("0.0.0.0/0")},
+			},
+		},
+	}
+	networkACL := aws.NetworkACL{
+		ID: "acl-abc123",
+		InboundRules: []aws.NetworkACLRule{
+			{
+				Number:          100,
+				TrafficContent:  reach.NewTrafficContentForAllTraffic(),
+				TargetIPNetwork: mustParseCIDR("0.0.0.0/0"),
+				Action:          aws.NetworkACLRuleActionAllow,
+			},
+		},
+	}
+
+	rc := reach.NewResourceCollection()
+	rc.Put(instance.ToResourceReference(), instance.ToResource())
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindSecurityGroup, ID: sg.ID}, sg.ToResource())
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindNetworkACL, ID: networkACL.ID}, networkACL.ToResource())
+
+	original := &Snapshot{
+		Version:   FormatVersion,
+		CreatedAt: time.Date(2020, 7, 1, 12, 0, 0, 0, time.UTC),
+		Resources: rc,
+	}
+
+	var buf bytes.Buffer
+	if err := original.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	written := buf.String()
+
+	loaded, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewritten, err := json.MarshalIndent(loaded, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, actual := written, string(rewritten)+"\n"; expected != actual {
+		reach.DiffErrorf(t, "snapshot", expected, actual)
+	}
+
+	provider := NewResourceProvider(loaded)
+
+	instances, err := provider.AllEC2Instances()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) != 1 || instances[0].ID != instance.ID {
+		reach.DiffErrorf(t, "instances", []aws.EC2Instance{instance}, instances)
+	}
+
+	loadedSG, err := provider.SecurityGroup(sg.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := sg.InboundRules[1].TrafficContent.String(), loadedSG.InboundRules[1].TrafficContent.String(); expected != actual {
+		reach.DiffErrorf(t, "security group rule traffic", expected, actual)
+	}
+
+	if _, err := provider.Subnet("subnet-missing"); err == nil {
+		t.Error("expected an error for a resource missing from the snapshot")
+	}
+}
+
+func TestReadUnsupportedVersion(t *testing.T) {
+	_, err := Read(bytes.NewBufferString(`{"Version": 999, "Resources": {}}`))
+	if err == nil {
+		t.Error("expected an error for an unsupported snapshot version")
+	}
+}
+
+func TestRouteTableDependenciesWithUnattachedTransitGateway(t *testing.T) {
+	_, destination, err := net.ParseCIDR("10.1.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vpc := aws.VPC{ID: "vpc-abc123"}
+	tgw := aws.TransitGateway{ID: "tgw-abc123", State: aws.TransitGatewayStateAvailable}
+	routeTable := aws.RouteTable{
+		ID:    "rtb-abc123",
+		VPCID: vpc.ID,
+		Routes: []aws.RouteTableRoute{
+			{
+				Destination: destination,
+				Target:      aws.RouteTableRouteTarget{Type: aws.RouteTableRouteTargetTypeTransitGateway, ID: tgw.ID},
+				State:       aws.RouteTableRouteStateActive,
+			},
+		},
+	}
+
+	rc := reach.NewResourceCollection()
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindVPC, ID: vpc.ID}, vpc.ToResource())
+	rc.Put(tgw.ToResourceReference(), tgw.ToResource())
+	provider := NewResourceProvider(&Snapshot{Version: FormatVersion, Resources: rc})
+
+	dependencies, err := routeTable.Dependencies(provider)
+	if err != nil {
+		t.Fatalf("expected a route to a transit gateway that the VPC isn't attached to to be valid, but got error: %v", err)
+	}
+
+	if attachments := dependencies.GetAll(aws.ResourceDomainAWS, aws.ResourceKindTransitGatewayAttachment); len(attachments) != 0 {
+		t.Errorf("expected no transit gateway attachments, but got %d", len(attachments))
+	}
+}
+
+func TestSecurityGroupReferenceInAnotherAccount(t *testing.T) {
+	provider := NewResourceProvider(&Snapshot{Version: FormatVersion, Resources: reach.NewResourceCollection()})
+
+	sgRef, err := provider.SecurityGroupReference("sg-peer", "222222222222")
+	if err != nil {
+		t.Fatalf("expected a reference to a group in another account to be returned even though it's missing from the snapshot, but got error: %v", err)
+	}
+	if expected, actual := "222222222222", sgRef.AccountID; expected != actual {
+		reach.DiffErrorf(t, "security group reference account ID", expected, actual)
+	}
+
+	if _, err := provider.SecurityGroupReference("sg-missing", ""); err == nil {
+		t.Error("expected an error for a security group reference missing from the snapshot")
+	}
+}
+
+func TestNewRoundTripWithEndpointsNetworkInterfacesAndServices(t *testing.T) {
+	_, serviceNetwork, err := net.ParseCIDR("52.216.0.0/15")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const ownerID = "111111111111"
+
+	vpc := aws.VPC{ID: "vpc-abc123"}
+	subnet := aws.Subnet{ID: "subnet-abc123", NetworkACLID: "acl-abc123", RouteTableID: "rtb-abc123", VPCID: vpc.ID}
+	networkACL := aws.NetworkACL{ID: "acl-abc123"}
+	routeTable := aws.RouteTable{ID: "rtb-abc123", VPCID: vpc.ID}
+	sg := aws.SecurityGroup{
+		ID:      "sg-abc123",
+		VPCID:   vpc.ID,
+		OwnerID: ownerID,
+	}
+	standaloneENI := aws.ElasticNetworkInterface{
+		ID:                   "eni-standalone",
+		OwnerID:              ownerID,
+		SubnetID:             subnet.ID,
+		VPCID:                vpc.ID,
+		SecurityGroupIDs:     []string{sg.ID},
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.0.10")},
+	}
+	endpointENI := aws.ElasticNetworkInterface{
+		ID:                   "eni-endpoint",
+		OwnerID:              ownerID,
+		SubnetID:             subnet.ID,
+		VPCID:                vpc.ID,
+		SecurityGroupIDs:     []string{sg.ID},
+		PrivateIPv4Addresses: []net.IP{net.ParseIP("10.0.0.11")},
+	}
+	interfaceEndpoint := aws.VPCEndpoint{
+		ID:                         "vpce-interface",
+		Type:                       aws.VPCEndpointTypeInterface,
+		ServiceName:                "com.amazonaws.us-east-1.sqs",
+		State:                      "available",
+		VPCID:                      vpc.ID,
+		SubnetIDs:                  []string{subnet.ID},
+		SecurityGroupIDs:           []string{sg.ID},
+		ElasticNetworkInterfaceIDs: []string{endpointENI.ID},
+	}
+	s3 := aws.AWSService{Name: "com.amazonaws.us-east-1.s3", PrefixListID: "pl-s3", IPNetworks: []*net.IPNet{serviceNetwork}}
+	dynamoDB := aws.AWSService{Name: "com.amazonaws.us-east-1.dynamodb", PrefixListID: "pl-dynamodb"}
+	gatewayEndpoint := aws.VPCEndpoint{
+		ID:            "vpce-gateway",
+		Type:          aws.VPCEndpointTypeGateway,
+		ServiceName:   s3.Name,
+		State:         "available",
+		VPCID:         vpc.ID,
+		RouteTableIDs: []string{routeTable.ID},
+	}
+
+	rc := reach.NewResourceCollection()
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindVPC, ID: vpc.ID}, vpc.ToResource())
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindSubnet, ID: subnet.ID}, subnet.ToResource())
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindNetworkACL, ID: networkACL.ID}, networkACL.ToResource())
+	rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+	rc.Put(reach.ResourceReference{Domain: aws.ResourceDomainAWS, Kind: aws.ResourceKindSecurityGroup, ID: sg.ID}, sg.ToResource())
+	rc.Put(standaloneENI.ToResourceReference(), standaloneENI.ToResource())
+	rc.Put(endpointENI.ToResourceReference(), endpointENI.ToResource())
+	rc.Put(interfaceEndpoint.ToResourceReference(), interfaceEndpoint.ToResource())
+	rc.Put(gatewayEndpoint.ToResourceReference(), gatewayEndpoint.ToResource())
+	rc.Put(s3.ToResourceReference(), s3.ToResource())
+	rc.Put(dynamoDB.ToResourceReference(), dynamoDB.ToResource())
+
+	created, err := New(NewResourceProvider(&Snapshot{Version: FormatVersion, Resources: rc}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := created.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider := NewResourceProvider(loaded)
+
+	networkInterfaces, err := provider.AllElasticNetworkInterfaces()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(networkInterfaces) != 2 {
+		t.Errorf("expected 2 network interfaces, but got %d", len(networkInterfaces))
+	}
+
+	endpoints, err := provider.AllVPCEndpoints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoints) != 2 {
+		t.Errorf("expected 2 VPC endpoints, but got %d", len(endpoints))
+	}
+
+	if _, err := provider.ElasticNetworkInterface(endpointENI.ID); err != nil {
+		t.Errorf("expected the interface endpoint's network interface to be saved as a dependency, but got error: %v", err)
+	}
+
+	services, err := provider.AllAWSServices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 2 {
+		t.Errorf("expected 2 AWS services, but got %d", len(services))
+	}
+
+	loadedS3, err := provider.AWSService(s3.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, actual := serviceNetwork.String(), loadedS3.IPNetworks[0].String(); expected != actual {
+		reach.DiffErrorf(t, "AWS service network", expected, actual)
+	}
+}
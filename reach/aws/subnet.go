@@ -9,6 +9,7 @@ const ResourceKindSubnet = "Subnet"
 type Subnet struct {
 	ID           string
 	NetworkACLID string
+	RouteTableID string
 	VPCID        string
 }
 
@@ -34,6 +35,22 @@ func (s Subnet) Dependencies(provider ResourceProvider) (*reach.ResourceCollecti
 		ID:     s.NetworkACLID,
 	}, networkACL.ToResource())
 
+	routeTable, err := provider.RouteTable(s.RouteTableID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindRouteTable,
+		ID:     routeTable.ID,
+	}, routeTable.ToResource())
+
+	routeTableDependencies, err := routeTable.Dependencies(provider)
+	if err != nil {
+		return nil, err
+	}
+	rc.Merge(routeTableDependencies)
+
 	vpc, err := provider.VPC(s.VPCID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,139 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindTargetGroup specifies the unique name for the target group kind of resource.
+const ResourceKindTargetGroup = "TargetGroup"
+
+// Target types for target groups supported by Reach.
+const (
+	TargetGroupTargetTypeInstance = "instance"
+	TargetGroupTargetTypeIP       = "ip"
+)
+
+// healthCheckPortTrafficPort is the health check port value that means "use the port on which each target receives traffic".
+const healthCheckPortTrafficPort = "traffic-port"
+
+// A TargetGroup resource representation. The target group's ID is its ARN.
+type TargetGroup struct {
+	ID                  string
+	TargetGroupName     string
+	Protocol            string
+	Port                int64
+	TargetType          string
+	VPCID               string
+	HealthCheckProtocol string
+	HealthCheckPort     string // either a port number or "traffic-port"
+	Targets             []TargetGroupTarget
+}
+
+// A TargetGroupTarget is a target registered with a target group. Its ID is an EC2 instance ID or an IP address, depending on the target group's target type.
+type TargetGroupTarget struct {
+	ID   string
+	Port int64
+}
+
+// ToResource returns the target group converted to a generalized Reach resource.
+func (tg TargetGroup) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindTargetGroup,
+		Properties: tg,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the target group.
+func (tg TargetGroup) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTargetGroup,
+		ID:     tg.ID,
+	}
+}
+
+// Name returns the target group's name.
+func (tg TargetGroup) Name() string {
+	return tg.TargetGroupName
+}
+
+// targetsForNetworkPoint returns the registered targets that correspond to the given network point. An instance target matches the private IP addresses of the instance's primary network interface, since that's where the load balancer sends traffic.
+func (tg TargetGroup) targetsForNetworkPoint(point reach.NetworkPoint, rc *reach.ResourceCollection) []TargetGroupTarget {
+	var matches []TargetGroupTarget
+
+	for _, target := range tg.Targets {
+		switch tg.TargetType {
+		case TargetGroupTargetTypeInstance:
+			if targetsInstancePrimaryAddress(target.ID, point, rc) {
+				matches = append(matches, target)
+			}
+		case TargetGroupTargetTypeIP:
+			if ip := net.ParseIP(target.ID); ip != nil && ip.Equal(point.IPAddress) {
+				matches = append(matches, target)
+			}
+		}
+	}
+
+	return matches
+}
+
+// trafficToTarget returns the traffic that the load balancer sends to the given target: the target group's protocol at the target's port, plus health checks.
+func (tg TargetGroup) trafficToTarget(target TargetGroupTarget) (reach.TrafficContent, error) {
+	port := target.Port
+	if port == 0 {
+		port = tg.Port
+	}
+
+	traffic, err := trafficForLoadBalancerProtocol(tg.Protocol, port)
+	if err != nil {
+		return reach.TrafficContent{}, fmt.Errorf("unable to use target group '%s': %v", tg.Name(), err)
+	}
+
+	healthCheckPort := port
+	if tg.HealthCheckPort != "" && tg.HealthCheckPort != healthCheckPortTrafficPort {
+		healthCheckPort, err = strconv.ParseInt(tg.HealthCheckPort, 10, 64)
+		if err != nil {
+			return reach.TrafficContent{}, fmt.Errorf("unable to use health check port for target group '%s': %v", tg.Name(), err)
+		}
+	}
+
+	healthCheckProtocol := tg.HealthCheckProtocol
+	if healthCheckProtocol == "" {
+		healthCheckProtocol = "TCP"
+	}
+
+	healthCheckTraffic, err := trafficForLoadBalancerProtocol(healthCheckProtocol, healthCheckPort)
+	if err != nil {
+		return reach.TrafficContent{}, fmt.Errorf("unable to use health check for target group '%s': %v", tg.Name(), err)
+	}
+
+	return reach.NewTrafficContentFromMergingMultiple([]reach.TrafficContent{traffic, healthCheckTraffic})
+}
+
+func targetsInstancePrimaryAddress(instanceID string, point reach.NetworkPoint, rc *reach.ResourceCollection) bool {
+	instance, err := GetEC2InstanceFromLineage(point.Lineage, rc)
+	if err != nil || instance.ID != instanceID {
+		return false
+	}
+
+	eni := ElasticNetworkInterfaceFromNetworkPoint(point, rc)
+	if eni == nil {
+		return false
+	}
+
+	for _, attachment := range instance.NetworkInterfaceAttachments {
+		if attachment.DeviceIndex == 0 && attachment.ElasticNetworkInterfaceID == eni.ID {
+			for _, ip := range eni.PrivateIPv4Addresses {
+				if ip.Equal(point.IPAddress) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
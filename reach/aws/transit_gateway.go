@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindTransitGateway specifies the unique name for the transit gateway kind of resource.
+const ResourceKindTransitGateway = "TransitGateway"
+
+// TransitGatewayStateAvailable is the state of a transit gateway that's able to carry network traffic.
+const TransitGatewayStateAvailable = "available"
+
+// A TransitGateway resource representation.
+type TransitGateway struct {
+	ID      string
+	NameTag string `json:"NameTag,omitempty"`
+	OwnerID string
+	State   string
+}
+
+// ToResource returns the transit gateway converted to a generalized Reach resource.
+func (tgw TransitGateway) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindTransitGateway,
+		Properties: tgw,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the transit gateway.
+func (tgw TransitGateway) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGateway,
+		ID:     tgw.ID,
+	}
+}
+
+// IsAvailable returns a boolean indicating whether or not the transit gateway is able to carry network traffic.
+func (tgw TransitGateway) IsAvailable() bool {
+	return tgw.State == TransitGatewayStateAvailable
+}
+
+// Name returns the transit gateway's ID, and, if available, its name tag value.
+func (tgw TransitGateway) Name() string {
+	if name := strings.TrimSpace(tgw.NameTag); name != "" {
+		return fmt.Sprintf("\"%s\" (%s)", name, tgw.ID)
+	}
+	return tgw.ID
+}
@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindTransitGatewayAttachment specifies the unique name for the transit gateway attachment kind of resource.
+const ResourceKindTransitGatewayAttachment = "TransitGatewayAttachment"
+
+// Values used to describe transit gateway attachments.
+const (
+	TransitGatewayAttachmentResourceTypeVPC = "vpc"
+	TransitGatewayAttachmentStateAvailable  = "available"
+)
+
+// A TransitGatewayAttachment resource representation. An attachment connects a resource (such as a VPC) to a transit gateway, and it's associated with the transit gateway route table that decides where network traffic from the attached resource is sent.
+type TransitGatewayAttachment struct {
+	ID                     string
+	TransitGatewayID       string
+	ResourceType           string
+	ResourceID             string
+	ResourceOwnerID        string
+	State                  string
+	AssociatedRouteTableID string `json:"AssociatedRouteTableID,omitempty"`
+}
+
+// ToResource returns the transit gateway attachment converted to a generalized Reach resource.
+func (attachment TransitGatewayAttachment) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindTransitGatewayAttachment,
+		Properties: attachment,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the transit gateway attachment.
+func (attachment TransitGatewayAttachment) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGatewayAttachment,
+		ID:     attachment.ID,
+	}
+}
+
+// IsAvailable returns a boolean indicating whether or not the transit gateway attachment is able to carry network traffic.
+func (attachment TransitGatewayAttachment) IsAvailable() bool {
+	return attachment.State == TransitGatewayAttachmentStateAvailable
+}
+
+// Dependencies returns a collection of the transit gateway attachment's resource dependencies.
+func (attachment TransitGatewayAttachment) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	rc := reach.NewResourceCollection()
+
+	if attachment.AssociatedRouteTableID == "" {
+		return rc, nil
+	}
+
+	routeTable, err := provider.TransitGatewayRouteTable(attachment.AssociatedRouteTableID)
+	if err != nil {
+		return nil, err
+	}
+	rc.Put(reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGatewayRouteTable,
+		ID:     routeTable.ID,
+	}, routeTable.ToResource())
+
+	return rc, nil
+}
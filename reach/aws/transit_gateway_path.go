@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// transitGatewayPath describes how a transit gateway would handle network traffic sent to it from a VPC.
+type transitGatewayPath struct {
+	TransitGateway reach.ResourceReference
+	Attachment     *reach.ResourceReference `json:"Attachment,omitempty"`
+	RouteTable     *reach.ResourceReference `json:"RouteTable,omitempty"`
+	MatchedRoute   *TransitGatewayRoute     `json:"MatchedRoute,omitempty"`
+}
+
+// newTransitGatewayPath follows network traffic from the source VPC into the specified transit gateway, using the transit gateway route table associated with the source VPC's attachment to look for a route to the destination VPC's attachment. The returned boolean indicates whether or not the transit gateway delivers the traffic to the destination VPC.
+func newTransitGatewayPath(transitGatewayID, sourceVPCID, destinationVPCID string, ip net.IP, rc *reach.ResourceCollection) (transitGatewayPath, bool) {
+	path := transitGatewayPath{
+		TransitGateway: reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindTransitGateway,
+			ID:     transitGatewayID,
+		},
+	}
+
+	tgwResource := rc.Get(path.TransitGateway)
+	if tgwResource == nil || !tgwResource.Properties.(TransitGateway).IsAvailable() {
+		return path, false
+	}
+
+	attachment := transitGatewayVPCAttachment(transitGatewayID, sourceVPCID, rc)
+	if attachment == nil {
+		return path, false
+	}
+
+	attachmentRef := attachment.ToResourceReference()
+	path.Attachment = &attachmentRef
+
+	if !attachment.IsAvailable() || attachment.AssociatedRouteTableID == "" {
+		return path, false
+	}
+
+	routeTableRef := reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGatewayRouteTable,
+		ID:     attachment.AssociatedRouteTableID,
+	}
+	path.RouteTable = &routeTableRef
+
+	routeTableResource := rc.Get(routeTableRef)
+	if routeTableResource == nil {
+		return path, false
+	}
+
+	route := routeTableResource.Properties.(TransitGatewayRouteTable).RouteForIP(ip)
+	path.MatchedRoute = route
+
+	if route == nil || !route.IsActive() {
+		return path, false
+	}
+
+	return path, route.sendsToVPC(destinationVPCID)
+}
+
+func transitGatewayVPCAttachment(transitGatewayID, vpcID string, rc *reach.ResourceCollection) *TransitGatewayAttachment {
+	for _, resource := range rc.GetAll(ResourceDomainAWS, ResourceKindTransitGatewayAttachment) {
+		attachment := resource.Properties.(TransitGatewayAttachment)
+
+		if attachment.TransitGatewayID == transitGatewayID &&
+			attachment.ResourceType == TransitGatewayAttachmentResourceTypeVPC &&
+			attachment.ResourceID == vpcID {
+			return &attachment
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package aws
+
+import (
+	"net"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+)
+
+func TestNewTransitGatewayPath(t *testing.T) {
+	mustParseCIDR := func(cidr string) *net.IPNet {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return network
+	}
+
+	const (
+		tgwID        = "tgw-abc123"
+		routeTableID = "tgw-rtb-abc123"
+		sourceVPCID  = "vpc-source"
+		destVPCID    = "vpc-dest"
+	)
+
+	sourceAttachment := TransitGatewayAttachment{
+		ID:                     "tgw-attach-source",
+		TransitGatewayID:       tgwID,
+		ResourceType:           TransitGatewayAttachmentResourceTypeVPC,
+		ResourceID:             sourceVPCID,
+		State:                  TransitGatewayAttachmentStateAvailable,
+		AssociatedRouteTableID: routeTableID,
+	}
+	destAttachment := TransitGatewayAttachment{
+		ID:               "tgw-attach-dest",
+		TransitGatewayID: tgwID,
+		ResourceType:     TransitGatewayAttachmentResourceTypeVPC,
+		ResourceID:       destVPCID,
+		State:            TransitGatewayAttachmentStateAvailable,
+	}
+	unassociatedSourceAttachment := sourceAttachment
+	unassociatedSourceAttachment.AssociatedRouteTableID = ""
+
+	newCollectionWithAttachments := func(tgwState string, routes []TransitGatewayRoute, attachments ...TransitGatewayAttachment) *reach.ResourceCollection {
+		rc := reach.NewResourceCollection()
+
+		tgw := TransitGateway{ID: tgwID, State: tgwState}
+		rc.Put(tgw.ToResourceReference(), tgw.ToResource())
+
+		for _, attachment := range attachments {
+			rc.Put(attachment.ToResourceReference(), attachment.ToResource())
+		}
+
+		routeTable := TransitGatewayRouteTable{ID: routeTableID, TransitGatewayID: tgwID, Routes: routes}
+		rc.Put(routeTable.ToResourceReference(), routeTable.ToResource())
+
+		return rc
+	}
+
+	newCollection := func(tgwState string, routes []TransitGatewayRoute) *reach.ResourceCollection {
+		return newCollectionWithAttachments(tgwState, routes, sourceAttachment, destAttachment)
+	}
+
+	routeToDest := TransitGatewayRoute{
+		Destination: mustParseCIDR("10.1.0.0/16"),
+		Attachments: []TransitGatewayRouteAttachment{
+			{ID: "tgw-attach-dest", ResourceType: TransitGatewayAttachmentResourceTypeVPC, ResourceID: destVPCID},
+		},
+		State: TransitGatewayRouteStateActive,
+	}
+	blackholeRoute := TransitGatewayRoute{
+		Destination: mustParseCIDR("10.1.2.0/24"),
+		State:       "blackhole",
+	}
+
+	routeToOtherVPC := TransitGatewayRoute{
+		Destination: mustParseCIDR("10.1.0.0/16"),
+		Attachments: []TransitGatewayRouteAttachment{
+			{ID: "tgw-attach-other", ResourceType: TransitGatewayAttachmentResourceTypeVPC, ResourceID: "vpc-other"},
+		},
+		State: TransitGatewayRouteStateActive,
+	}
+
+	cases := []struct {
+		name              string
+		rc                *reach.ResourceCollection
+		ip                string
+		expectedDelivered bool
+	}{
+		{
+			name:              "route to destination VPC",
+			rc:                newCollection(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToDest}),
+			ip:                "10.1.2.3",
+			expectedDelivered: true,
+		},
+		{
+			name:              "transit gateway not available",
+			rc:                newCollection("deleting", []TransitGatewayRoute{routeToDest}),
+			ip:                "10.1.2.3",
+			expectedDelivered: false,
+		},
+		{
+			name:              "no matching route",
+			rc:                newCollection(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToDest}),
+			ip:                "10.2.0.1",
+			expectedDelivered: false,
+		},
+		{
+			name:              "source VPC not attached to transit gateway",
+			rc:                newCollectionWithAttachments(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToDest}, destAttachment),
+			ip:                "10.1.2.3",
+			expectedDelivered: false,
+		},
+		{
+			name:              "source attachment not associated with a route table",
+			rc:                newCollectionWithAttachments(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToDest}, unassociatedSourceAttachment, destAttachment),
+			ip:                "10.1.2.3",
+			expectedDelivered: false,
+		},
+		{
+			name:              "route to a different VPC",
+			rc:                newCollection(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToOtherVPC}),
+			ip:                "10.1.2.3",
+			expectedDelivered: false,
+		},
+		{
+			name:              "more specific blackhole route",
+			rc:                newCollection(TransitGatewayStateAvailable, []TransitGatewayRoute{routeToDest, blackholeRoute}),
+			ip:                "10.1.2.3",
+			expectedDelivered: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, delivered := newTransitGatewayPath(tgwID, sourceVPCID, destVPCID, net.ParseIP(tc.ip), tc.rc)
+
+			if delivered != tc.expectedDelivered {
+				reach.DiffErrorf(t, "delivered", tc.expectedDelivered, delivered)
+			}
+		})
+	}
+}
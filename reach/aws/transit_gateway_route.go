@@ -0,0 +1,73 @@
+package aws
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// TransitGatewayRouteStateActive is the state of a transit gateway route that's able to deliver network traffic to its attachments.
+const TransitGatewayRouteStateActive = "active"
+
+// A TransitGatewayRoute resource representation. A route whose destination is a prefix list is represented as one route for each of the prefix list's CIDR blocks, each of which records the prefix list's ID.
+type TransitGatewayRoute struct {
+	Destination             *net.IPNet
+	DestinationPrefixListID string `json:"DestinationPrefixListID,omitempty"`
+	Attachments             []TransitGatewayRouteAttachment
+	Type                    string
+	State                   string
+}
+
+// A TransitGatewayRouteAttachment identifies a transit gateway attachment to which a transit gateway route sends network traffic, along with the resource that's attached.
+type TransitGatewayRouteAttachment struct {
+	ID           string
+	ResourceType string
+	ResourceID   string
+}
+
+// IsActive returns a boolean indicating whether or not the route is able to deliver network traffic.
+func (route TransitGatewayRoute) IsActive() bool {
+	return route.State == TransitGatewayRouteStateActive
+}
+
+// String returns the string representation of the route.
+func (route TransitGatewayRoute) String() string {
+	destination := "[unknown destination]"
+	if route.Destination != nil {
+		destination = route.Destination.String()
+	}
+
+	if route.DestinationPrefixListID != "" {
+		destination = fmt.Sprintf("%s (%s)", route.DestinationPrefixListID, destination)
+	}
+
+	var targets []string
+	for _, attachment := range route.Attachments {
+		targets = append(targets, fmt.Sprintf("%s (%s %s)", attachment.ID, attachment.ResourceType, attachment.ResourceID))
+	}
+
+	if len(targets) == 0 {
+		targets = append(targets, "[no attachments]")
+	}
+
+	return fmt.Sprintf("%s -> %s (%s)", destination, strings.Join(targets, ", "), route.State)
+}
+
+func (route TransitGatewayRoute) prefixLength() int {
+	if route.Destination == nil {
+		return -1
+	}
+
+	ones, _ := route.Destination.Mask.Size()
+	return ones
+}
+
+func (route TransitGatewayRoute) sendsToVPC(vpcID string) bool {
+	for _, attachment := range route.Attachments {
+		if attachment.ResourceType == TransitGatewayAttachmentResourceTypeVPC && attachment.ResourceID == vpcID {
+			return true
+		}
+	}
+
+	return false
+}
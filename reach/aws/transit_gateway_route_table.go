@@ -0,0 +1,53 @@
+package aws
+
+import (
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindTransitGatewayRouteTable specifies the unique name for the transit gateway route table kind of resource.
+const ResourceKindTransitGatewayRouteTable = "TransitGatewayRouteTable"
+
+// A TransitGatewayRouteTable resource representation.
+type TransitGatewayRouteTable struct {
+	ID               string
+	TransitGatewayID string
+	Routes           []TransitGatewayRoute
+}
+
+// ToResource returns the transit gateway route table converted to a generalized Reach resource.
+func (rt TransitGatewayRouteTable) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindTransitGatewayRouteTable,
+		Properties: rt,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the transit gateway route table.
+func (rt TransitGatewayRouteTable) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindTransitGatewayRouteTable,
+		ID:     rt.ID,
+	}
+}
+
+// RouteForIP returns the route that the transit gateway route table would use to send network traffic to the specified IP address, using longest prefix matching. If no route's destination contains the IP address, RouteForIP returns nil.
+func (rt TransitGatewayRouteTable) RouteForIP(ip net.IP) *TransitGatewayRoute {
+	var bestMatch *TransitGatewayRoute
+
+	for i := range rt.Routes {
+		route := rt.Routes[i]
+
+		if route.Destination == nil || !route.Destination.Contains(ip) {
+			continue
+		}
+
+		if bestMatch == nil || route.prefixLength() > bestMatch.prefixLength() {
+			bestMatch = &route
+		}
+	}
+
+	return bestMatch
+}
@@ -1,8 +1,6 @@
 package aws
 
 import (
-	"fmt"
-
 	"github.com/luhring/reach/reach"
 )
 
@@ -21,6 +19,9 @@ func NewVectorAnalyzer(resourceCollection *reach.ResourceCollection) VectorAnaly
 func (analyzer VectorAnalyzer) factorsForPerspective(p reach.Perspective) ([]reach.Factor, error) {
 	var factors []reach.Factor
 
+	// A network point on a load balancer is a network interface that the load balancer manages.
+	lb := LoadBalancerFromLineage(p.Self.Lineage, analyzer.resourceCollection)
+
 	for _, resourceRef := range p.Self.Lineage {
 		if resourceRef.Domain == ResourceDomainAWS {
 			if resourceRef.Kind == ResourceKindEC2Instance {
@@ -29,6 +30,39 @@ func (analyzer VectorAnalyzer) factorsForPerspective(p reach.Perspective) ([]rea
 				factors = append(factors, ec2Instance.newInstanceStateFactor())
 			}
 
+			if resourceRef.Kind == ResourceKindLoadBalancer && lb != nil {
+				var f *reach.Factor
+				var err error
+
+				if p.SelfRole == reach.SubjectRoleSource {
+					f, err = lb.newTargetsFactor(analyzer.resourceCollection, p.Other)
+				} else {
+					f, err = lb.newListenersFactor()
+				}
+				if err != nil {
+					return nil, err
+				}
+
+				factors = append(factors, *f)
+			}
+
+			if resourceRef.Kind == ResourceKindVPCEndpoint {
+				vpce := analyzer.resourceCollection.Get(resourceRef).Properties.(VPCEndpoint)
+
+				factors = append(factors, *vpce.newInterfaceFactor(p.SelfRole))
+			}
+
+			if resourceRef.Kind == ResourceKindRDSInstance {
+				db := analyzer.resourceCollection.Get(resourceRef).Properties.(RDSInstance)
+
+				f, err := db.newEndpointFactor(p.SelfRole)
+				if err != nil {
+					return nil, err
+				}
+
+				factors = append(factors, *f)
+			}
+
 			if resourceRef.Kind == ResourceKindElasticNetworkInterface {
 				// Get ready to evaluate factors
 				eni := analyzer.resourceCollection.Get(resourceRef).Properties.(ElasticNetworkInterface)
@@ -41,30 +75,29 @@ func (analyzer VectorAnalyzer) factorsForPerspective(p reach.Perspective) ([]rea
 					awsP = newPerspectiveDestinationOriented()
 				}
 
-				// Ensure this is scenario that Reach can analyze
-				if !sameVPC(&eni, targetENI) {
-					return nil, fmt.Errorf("error: reach is not yet able to analyze EC2 instances in different VPCs, but that's coming soon! (VPCs: %s, %s)", eni.VPCID, targetENI.VPCID)
-				}
+				// When the other network point is outside of AWS (targetENI is nil), it can only be matched by its IP address.
 
 				// Evaluate factors
-				securityGroupRulesFactor, err := eni.newSecurityGroupRulesFactor(
-					analyzer.resourceCollection,
-					p,
-					awsP,
-					targetENI,
-				)
-				if err != nil {
-					return nil, err
+				if lb == nil || lb.usesSecurityGroups() {
+					securityGroupRulesFactor, err := eni.newSecurityGroupRulesFactor(
+						analyzer.resourceCollection,
+						p,
+						awsP,
+						targetENI,
+					)
+					if err != nil {
+						return nil, err
+					}
+
+					factors = append(factors, *securityGroupRulesFactor)
 				}
 
-				factors = append(factors, *securityGroupRulesFactor)
-
 				if sameSubnet(&eni, targetENI) {
 					// There's nothing further to evaluate for this ENI
 					continue
 				}
 
-				// Different subnets, same VPC
+				// Different subnets, possibly in different VPCs
 
 				networkACLRulesFactor, err := eni.newNetworkACLRulesFactor(
 					analyzer.resourceCollection,
@@ -77,6 +110,41 @@ func (analyzer VectorAnalyzer) factorsForPerspective(p reach.Perspective) ([]rea
 				}
 
 				factors = append(factors, *networkACLRulesFactor)
+
+				routeTablesFactor, err := eni.newRouteTablesFactor(
+					analyzer.resourceCollection,
+					p,
+					awsP,
+					targetENI,
+				)
+				if err != nil {
+					return nil, err
+				}
+
+				factors = append(factors, *routeTablesFactor)
+
+				// A NAT gateway is an extra hop between the source and a destination on the internet.
+				route := matchedRoute(*routeTablesFactor)
+				if p.SelfRole == reach.SubjectRoleSource && route != nil && !route.IsBlackhole() &&
+					route.Target.Type == RouteTableRouteTargetTypeNATGateway && viaInternet(targetENI, p.Other.IPAddress) {
+					natGatewayFactor, err := eni.newNATGatewayFactor(route.Target.ID, analyzer.resourceCollection, p)
+					if err != nil {
+						return nil, err
+					}
+
+					factors = append(factors, *natGatewayFactor)
+				}
+
+				// A gateway VPC endpoint's policy is surfaced alongside the route that uses the endpoint.
+				if route != nil && route.Target.Type == RouteTableRouteTargetTypeVPCEndpoint && targetENI == nil {
+					if resource := analyzer.resourceCollection.Get(reach.ResourceReference{
+						Domain: ResourceDomainAWS,
+						Kind:   ResourceKindVPCEndpoint,
+						ID:     route.Target.ID,
+					}); resource != nil {
+						factors = append(factors, *resource.Properties.(VPCEndpoint).newGatewayFactor())
+					}
+				}
 			}
 		}
 	}
@@ -94,6 +162,9 @@ func (analyzer VectorAnalyzer) Factors(v reach.NetworkVector) ([]reach.Factor, r
 		return nil, reach.NetworkVector{}, err
 	}
 
+	// The destination sees the source IP address after any translation along the way.
+	v.TranslatedSourceIPAddress = translatedSourceIPAddress(sourceFactors)
+
 	destinationPerspective := v.DestinationPerspective()
 	destinationFactors, err := analyzer.factorsForPerspective(destinationPerspective)
 	if err != nil {
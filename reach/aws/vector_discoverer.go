@@ -1,6 +1,11 @@
 package aws
 
-import "github.com/luhring/reach/reach"
+import (
+	"fmt"
+	"net"
+
+	"github.com/luhring/reach/reach"
+)
 
 // VectorDiscoverer is the AWS-specific implementation of the VectorDiscoverer interface.
 type VectorDiscoverer struct {
@@ -20,43 +25,65 @@ func (d VectorDiscoverer) Discover(subjects []*reach.Subject) ([]reach.NetworkVe
 
 	var sourceNetworkPoints []reach.NetworkPoint
 	var destinationNetworkPoints []reach.NetworkPoint
+	var networkSubjects []*reach.Subject
 
 	for _, subject := range subjects {
+		if subject.Domain != ResourceDomainAWS || subject.Role == reach.SubjectRoleNone {
+			continue
+		}
+
+		if subject.Kind == SubjectKindIPNetwork || subject.Kind == SubjectKindInternet || subject.Kind == SubjectKindAWSService {
+			// The ranges of a network (or of the internet, or of an AWS service) depend on the network points on the other side, so they're determined last.
+			networkSubjects = append(networkSubjects, subject)
+			continue
+		}
+
+		points, err := d.networkPoints(subject)
+		if err != nil {
+			return nil, err
+		}
+
 		if subject.Role == reach.SubjectRoleSource {
-			switch subject.Domain {
-			case ResourceDomainAWS:
-				switch subject.Kind {
-				case SubjectKindEC2Instance:
-					ec2Instance := d.resourceCollection.Get(reach.ResourceReference{
-						Domain: ResourceDomainAWS,
-						Kind:   ResourceKindEC2Instance,
-						ID:     subject.ID,
-					}).Properties.(EC2Instance)
-
-					sourceNetworkPoints = append(sourceNetworkPoints, ec2Instance.networkPoints(d.resourceCollection)...)
-				}
+			sourceNetworkPoints = append(sourceNetworkPoints, points...)
+		} else if subject.Role == reach.SubjectRoleDestination {
+			destinationNetworkPoints = append(destinationNetworkPoints, points...)
+		}
+	}
+
+	var networkSourcePoints []reach.NetworkPoint
+	var networkDestinationPoints []reach.NetworkPoint
+
+	for _, subject := range networkSubjects {
+		if subject.Role == reach.SubjectRoleSource {
+			points, err := d.ipRangeNetworkPoints(subject, destinationNetworkPoints)
+			if err != nil {
+				return nil, err
 			}
+			networkSourcePoints = append(networkSourcePoints, points...)
 		} else if subject.Role == reach.SubjectRoleDestination {
-			switch subject.Domain {
-			case ResourceDomainAWS:
-				switch subject.Kind {
-				case SubjectKindEC2Instance:
-					ec2Instance := d.resourceCollection.Get(reach.ResourceReference{
-						Domain: ResourceDomainAWS,
-						Kind:   ResourceKindEC2Instance,
-						ID:     subject.ID,
-					}).Properties.(EC2Instance)
-
-					destinationNetworkPoints = append(destinationNetworkPoints, ec2Instance.networkPoints(d.resourceCollection)...)
-				}
+			points, err := d.ipRangeNetworkPoints(subject, sourceNetworkPoints)
+			if err != nil {
+				return nil, err
 			}
+			networkDestinationPoints = append(networkDestinationPoints, points...)
 		}
 	}
 
+	sourceNetworkPoints = append(sourceNetworkPoints, networkSourcePoints...)
+	destinationNetworkPoints = append(destinationNetworkPoints, networkDestinationPoints...)
+
 	var networkVectors []reach.NetworkVector
 
 	for _, source := range sourceNetworkPoints {
 		for _, destination := range destinationNetworkPoints {
+			if !IsUsedByNetworkPoint(source) && !IsUsedByNetworkPoint(destination) {
+				return nil, fmt.Errorf("unable to analyze traffic from %s to %s: at least one of the source and destination must be in AWS", source.AddressString(), destination.AddressString())
+			}
+
+			if !d.pairable(source, destination, sourceNetworkPoints) || !d.pairable(destination, source, destinationNetworkPoints) {
+				continue
+			}
+
 			vector, err := reach.NewNetworkVector(source, destination)
 			if err != nil {
 				return nil, err
@@ -68,3 +95,138 @@ func (d VectorDiscoverer) Discover(subjects []*reach.Subject) ([]reach.NetworkVe
 
 	return networkVectors, nil
 }
+
+// ipRangeNetworkPoints returns a network point for each range of the IP network (or internet, or AWS service) subject that has a uniform analysis result with respect to the other network points.
+func (d VectorDiscoverer) ipRangeNetworkPoints(subject *reach.Subject, others []reach.NetworkPoint) ([]reach.NetworkPoint, error) {
+	boundaries := ipNetworkBoundaries(others, d.resourceCollection)
+
+	var ranges []reach.IPRange
+	switch subject.Kind {
+	case SubjectKindInternet:
+		ranges = internetRanges(boundaries)
+	case SubjectKindAWSService:
+		resource := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindAWSService,
+			ID:     subject.ID,
+		})
+		if resource == nil {
+			return nil, fmt.Errorf("couldn't find AWS service: %s", subject.ID)
+		}
+
+		for _, network := range resource.Properties.(AWSService).IPNetworks {
+			ranges = append(ranges, splitIPNetwork(network, boundaries)...)
+		}
+	default:
+		_, network, err := net.ParseCIDR(subject.ID)
+		if err != nil {
+			return nil, err
+		}
+		ranges = splitIPNetwork(network, boundaries)
+	}
+
+	var points []reach.NetworkPoint
+
+	for _, ipRange := range ranges {
+		ipRange := ipRange
+		points = append(points, reach.NetworkPoint{
+			IPAddress: ipRange.First,
+			Range:     &ipRange,
+		})
+	}
+
+	return points, nil
+}
+
+// pairable returns a boolean indicating whether or not the network point should be paired with the other network point to form a network vector. Traffic between a network interface and an address outside of AWS only uses the IP version of the outside address. And since analysis only depends on the outside address, a network interface's public IPv4 address isn't paired with an outside address when the same side already includes the interface's private IPv4 address, which would have the same result.
+func (d VectorDiscoverer) pairable(point, other reach.NetworkPoint, side []reach.NetworkPoint) bool {
+	if IsUsedByNetworkPoint(other) {
+		return true
+	}
+
+	if (point.IPAddress.To4() == nil) != (other.IPAddress.To4() == nil) {
+		return false
+	}
+
+	eni := ElasticNetworkInterfaceFromNetworkPoint(point, d.resourceCollection)
+	if eni == nil || eni.PublicIPv4Address == nil || !eni.PublicIPv4Address.Equal(point.IPAddress) {
+		return true
+	}
+
+	for _, sidePoint := range side {
+		sideENI := ElasticNetworkInterfaceFromNetworkPoint(sidePoint, d.resourceCollection)
+		if sideENI != nil && sideENI.ID == eni.ID && !sidePoint.IPAddress.Equal(eni.PublicIPv4Address) && sidePoint.IPAddress.To4() != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (d VectorDiscoverer) networkPoints(subject *reach.Subject) ([]reach.NetworkPoint, error) {
+	switch subject.Kind {
+	case SubjectKindEC2Instance:
+		ec2Instance := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindEC2Instance,
+			ID:     subject.ID,
+		}).Properties.(EC2Instance)
+
+		return ec2Instance.networkPoints(d.resourceCollection), nil
+	case SubjectKindLoadBalancer:
+		lb := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindLoadBalancer,
+			ID:     subject.ID,
+		}).Properties.(LoadBalancer)
+
+		return lb.networkPoints(d.resourceCollection), nil
+	case SubjectKindRDSInstance:
+		db := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindRDSInstance,
+			ID:     subject.ID,
+		}).Properties.(RDSInstance)
+
+		return db.networkPoints(d.resourceCollection), nil
+	case SubjectKindRDSCluster:
+		cluster := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindRDSCluster,
+			ID:     subject.ID,
+		}).Properties.(RDSCluster)
+
+		return cluster.networkPoints(d.resourceCollection), nil
+	case SubjectKindVPCEndpoint:
+		vpce := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindVPCEndpoint,
+			ID:     subject.ID,
+		}).Properties.(VPCEndpoint)
+
+		return vpce.networkPoints(d.resourceCollection), nil
+	case SubjectKindElasticNetworkInterface:
+		eni := d.resourceCollection.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindElasticNetworkInterface,
+			ID:     subject.ID,
+		}).Properties.(ElasticNetworkInterface)
+
+		return eni.networkPoints(), nil
+	case SubjectKindIPAddress:
+		ip := net.ParseIP(subject.ID)
+
+		for _, resource := range d.resourceCollection.GetAll(ResourceDomainAWS, ResourceKindElasticNetworkInterface) {
+			eni := resource.Properties.(ElasticNetworkInterface)
+
+			if eni.HasIPAddress(ip) {
+				return []reach.NetworkPoint{eni.ipAddressNetworkPoint(ip)}, nil
+			}
+		}
+
+		// The IP address is outside of AWS.
+		return []reach.NetworkPoint{{IPAddress: ip}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported subject kind: '%s'", subject.Kind)
+	}
+}
@@ -0,0 +1,131 @@
+package aws
+
+import (
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindVPCEndpoint specifies the unique name for the VPC endpoint kind of resource.
+const ResourceKindVPCEndpoint = "VPCEndpoint"
+
+// Types of VPC endpoints.
+const (
+	VPCEndpointTypeInterface           = "Interface"
+	VPCEndpointTypeGateway             = "Gateway"
+	VPCEndpointTypeGatewayLoadBalancer = "GatewayLoadBalancer"
+)
+
+// A VPCEndpoint resource representation. An interface endpoint uses network interfaces in the VPC's subnets, which are protected by the endpoint's security groups. A gateway endpoint (for S3 or DynamoDB) has no network interfaces; instead, route tables send network traffic for the service's prefix list to the endpoint.
+type VPCEndpoint struct {
+	ID                         string
+	NameTag                    string `json:"NameTag,omitempty"`
+	Type                       string
+	ServiceName                string // e.g. "com.amazonaws.us-east-1.s3"
+	State                      string
+	VPCID                      string
+	SubnetIDs                  []string `json:"SubnetIDs,omitempty"`
+	SecurityGroupIDs           []string `json:"SecurityGroupIDs,omitempty"`
+	ElasticNetworkInterfaceIDs []string `json:"ElasticNetworkInterfaceIDs,omitempty"`
+	RouteTableIDs              []string `json:"RouteTableIDs,omitempty"`
+	PolicyDocument             string   `json:"PolicyDocument,omitempty"`
+}
+
+// ToResource returns the VPC endpoint converted to a generalized Reach resource.
+func (vpce VPCEndpoint) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindVPCEndpoint,
+		Properties: vpce,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the VPC endpoint.
+func (vpce VPCEndpoint) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindVPCEndpoint,
+		ID:     vpce.ID,
+	}
+}
+
+// Dependencies returns a collection of the VPC endpoint's resource dependencies. For an interface endpoint, these are its network interfaces (retrieved concurrently). For a gateway endpoint, this is the AWS service that the endpoint provides access to.
+func (vpce VPCEndpoint) Dependencies(provider ResourceProvider) (*reach.ResourceCollection, error) {
+	var lookups []dependencyLookup
+
+	switch vpce.Type {
+	case VPCEndpointTypeInterface:
+		for _, id := range vpce.ElasticNetworkInterfaceIDs {
+			id := id
+			lookups = append(lookups, func() (*reach.ResourceCollection, error) {
+				eni, err := provider.ElasticNetworkInterface(id)
+				if err != nil {
+					return nil, err
+				}
+
+				rc, err := eni.Dependencies(provider)
+				if err != nil {
+					return nil, err
+				}
+				rc.Put(eni.ToResourceReference(), eni.ToResource())
+
+				return rc, nil
+			})
+		}
+	case VPCEndpointTypeGateway:
+		lookups = append(lookups, func() (*reach.ResourceCollection, error) {
+			service, err := provider.AWSService(vpce.ServiceName)
+			if err != nil {
+				return nil, err
+			}
+
+			rc := reach.NewResourceCollection()
+			rc.Put(service.ToResourceReference(), service.ToResource())
+
+			return rc, nil
+		})
+	}
+
+	return resolveDependencies(lookups...)
+}
+
+// IsAvailable returns a boolean indicating whether or not the VPC endpoint is able to carry network traffic.
+func (vpce VPCEndpoint) IsAvailable() bool {
+	return strings.EqualFold(vpce.State, "available")
+}
+
+// Name returns the VPC endpoint's ID, and, if available, its name tag value.
+func (vpce VPCEndpoint) Name() string {
+	return gatewayName(vpce.ID, vpce.NameTag)
+}
+
+func (vpce VPCEndpoint) networkPoints(rc *reach.ResourceCollection) []reach.NetworkPoint {
+	var points []reach.NetworkPoint
+
+	for _, id := range vpce.ElasticNetworkInterfaceIDs {
+		eni := rc.Get(reach.ResourceReference{
+			Domain: ResourceDomainAWS,
+			Kind:   ResourceKindElasticNetworkInterface,
+			ID:     id,
+		}).Properties.(ElasticNetworkInterface)
+		points = append(points, eni.getNetworkPoints(vpce.ToResourceReference())...)
+	}
+
+	return points
+}
+
+// VPCEndpointFromLineage returns the VPC endpoint from the given lineage, or nil if the lineage doesn't contain a VPC endpoint.
+func VPCEndpointFromLineage(lineage []reach.ResourceReference, rc *reach.ResourceCollection) *VPCEndpoint {
+	for _, ref := range lineage {
+		if ref.Domain == ResourceDomainAWS && ref.Kind == ResourceKindVPCEndpoint {
+			resource := rc.Get(ref)
+			if resource == nil {
+				return nil
+			}
+
+			vpce := resource.Properties.(VPCEndpoint)
+			return &vpce
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"strconv"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+// FactorKindVPCEndpoint specifies the unique name for the VPC endpoint kind of factor.
+const FactorKindVPCEndpoint = "VPCEndpoint"
+
+type vpcEndpointFactor struct {
+	Type           string
+	ServiceName    string
+	State          string
+	Available      bool
+	PolicyDocument string `json:"PolicyDocument,omitempty"`
+}
+
+// FactorDetails describes the VPC endpoint's type, service and state. The endpoint policy is included as an attribute, if the endpoint has one.
+func (f vpcEndpointFactor) FactorDetails() reach.FactorDetails {
+	details := reach.FactorDetails{
+		Attributes: map[string]string{
+			"type":        f.Type,
+			"serviceName": f.ServiceName,
+			"state":       f.State,
+			"available":   strconv.FormatBool(f.Available),
+		},
+	}
+
+	if f.PolicyDocument != "" {
+		details.Attributes["policyDocument"] = f.PolicyDocument
+	}
+
+	return details
+}
+
+// newInterfaceFactor describes the traffic allowed by an interface endpoint's state. An interface endpoint only accepts TCP connections, and never initiates connections of its own. The endpoint policy is included for reference only: AWS evaluates it when authorizing each request to the service, so it doesn't affect network traffic.
+func (vpce VPCEndpoint) newInterfaceFactor(role reach.SubjectRole) *reach.Factor {
+	traffic := reach.NewTrafficContentForNoTraffic()
+	returnTraffic := reach.NewTrafficContentForNoTraffic()
+
+	if vpce.IsAvailable() && role == reach.SubjectRoleDestination {
+		traffic = reach.NewTrafficContentForPorts(reach.ProtocolTCP, set.NewFullPortSet())
+		returnTraffic = reach.NewTrafficContentForAllTraffic()
+	}
+
+	return vpce.newFactor(traffic, returnTraffic)
+}
+
+// newGatewayFactor describes a gateway endpoint that a route sends network traffic to. Whether the endpoint can carry the traffic is already decided by the route tables factor, so this factor doesn't restrict any traffic; it surfaces the endpoint's policy for reference.
+func (vpce VPCEndpoint) newGatewayFactor() *reach.Factor {
+	return vpce.newFactor(reach.NewTrafficContentForAllTraffic(), reach.NewTrafficContentForAllTraffic())
+}
+
+func (vpce VPCEndpoint) newFactor(traffic, returnTraffic reach.TrafficContent) *reach.Factor {
+	return &reach.Factor{
+		Kind:          FactorKindVPCEndpoint,
+		Resource:      vpce.ToResourceReference(),
+		Traffic:       traffic,
+		ReturnTraffic: returnTraffic,
+		Properties: vpcEndpointFactor{
+			Type:           vpce.Type,
+			ServiceName:    vpce.ServiceName,
+			State:          vpce.State,
+			Available:      vpce.IsAvailable(),
+			PolicyDocument: vpce.PolicyDocument,
+		},
+	}
+}
@@ -0,0 +1,74 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestVPCEndpointInterfaceFactor(t *testing.T) {
+	all := reach.NewTrafficContentForAllTraffic()
+	none := reach.NewTrafficContentForNoTraffic()
+	tcp := reach.NewTrafficContentForPorts(reach.ProtocolTCP, set.NewFullPortSet())
+
+	cases := []struct {
+		name                  string
+		state                 string
+		role                  reach.SubjectRole
+		expectedTraffic       reach.TrafficContent
+		expectedReturnTraffic reach.TrafficContent
+	}{
+		{"available endpoint as destination", "available", reach.SubjectRoleDestination, tcp, all},
+		{"available endpoint as source", "available", reach.SubjectRoleSource, none, none},
+		{"pending endpoint as destination", "pendingAcceptance", reach.SubjectRoleDestination, none, none},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vpce := VPCEndpoint{
+				ID:          "vpce-abc123",
+				Type:        VPCEndpointTypeInterface,
+				ServiceName: "com.amazonaws.us-east-1.ssm",
+				State:       tc.state,
+			}
+
+			factor := vpce.newInterfaceFactor(tc.role)
+
+			if factor.Traffic.String() != tc.expectedTraffic.String() {
+				reach.DiffErrorf(t, "traffic", tc.expectedTraffic, factor.Traffic)
+			}
+
+			if factor.ReturnTraffic.String() != tc.expectedReturnTraffic.String() {
+				reach.DiffErrorf(t, "return traffic", tc.expectedReturnTraffic, factor.ReturnTraffic)
+			}
+		})
+	}
+}
+
+func TestAWSServiceName(t *testing.T) {
+	cases := []struct {
+		identifier   string
+		expectedName string
+		expectedOK   bool
+	}{
+		{"s3:us-east-1", "com.amazonaws.us-east-1.s3", true},
+		{"dynamodb:eu-west-2", "com.amazonaws.eu-west-2.dynamodb", true},
+		{"s3", "", false},
+		{"i-0abc123", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.identifier, func(t *testing.T) {
+			name, ok := AWSServiceName(tc.identifier)
+
+			if ok != tc.expectedOK {
+				t.Fatalf("expected ok to be %v, but got %v", tc.expectedOK, ok)
+			}
+
+			if name != tc.expectedName {
+				reach.DiffErrorf(t, "service name", tc.expectedName, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// SubjectKindVPCEndpoint specifies the unique name for the VPC endpoint kind of subject. Only interface endpoints can be subjects, since gateway endpoints don't have network interfaces.
+const SubjectKindVPCEndpoint = "VPCEndpoint"
+
+// SubjectKindAWSService specifies the unique name for the AWS service kind of subject. The service is analyzed as the IP address ranges of its prefix list, in the same way as an IP network subject.
+const SubjectKindAWSService = "AWSService"
+
+// NewVPCEndpointSubject returns a new subject for the specified VPC endpoint (identified by its ID, e.g. "vpce-0abc123").
+func NewVPCEndpointSubject(id string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	if !isVPCEndpointID(id) {
+		return nil, reach.NewSubjectError(fmt.Sprintf("invalid VPC endpoint ID: '%s'", id))
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindVPCEndpoint,
+		ID:     id,
+		Role:   role,
+	}, nil
+}
+
+// NewAWSServiceSubject returns a new subject for the specified AWS service (identified by its service name, e.g. "com.amazonaws.us-east-1.s3").
+func NewAWSServiceSubject(serviceName string, role reach.SubjectRole) (*reach.Subject, error) {
+	if !reach.ValidSubjectRole(role) {
+		return nil, reach.NewSubjectError(reach.ErrSubjectRoleValidation)
+	}
+
+	if len(serviceName) < 1 {
+		return nil, reach.NewSubjectError(reach.ErrSubjectIDValidation)
+	}
+
+	return &reach.Subject{
+		Domain: ResourceDomainAWS,
+		Kind:   SubjectKindAWSService,
+		ID:     serviceName,
+		Role:   role,
+	}, nil
+}
+
+func isVPCEndpointID(identifier string) bool {
+	return strings.HasPrefix(identifier, "vpce-") && len(identifier) > len("vpce-")
+}
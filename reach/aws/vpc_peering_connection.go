@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// ResourceKindVPCPeeringConnection specifies the unique name for the VPC peering connection kind of resource.
+const ResourceKindVPCPeeringConnection = "VPCPeeringConnection"
+
+// VPCPeeringConnectionStatusActive is the status of a VPC peering connection that's able to carry network traffic.
+const VPCPeeringConnectionStatusActive = "active"
+
+// A VPCPeeringConnection resource representation.
+type VPCPeeringConnection struct {
+	ID               string
+	NameTag          string `json:"NameTag,omitempty"`
+	Status           string
+	RequesterVPCID   string
+	RequesterOwnerID string
+	RequesterRegion  string
+	AccepterVPCID    string
+	AccepterOwnerID  string
+	AccepterRegion   string
+}
+
+// ToResource returns the VPC peering connection converted to a generalized Reach resource.
+func (pcx VPCPeeringConnection) ToResource() reach.Resource {
+	return reach.Resource{
+		Kind:       ResourceKindVPCPeeringConnection,
+		Properties: pcx,
+	}
+}
+
+// ToResourceReference returns a resource reference to uniquely identify the VPC peering connection.
+func (pcx VPCPeeringConnection) ToResourceReference() reach.ResourceReference {
+	return reach.ResourceReference{
+		Domain: ResourceDomainAWS,
+		Kind:   ResourceKindVPCPeeringConnection,
+		ID:     pcx.ID,
+	}
+}
+
+// IsActive returns a boolean indicating whether or not the VPC peering connection has been accepted and is able to carry network traffic.
+func (pcx VPCPeeringConnection) IsActive() bool {
+	return pcx.Status == VPCPeeringConnectionStatusActive
+}
+
+// Connects returns a boolean indicating whether or not the VPC peering connection is between the two specified VPCs (in either direction).
+func (pcx VPCPeeringConnection) Connects(firstVPCID, secondVPCID string) bool {
+	return (pcx.RequesterVPCID == firstVPCID && pcx.AccepterVPCID == secondVPCID) ||
+		(pcx.RequesterVPCID == secondVPCID && pcx.AccepterVPCID == firstVPCID)
+}
+
+// Name returns the VPC peering connection's ID, and, if available, its name tag value.
+func (pcx VPCPeeringConnection) Name() string {
+	if name := strings.TrimSpace(pcx.NameTag); name != "" {
+		return fmt.Sprintf("\"%s\" (%s)", name, pcx.ID)
+	}
+	return pcx.ID
+}
@@ -0,0 +1,40 @@
+package aws
+
+import "testing"
+
+func TestVPCPeeringConnection(t *testing.T) {
+	cases := []struct {
+		name              string
+		status            string
+		firstVPCID        string
+		secondVPCID       string
+		expectedActive    bool
+		expectedConnected bool
+	}{
+		{"active, requester to accepter", VPCPeeringConnectionStatusActive, "vpc-requester", "vpc-accepter", true, true},
+		{"active, accepter to requester", VPCPeeringConnectionStatusActive, "vpc-accepter", "vpc-requester", true, true},
+		{"active, different pair of VPCs", VPCPeeringConnectionStatusActive, "vpc-requester", "vpc-other", true, false},
+		{"active, same VPC twice", VPCPeeringConnectionStatusActive, "vpc-requester", "vpc-requester", true, false},
+		{"pending acceptance", "pending-acceptance", "vpc-requester", "vpc-accepter", false, true},
+		{"deleted", "deleted", "vpc-requester", "vpc-accepter", false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pcx := VPCPeeringConnection{
+				ID:             "pcx-abc123",
+				Status:         tc.status,
+				RequesterVPCID: "vpc-requester",
+				AccepterVPCID:  "vpc-accepter",
+			}
+
+			if active := pcx.IsActive(); active != tc.expectedActive {
+				t.Errorf("expected IsActive() to be %t, but got %t", tc.expectedActive, active)
+			}
+
+			if connected := pcx.Connects(tc.firstVPCID, tc.secondVPCID); connected != tc.expectedConnected {
+				t.Errorf("expected Connects(%s, %s) to be %t, but got %t", tc.firstVPCID, tc.secondVPCID, tc.expectedConnected, connected)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package reach
+
+import (
+	"sync"
+)
+
+// ForEachConcurrently calls fn once for each index in [0, n), using at most the given number of goroutines at a time. After every call has returned, it returns the error from the lowest index whose call failed, or nil if every call succeeded.
+func ForEachConcurrently(n, workers int, fn func(i int) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	errs := make([]error, n)
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,8 @@
 package explainer
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/mgutz/ansi"
@@ -25,40 +25,53 @@ func New(analysis reach.Analysis) *Explainer {
 }
 
 // Explain returns a natural language representation of the logic used during an analysis to compute the final result.
-func (ex *Explainer) Explain() string {
+func (ex *Explainer) Explain() (string, error) {
 	var outputItems []string
 	for _, v := range ex.analysis.NetworkVectors {
-		outputItems = append(outputItems, ex.ExplainNetworkVector(v))
+		explanation, err := ex.ExplainNetworkVector(v)
+		if err != nil {
+			return "", err
+		}
+		outputItems = append(outputItems, explanation)
 	}
 
 	output := ""
 	output += strings.Join(outputItems, "\n")
 
-	return output
+	return output, nil
 }
 
 // ExplainNetworkVector returns the part of an analysis explanation that's specific to an individual network vector.
-func (ex *Explainer) ExplainNetworkVector(v reach.NetworkVector) string {
+func (ex *Explainer) ExplainNetworkVector(v reach.NetworkVector) (string, error) {
 	var outputSections []string
 
 	// setting the stage: the source and destination
 	var vectorHeader string
 	vectorHeader += fmt.Sprintf("%s %s\n", helper.Bold("source:"), ex.NetworkPointName(v.Source))
 	vectorHeader += fmt.Sprintf("%s %s\n", helper.Bold("destination:"), ex.NetworkPointName(v.Destination))
+	if v.TranslatedSourceIPAddress != nil {
+		vectorHeader += fmt.Sprintf("%s %s\n", helper.Bold("translated source IP address (as seen by destination):"), v.TranslatedSourceIPAddress)
+	}
 	outputSections = append(outputSections, vectorHeader)
 
 	// explain source
 	sourceHeader := helper.Bold("source factors:")
 	outputSections = append(outputSections, sourceHeader)
 
-	sourceContent := ex.ExplainNetworkPoint(v.Source, v.SourcePerspective())
+	sourceContent, err := ex.ExplainNetworkPoint(v.Source, v.SourcePerspective())
+	if err != nil {
+		return "", err
+	}
 	outputSections = append(outputSections, helper.Indent(sourceContent, 2))
 
 	// explain destination
 	destinationHeader := helper.Bold("destination factors:")
 	outputSections = append(outputSections, destinationHeader)
 
-	destinationContent := ex.ExplainNetworkPoint(v.Destination, v.DestinationPerspective())
+	destinationContent, err := ex.ExplainNetworkPoint(v.Destination, v.DestinationPerspective())
+	if err != nil {
+		return "", err
+	}
 	outputSections = append(outputSections, helper.Indent(destinationContent, 2))
 
 	// final results
@@ -68,50 +81,38 @@ func (ex *Explainer) ExplainNetworkVector(v reach.NetworkVector) string {
 	returnResults := fmt.Sprintf("%s\n%s", helper.Bold("network traffic allowed to return from destination to source:"), v.ReturnTraffic.StringWithSymbols())
 	outputSections = append(outputSections, returnResults)
 
-	return strings.Join(outputSections, "\n")
+	return strings.Join(outputSections, "\n"), nil
 }
 
-// ExplainCapabilityChecks returns a report on whether or not Reach's capabilities are sufficient to handle the requested analysis.
-func (ex *Explainer) ExplainCapabilityChecks(v reach.NetworkVector) string {
+// ExplainCapabilityChecks returns a report on whether or not Reach's capabilities are sufficient to handle the requested analysis, or an error if they aren't.
+func (ex *Explainer) ExplainCapabilityChecks(v reach.NetworkVector) (string, error) {
 	var outputItems []string
-	var checksItems []string
 
 	checksHeader := helper.Bold("analysis capability checks:")
 	outputItems = append(outputItems, checksHeader)
 
 	awsEx := aws.NewExplainer(ex.analysis)
 
-	if awsEx.CheckBothInAWS(v) {
-		checksItems = append(checksItems, "✓ both source and destination are in AWS")
-	} else {
-		log.Fatal("source and/or destination is not in AWS, and this is not yet supported")
-	}
-
-	if awsEx.CheckBothInSameVPC(v) {
-		checksItems = append(checksItems, "✓ both source and destination are in same VPC")
-	} else {
-		log.Fatal("source and/or destination are not in same VPC, and this is not yet supported")
-	}
-
-	if awsEx.CheckBothInSameSubnet(v) {
-		checksItems = append(checksItems, "✓ both source and destination are in same subnet")
-	} else {
-		log.Fatal("source and/or destination are not in same subnet, and this is not yet supported")
+	if !awsEx.CheckBothInAWS(v) {
+		return "", errors.New("source and/or destination is not in AWS, and this is not yet supported")
 	}
+	outputItems = append(outputItems, "✓ both source and destination are in AWS")
 
-	outputItems = append(outputItems, checksItems...)
-
-	return strings.Join(outputItems, "\n")
+	return strings.Join(outputItems, "\n"), nil
 }
 
 // ExplainNetworkPoint returns the part of an analysis explanation that's specific to an individual network point (within a network vector).
-func (ex *Explainer) ExplainNetworkPoint(point reach.NetworkPoint, p reach.Perspective) string {
+func (ex *Explainer) ExplainNetworkPoint(point reach.NetworkPoint, p reach.Perspective) (string, error) {
 	if aws.IsUsedByNetworkPoint(point) {
 		awsEx := aws.NewExplainer(ex.analysis)
 		return awsEx.NetworkPoint(point, p)
 	}
 
-	return fmt.Sprintf("unable to explain analysis for network point with IP address '%s'", point.IPAddress)
+	if len(point.Lineage) == 0 {
+		return fmt.Sprintf("(none: %s is outside of AWS, so it's only matched by its IP address)", point.AddressString()), nil
+	}
+
+	return fmt.Sprintf("unable to explain analysis for network point with IP address '%s'", point.IPAddress), nil
 }
 
 // NetworkPointName returns an understandable string representation of a network point.
@@ -119,14 +120,23 @@ func (ex *Explainer) NetworkPointName(point reach.NetworkPoint) string {
 	// ignoring errors because it's okay if we can't find a particular kind of AWS resource in the lineage
 	eni, _ := aws.GetENIFromLineage(point.Lineage, ex.analysis.Resources)
 	ec2Instance, _ := aws.GetEC2InstanceFromLineage(point.Lineage, ex.analysis.Resources)
+	lb := aws.LoadBalancerFromLineage(point.Lineage, ex.analysis.Resources)
+	db := aws.RDSInstanceFromLineage(point.Lineage, ex.analysis.Resources)
+	vpce := aws.VPCEndpointFromLineage(point.Lineage, ex.analysis.Resources)
 
-	output := point.IPAddress.String()
+	output := point.AddressString()
 
 	if eni != nil {
 		output = fmt.Sprintf("%s -> %s", eni.Name(), output)
 
 		if ec2Instance != nil {
 			output = fmt.Sprintf("%s -> %s", ec2Instance.Name(), output)
+		} else if lb != nil {
+			output = fmt.Sprintf("%s -> %s", lb.Name(), output)
+		} else if db != nil {
+			output = fmt.Sprintf("%s -> %s", db.Name(), output)
+		} else if vpce != nil {
+			output = fmt.Sprintf("%s -> %s", vpce.Name(), output)
 		}
 	}
 
@@ -0,0 +1,75 @@
+package reach
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"net"
+)
+
+// An IPRange is an inclusive range of IP addresses, where both ends of the range are either IPv4 or IPv6 addresses. A network point with an IPRange stands for every address in the range, since the analysis result is the same for each of them.
+type IPRange struct {
+	First net.IP
+	Last  net.IP
+}
+
+// NewIPRange returns the range of IP addresses from first to last, inclusive.
+func NewIPRange(first, last net.IP) IPRange {
+	return IPRange{
+		First: normalizeIP(first),
+		Last:  normalizeIP(last),
+	}
+}
+
+// String returns the range in CIDR notation if the range is exactly one CIDR block, and as "first-last" otherwise.
+func (r IPRange) String() string {
+	if network := r.cidr(); network != nil {
+		return network.String()
+	}
+
+	return fmt.Sprintf("%s-%s", r.First, r.Last)
+}
+
+// Contains returns a boolean indicating whether or not the range contains the given IP address.
+func (r IPRange) Contains(ip net.IP) bool {
+	first, last, ip := normalizeIP(r.First), normalizeIP(r.Last), normalizeIP(ip)
+	if first == nil || len(first) != len(last) || len(first) != len(ip) {
+		return false
+	}
+
+	return bytes.Compare(first, ip) <= 0 && bytes.Compare(ip, last) <= 0
+}
+
+func (r IPRange) cidr() *net.IPNet {
+	first, last := normalizeIP(r.First), normalizeIP(r.Last)
+	if first == nil || len(first) != len(last) {
+		return nil
+	}
+
+	start := new(big.Int).SetBytes(first)
+	size := new(big.Int).Sub(new(big.Int).SetBytes(last), start)
+	size.Add(size, big.NewInt(1))
+
+	// A CIDR block's size is a power of two, and the block starts at a multiple of its size.
+	hostBits := size.BitLen() - 1
+	powerOfTwo := size.Sign() > 0 && size.TrailingZeroBits() == uint(hostBits)
+	aligned := start.Sign() == 0 || start.TrailingZeroBits() >= uint(hostBits)
+	if !powerOfTwo || !aligned {
+		return nil
+	}
+
+	bits := 8 * len(first)
+	return &net.IPNet{
+		IP:   first,
+		Mask: net.CIDRMask(bits-hostBits, bits),
+	}
+}
+
+// normalizeIP returns the 4-byte form of an IPv4 address, and the 16-byte form of an IPv6 address.
+func normalizeIP(ip net.IP) net.IP {
+	if v4 := ip.To4(); v4 != nil {
+		return v4
+	}
+
+	return ip.To16()
+}
@@ -0,0 +1,137 @@
+package reach
+
+import (
+	"strings"
+	"text/tabwriter"
+)
+
+// matrixSelfCell is shown in place of traffic for a source and destination that are the same subject, which isn't analyzed.
+const matrixSelfCell = "-"
+
+// A Matrix describes the network traffic allowed from each of a set of source subjects to each of a set of destination subjects. Each pairing of a source and a destination is analyzed separately, and the result is stored as one of the matrix's cells.
+type Matrix struct {
+	Sources      []*Subject
+	Destinations []*Subject
+	Cells        []MatrixCell
+	TrafficQuery *TrafficContent `json:"TrafficQuery,omitempty"` // if set, the only traffic considered by the analyses
+}
+
+// A MatrixCell is the result of analyzing the network traffic allowed from one source subject to one destination subject.
+type MatrixCell struct {
+	Source        *Subject
+	Destination   *Subject
+	Traffic       TrafficContent
+	ReturnTraffic TrafficContent
+	Analysis      *Analysis `json:"-"`
+}
+
+// NewMatrix creates a new Matrix for the given sources and destinations that doesn't have any cells yet.
+func NewMatrix(sources, destinations []*Subject) *Matrix {
+	return &Matrix{
+		Sources:      sources,
+		Destinations: destinations,
+	}
+}
+
+// NewMatrixCell creates a new MatrixCell from the analysis of the given source and destination, merging the traffic of all of the analysis's network vectors.
+func NewMatrixCell(source, destination *Subject, analysis *Analysis) (MatrixCell, error) {
+	traffic, err := analysis.MergedTraffic()
+	if err != nil {
+		return MatrixCell{}, err
+	}
+
+	returnTraffic, err := analysis.MergedReturnTraffic()
+	if err != nil {
+		return MatrixCell{}, err
+	}
+
+	return MatrixCell{
+		Source:        source,
+		Destination:   destination,
+		Traffic:       traffic,
+		ReturnTraffic: returnTraffic,
+		Analysis:      analysis,
+	}, nil
+}
+
+// SameSubject returns a boolean indicating whether or not the two subjects refer to the same entity, regardless of role.
+func SameSubject(a, b *Subject) bool {
+	return a.Domain == b.Domain && a.Kind == b.Kind && a.ID == b.ID
+}
+
+// Cell returns the cell for the given source and destination, or nil if the matrix doesn't have a cell for them.
+func (m Matrix) Cell(source, destination *Subject) *MatrixCell {
+	for i := range m.Cells {
+		if SameSubject(m.Cells[i].Source, source) && SameSubject(m.Cells[i].Destination, destination) {
+			return &m.Cells[i]
+		}
+	}
+
+	return nil
+}
+
+// Table returns a text table of the matrix, where each row is a source, each column is a destination, and each cell is a summary of the traffic allowed from the source to the destination.
+func (m Matrix) Table() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 3, ' ', 0)
+
+	for _, row := range m.Rows() {
+		_, _ = w.Write([]byte(strings.Join(row, "\t") + "\n"))
+	}
+
+	_ = w.Flush()
+	return b.String()
+}
+
+// PassesAssertReachable determines if every source can reach every destination (see Analysis.PassesAssertReachable).
+func (m Matrix) PassesAssertReachable() bool {
+	if len(m.Cells) == 0 {
+		return false
+	}
+
+	for _, cell := range m.Cells {
+		if !cell.Analysis.PassesAssertReachable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PassesAssertNotReachable determines if no source has a way to send network traffic to any destination (see Analysis.PassesAssertNotReachable).
+func (m Matrix) PassesAssertNotReachable() bool {
+	for _, cell := range m.Cells {
+		if !cell.Analysis.PassesAssertNotReachable() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Rows returns the matrix as rows of text, starting with a header row of destinations. Each following row starts with a source, followed by a summary of the traffic allowed from the source to each destination.
+func (m Matrix) Rows() [][]string {
+	header := []string{"source \\ destination"}
+	for _, destination := range m.Destinations {
+		header = append(header, destination.ID)
+	}
+
+	rows := [][]string{header}
+
+	for _, source := range m.Sources {
+		row := []string{source.ID}
+
+		for _, destination := range m.Destinations {
+			content := matrixSelfCell
+			if cell := m.Cell(source, destination); cell != nil {
+				content = cell.Traffic.Summary()
+			}
+
+			row = append(row, content)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
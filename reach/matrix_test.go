@@ -0,0 +1,35 @@
+package reach
+
+import (
+	"testing"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestMatrixTable(t *testing.T) {
+	http, err := set.NewPortSetFromRange(8080, 8080)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := func(id string, role SubjectRole) *Subject {
+		return &Subject{Domain: "AWS", Kind: "EC2Instance", ID: id, Role: role}
+	}
+
+	web, app := subject("i-web", SubjectRoleSource), subject("i-app", SubjectRoleSource)
+	webDestination, appDestination := subject("i-web", SubjectRoleDestination), subject("i-app", SubjectRoleDestination)
+
+	matrix := NewMatrix([]*Subject{web, app}, []*Subject{appDestination, webDestination})
+	matrix.Cells = []MatrixCell{
+		{Source: web, Destination: appDestination, Traffic: NewTrafficContentForPorts(ProtocolTCP, http)},
+		{Source: app, Destination: webDestination, Traffic: NewTrafficContentForNoTraffic()},
+	}
+
+	expectedTable := `source \ destination   i-app      i-web
+i-web                  TCP 8080   -
+i-app                  -          (none)
+`
+	if table := matrix.Table(); table != expectedTable {
+		DiffErrorf(t, "table", expectedTable, table)
+	}
+}
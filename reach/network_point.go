@@ -6,8 +6,11 @@ import (
 )
 
 // A NetworkPoint is a point of termination for an analyzed network vector (on either the source or destination side), such that there is no further subdivision of a source or destination possible beyond the network point. For example, the CIDR block "10.0.1.0/24" contains numerous individual IP addresses, and the analysis result might vary depending on which of these individual IP addresses is used in real network traffic. To break this problem down, such that an analysis result is as definitive as possible, each individual IP address must be analyzed, one at a time. Each IP address could be considered a network point, whereas the CIDR block could not be considered a network point.
+//
+// The one exception is a range of IP addresses for which the analysis result can't vary, such as part of a CIDR block that's not mentioned by any security group rule, network ACL rule or route. In this case, the network point's IPAddress is the first address in its Range.
 type NetworkPoint struct {
 	IPAddress net.IP
+	Range     *IPRange `json:"Range,omitempty"`
 	Lineage   []ResourceReference
 	Factors   []Factor
 }
@@ -30,7 +33,16 @@ func (point NetworkPoint) String() string {
 		generations = append(generations, point.Lineage[i].ID)
 	}
 
-	generations = append(generations, point.IPAddress.String())
+	generations = append(generations, point.AddressString())
 
 	return strings.Join(generations, " -> ")
 }
+
+// AddressString returns the network point's IP address, or its range of IP addresses if it has one.
+func (point NetworkPoint) AddressString() string {
+	if point.Range != nil {
+		return point.Range.String()
+	}
+
+	return point.IPAddress.String()
+}
@@ -2,17 +2,19 @@ package reach
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/nu7hatch/gouuid"
 )
 
 // A NetworkVector represents the path between two network points that's able to be analyzed in terms of what kind of network traffic is allowed to flow from point to point.
 type NetworkVector struct {
-	ID            string
-	Source        NetworkPoint
-	Destination   NetworkPoint
-	Traffic       *TrafficContent
-	ReturnTraffic *TrafficContent
+	ID                        string
+	Source                    NetworkPoint
+	Destination               NetworkPoint
+	TranslatedSourceIPAddress net.IP `json:"TranslatedSourceIPAddress,omitempty"` // the source IP address seen by the destination, if it's translated along the way (e.g. by a NAT gateway)
+	Traffic                   *TrafficContent
+	ReturnTraffic             *TrafficContent
 }
 
 // NewNetworkVector creates a new network vector given a source and a destination network point.
@@ -35,6 +37,10 @@ func (v NetworkVector) String() string {
 	output += fmt.Sprintf("* network vector ID: %s\n", v.ID)
 	output += fmt.Sprintf("* source network point: %s\n* destination network point: %s\n", v.Source.String(), v.Destination.String())
 
+	if v.TranslatedSourceIPAddress != nil {
+		output += fmt.Sprintf("* translated source IP address: %s\n", v.TranslatedSourceIPAddress)
+	}
+
 	if v.Traffic != nil {
 		output += "\n"
 		output += v.Traffic.String()
@@ -57,11 +63,16 @@ func (v NetworkVector) SourcePerspective() Perspective {
 	}
 }
 
-// DestinationPerspective returns an analyzable Perspective based on the NetworkVector's destination network point.
+// DestinationPerspective returns an analyzable Perspective based on the NetworkVector's destination network point. If the source IP address is translated along the way, the destination only sees the translated address, so the "other" network point is just that address.
 func (v NetworkVector) DestinationPerspective() Perspective {
+	other := v.Source
+	if v.TranslatedSourceIPAddress != nil {
+		other = NetworkPoint{IPAddress: v.TranslatedSourceIPAddress}
+	}
+
 	return Perspective{
 		Self:      v.Destination,
-		Other:     v.Source,
+		Other:     other,
 		SelfRole:  SubjectRoleDestination,
 		OtherRole: SubjectRoleSource,
 	}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"io"
+
+	"github.com/luhring/reach/reach"
+)
+
+// dotFormatter outputs a diagram of the network vectors in the Graphviz DOT language (see reach.VectorGraph).
+type dotFormatter struct{}
+
+// mermaidFormatter outputs a diagram of the network vectors as a Mermaid flowchart (see reach.VectorGraph).
+type mermaidFormatter struct{}
+
+func init() {
+	Register("dot", dotFormatter{})
+	Register("mermaid", mermaidFormatter{})
+}
+
+func (dotFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	_, err := io.WriteString(w, reach.NewVectorGraph(analysis).DOT())
+	return err
+}
+
+func (dotFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	_, err := io.WriteString(w, reach.NewVectorGraph(cellAnalyses(matrix)...).DOT())
+	return err
+}
+
+func (mermaidFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	_, err := io.WriteString(w, reach.NewVectorGraph(analysis).Mermaid())
+	return err
+}
+
+func (mermaidFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	_, err := io.WriteString(w, reach.NewVectorGraph(cellAnalyses(matrix)...).Mermaid())
+	return err
+}
+
+func cellAnalyses(matrix *reach.Matrix) []*reach.Analysis {
+	analyses := make([]*reach.Analysis, len(matrix.Cells))
+
+	for i, cell := range matrix.Cells {
+		analyses[i] = cell.Analysis
+	}
+
+	return analyses
+}
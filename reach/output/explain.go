@@ -0,0 +1,69 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/explainer"
+)
+
+// explainFormatter explains how the configuration was analyzed (see explainer.Explainer).
+type explainFormatter struct{}
+
+// vectorsFormatter shows the traffic allowed along each network vector.
+type vectorsFormatter struct{}
+
+func init() {
+	Register("explain", explainFormatter{})
+	Register("vectors", vectorsFormatter{})
+}
+
+func (explainFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	explanation, err := explainer.New(*analysis).Explain()
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, explanation)
+	return err
+}
+
+func (f explainFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return formatCells(w, matrix, f)
+}
+
+func (vectorsFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	var vectorOutputs []string
+
+	for _, v := range analysis.NetworkVectors {
+		vectorOutputs = append(vectorOutputs, v.String())
+	}
+
+	_, err := io.WriteString(w, strings.Join(vectorOutputs, "\n"))
+	return err
+}
+
+func (f vectorsFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return formatCells(w, matrix, f)
+}
+
+// formatCells formats the analysis of each of the matrix's cells in turn, each preceded by its source and destination.
+func formatCells(w io.Writer, matrix *reach.Matrix, f Formatter) error {
+	for _, cell := range matrix.Cells {
+		if _, err := fmt.Fprintf(w, "source: %s\ndestination: %s\n\n", cell.Source.ID, cell.Destination.ID); err != nil {
+			return err
+		}
+
+		if err := f.FormatAnalysis(w, cell.Analysis); err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
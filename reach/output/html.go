@@ -0,0 +1,76 @@
+package output
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/luhring/reach/reach"
+)
+
+// htmlFormatter outputs a standalone HTML page with a table of the analysis's network vectors, or of the matrix.
+type htmlFormatter struct{}
+
+func init() {
+	Register("html", htmlFormatter{})
+}
+
+var htmlTemplate = template.Must(template.New("reach").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Query}}<p>Considering only: {{.Query}}</p>
+{{end}}{{if .Summary}}<p><strong>{{.Summary}}</strong></p>
+{{end}}<table>
+{{range $i, $row := .Rows}}<tr>{{range $row}}{{if eq $i 0}}<th>{{.}}</th>{{else}}<td>{{.}}</td>{{end}}{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+type htmlPage struct {
+	Title   string
+	Query   string
+	Summary string
+	Rows    [][]string
+}
+
+func (htmlFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	source, destination := subjectIDs(analysis)
+
+	mergedTraffic, err := analysis.MergedTraffic()
+	if err != nil {
+		return err
+	}
+
+	return htmlTemplate.Execute(w, htmlPage{
+		Title:   "Network traffic allowed from " + source + " to " + destination,
+		Query:   querySummary(analysis.TrafficQuery),
+		Summary: mergedTraffic.Summary(),
+		Rows:    vectorRows(analysis),
+	})
+}
+
+func (htmlFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return htmlTemplate.Execute(w, htmlPage{
+		Title: "Network traffic allowed from each source (row) to each destination (column)",
+		Query: querySummary(matrix.TrafficQuery),
+		Rows:  matrix.Rows(),
+	})
+}
+
+func querySummary(query *reach.TrafficContent) string {
+	if query == nil {
+		return ""
+	}
+
+	return query.Summary()
+}
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/luhring/reach/reach"
+)
+
+// jsonFormatter outputs the full analysis or matrix as indented JSON.
+type jsonFormatter struct{}
+
+func init() {
+	Register("json", jsonFormatter{})
+}
+
+func (jsonFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	return writeJSON(w, analysis)
+}
+
+func (jsonFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return writeJSON(w, matrix)
+}
+
+func (jsonFormatter) FormatDiff(w io.Writer, diff *reach.AnalysisDiff) error {
+	return writeJSON(w, diff)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
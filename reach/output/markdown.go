@@ -0,0 +1,70 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/luhring/reach/reach"
+)
+
+// markdownFormatter outputs a Markdown table of the analysis's network vectors, or of the matrix, e.g. for a pull request comment.
+type markdownFormatter struct{}
+
+func init() {
+	Register("markdown", markdownFormatter{})
+}
+
+func (markdownFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	source, destination := subjectIDs(analysis)
+
+	mergedTraffic, err := analysis.MergedTraffic()
+	if err != nil {
+		return err
+	}
+
+	output := fmt.Sprintf("## Network traffic allowed from `%s` to `%s`\n\n", source, destination)
+	if analysis.TrafficQuery != nil {
+		output += fmt.Sprintf("Considering only: %s\n\n", analysis.TrafficQuery.Summary())
+	}
+	output += fmt.Sprintf("**%s**\n\n", markdownEscape(mergedTraffic.Summary()))
+	output += markdownTable(vectorRows(analysis))
+
+	_, err = io.WriteString(w, output)
+	return err
+}
+
+func (markdownFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	output := "## Network traffic allowed from each source (row) to each destination (column)\n\n"
+	if matrix.TrafficQuery != nil {
+		output += fmt.Sprintf("Considering only: %s\n\n", matrix.TrafficQuery.Summary())
+	}
+	output += markdownTable(matrix.Rows())
+
+	_, err := io.WriteString(w, output)
+	return err
+}
+
+// markdownTable returns a Markdown table, where the first row is the header.
+func markdownTable(rows [][]string) string {
+	var b strings.Builder
+
+	for i, row := range rows {
+		cells := make([]string, len(row))
+		for j, cell := range row {
+			cells[j] = markdownEscape(cell)
+		}
+
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", len(row)) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`").Replace(s)
+}
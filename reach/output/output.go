@@ -0,0 +1,184 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/policy"
+)
+
+// A Formatter writes the results of an analysis, or of a matrix of analyses, to a writer in a particular format.
+type Formatter interface {
+	FormatAnalysis(w io.Writer, analysis *reach.Analysis) error
+	FormatMatrix(w io.Writer, matrix *reach.Matrix) error
+}
+
+// A DiffFormatter writes the differences between two analyses in a particular format (e.g. for 'reach diff').
+type DiffFormatter interface {
+	FormatDiff(w io.Writer, diff *reach.AnalysisDiff) error
+}
+
+// A ReportFormatter writes the results of checking a policy file in a particular format (e.g. for 'reach check').
+type ReportFormatter interface {
+	FormatReport(w io.Writer, report *policy.Report) error
+}
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = make(map[string]interface{})
+)
+
+// Register makes a formatter available by the given name (e.g. for the --format flag). The formatter must implement at least one of Formatter, DiffFormatter and ReportFormatter, and it's available to the commands whose output it's able to format. Register panics if a formatter is already registered with the name, or if the formatter doesn't implement any of these interfaces.
+func Register(name string, formatter interface{}) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+
+	if formatter == nil {
+		panic("output: Register formatter is nil")
+	}
+
+	if !isFormatter(formatter) && !isDiffFormatter(formatter) && !isReportFormatter(formatter) {
+		panic("output: Register formatter " + name + " doesn't implement any formatter interface")
+	}
+
+	if _, exists := formatters[name]; exists {
+		panic("output: Register called twice for formatter " + name)
+	}
+
+	formatters[name] = formatter
+}
+
+// Lookup returns the formatter registered with the given name, as long as it's able to format analyses.
+func Lookup(name string) (Formatter, error) {
+	formatter, err := lookup(name, isFormatter, "show analyses")
+	if err != nil {
+		return nil, err
+	}
+
+	return formatter.(Formatter), nil
+}
+
+// LookupDiff returns the formatter registered with the given name, as long as it's able to format analysis differences.
+func LookupDiff(name string) (DiffFormatter, error) {
+	formatter, err := lookup(name, isDiffFormatter, "show differences")
+	if err != nil {
+		return nil, err
+	}
+
+	return formatter.(DiffFormatter), nil
+}
+
+// LookupReport returns the formatter registered with the given name, as long as it's able to format policy reports.
+func LookupReport(name string) (ReportFormatter, error) {
+	formatter, err := lookup(name, isReportFormatter, "show policy reports")
+	if err != nil {
+		return nil, err
+	}
+
+	return formatter.(ReportFormatter), nil
+}
+
+// Names returns the sorted names of the registered formatters that are able to format analyses.
+func Names() []string {
+	return names(isFormatter)
+}
+
+// DiffNames returns the sorted names of the registered formatters that are able to format analysis differences.
+func DiffNames() []string {
+	return names(isDiffFormatter)
+}
+
+// ReportNames returns the sorted names of the registered formatters that are able to format policy reports.
+func ReportNames() []string {
+	return names(isReportFormatter)
+}
+
+func lookup(name string, able func(interface{}) bool, ability string) (interface{}, error) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	formatter, exists := formatters[name]
+	if !exists {
+		return nil, fmt.Errorf("unrecognized format '%s' (must be one of: %s)", name, strings.Join(namesLocked(able), ", "))
+	}
+
+	if !able(formatter) {
+		return nil, fmt.Errorf("format '%s' can't %s (must be one of: %s)", name, ability, strings.Join(namesLocked(able), ", "))
+	}
+
+	return formatter, nil
+}
+
+func names(able func(interface{}) bool) []string {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+
+	return namesLocked(able)
+}
+
+func namesLocked(able func(interface{}) bool) []string {
+	var names []string
+
+	for name, formatter := range formatters {
+		if able(formatter) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+func isFormatter(formatter interface{}) bool {
+	_, ok := formatter.(Formatter)
+	return ok
+}
+
+func isDiffFormatter(formatter interface{}) bool {
+	_, ok := formatter.(DiffFormatter)
+	return ok
+}
+
+func isReportFormatter(formatter interface{}) bool {
+	_, ok := formatter.(ReportFormatter)
+	return ok
+}
+
+// subjectIDs returns the IDs of the analysis's source and destination subjects.
+func subjectIDs(analysis *reach.Analysis) (source, destination string) {
+	for _, subject := range analysis.Subjects {
+		switch subject.Role {
+		case reach.SubjectRoleSource:
+			source = subject.ID
+		case reach.SubjectRoleDestination:
+			destination = subject.ID
+		}
+	}
+
+	return source, destination
+}
+
+// vectorRows returns a header row and a row for each of the analysis's network vectors, with the vector's network points and the traffic allowed in each direction.
+func vectorRows(analysis *reach.Analysis) [][]string {
+	rows := [][]string{
+		{"source", "destination", "traffic", "return traffic"},
+	}
+
+	for _, v := range analysis.NetworkVectors {
+		traffic, returnTraffic := reach.NewTrafficContentForNoTraffic(), reach.NewTrafficContentForNoTraffic()
+		if v.Traffic != nil {
+			traffic = *v.Traffic
+		}
+		if v.ReturnTraffic != nil {
+			returnTraffic = *v.ReturnTraffic
+		}
+
+		rows = append(rows, []string{v.Source.String(), v.Destination.String(), traffic.Summary(), returnTraffic.Summary()})
+	}
+
+	return rows
+}
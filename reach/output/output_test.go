@@ -0,0 +1,486 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/analyzer"
+	"github.com/luhring/reach/reach/aws"
+	"github.com/luhring/reach/reach/aws/fake"
+	"github.com/luhring/reach/reach/set"
+)
+
+type countingFormatter struct{}
+
+func (countingFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	_, err := io.WriteString(w, strings.Repeat("v", len(analysis.NetworkVectors)))
+	return err
+}
+
+func (countingFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	_, err := io.WriteString(w, strings.Repeat("c", len(matrix.Cells)))
+	return err
+}
+
+func TestRegister(t *testing.T) {
+	Register("test-counting", countingFormatter{})
+
+	formatter, err := Lookup("test-counting")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := formatter.FormatMatrix(&b, &reach.Matrix{Cells: make([]reach.MatrixCell, 3)}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "ccc" {
+		t.Errorf("expected registered formatter to be used, but got output: %s", b.String())
+	}
+
+	if _, err := Lookup("not-a-format"); err == nil {
+		t.Error("expected an error for an unregistered format")
+	}
+
+	for _, name := range []string{"text", "json", "yaml", "table", "markdown", "html"} {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("expected built-in format '%s' to be registered", name)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic for a name that's already registered")
+		}
+	}()
+	Register("json", countingFormatter{})
+}
+
+func TestLookupByCapability(t *testing.T) {
+	cases := []struct {
+		name        string
+		analysis    bool
+		differences bool
+		report      bool
+	}{
+		{"text", true, true, true},
+		{"json", true, true, false},
+		{"csv", true, false, false},
+		{"junit", false, false, true},
+		{"sarif", false, false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Lookup(tc.name); (err == nil) != tc.analysis {
+				t.Errorf("expected format to be able to show analyses: %t, but got error: %v", tc.analysis, err)
+			}
+			if _, err := LookupDiff(tc.name); (err == nil) != tc.differences {
+				t.Errorf("expected format to be able to show differences: %t, but got error: %v", tc.differences, err)
+			}
+			if _, err := LookupReport(tc.name); (err == nil) != tc.report {
+				t.Errorf("expected format to be able to show policy reports: %t, but got error: %v", tc.report, err)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Register to panic for a value that isn't a formatter")
+		}
+	}()
+	Register("test-not-a-formatter", struct{}{})
+}
+
+func TestFormatAnalysis(t *testing.T) {
+	analysis := analyzeFake(t)
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"table",
+			`source                          destination                   traffic    return traffic
+i-app -> eni-app -> 10.0.1.10   i-db -> eni-db -> 10.0.1.11   TCP 5432   all traffic
+`,
+		},
+		{
+			"csv",
+			`source,destination,traffic,return traffic
+i-app -> eni-app -> 10.0.1.10,i-db -> eni-db -> 10.0.1.11,TCP 5432,all traffic
+`,
+		},
+		{
+			"markdown",
+			"## Network traffic allowed from `i-app` to `i-db`\n\n" +
+				`**TCP 5432**
+
+| source | destination | traffic | return traffic |
+| --- | --- | --- | --- |
+| i-app -> eni-app -> 10.0.1.10 | i-db -> eni-db -> 10.0.1.11 | TCP 5432 | all traffic |
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.format, func(t *testing.T) {
+			formatter, err := Lookup(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var b bytes.Buffer
+			if err := formatter.FormatAnalysis(&b, analysis); err != nil {
+				t.Fatal(err)
+			}
+
+			if b.String() != tc.expected {
+				reach.DiffErrorf(t, tc.format, tc.expected, b.String())
+			}
+		})
+	}
+}
+
+func TestFormatMatrix(t *testing.T) {
+	http, err := set.NewPortSetFromRange(8080, 8080)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := func(id string, role reach.SubjectRole) *reach.Subject {
+		return &reach.Subject{Domain: "AWS", Kind: "EC2Instance", ID: id, Role: role}
+	}
+
+	web, app := subject("i-web", reach.SubjectRoleSource), subject("i-app", reach.SubjectRoleSource)
+	webDestination, appDestination := subject("i-web", reach.SubjectRoleDestination), subject("i-app", reach.SubjectRoleDestination)
+
+	matrix := reach.NewMatrix([]*reach.Subject{web, app}, []*reach.Subject{appDestination, webDestination})
+	matrix.Cells = []reach.MatrixCell{
+		{Source: web, Destination: appDestination, Traffic: reach.NewTrafficContentForPorts(reach.ProtocolTCP, http)},
+		{Source: app, Destination: webDestination, Traffic: reach.NewTrafficContentForNoTraffic()},
+	}
+
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			"table",
+			`source \ destination   i-app      i-web
+i-web                  TCP 8080   -
+i-app                  -          (none)
+`,
+		},
+		{
+			"csv",
+			`source \ destination,i-app,i-web
+i-web,TCP 8080,-
+i-app,-,(none)
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.format, func(t *testing.T) {
+			formatter, err := Lookup(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var b bytes.Buffer
+			if err := formatter.FormatMatrix(&b, matrix); err != nil {
+				t.Fatal(err)
+			}
+
+			if b.String() != tc.expected {
+				reach.DiffErrorf(t, tc.format, tc.expected, b.String())
+			}
+		})
+	}
+}
+
+// TestJSONDocument guards the versioned JSON representation of an analysis (see schema/analysis.v1.schema.json), which has to stay the same when the internals of Reach change, such as the fields of the types that represent AWS resources.
+func TestJSONDocument(t *testing.T) {
+	analysis := analyzeFake(t)
+	for i := range analysis.NetworkVectors {
+		analysis.NetworkVectors[i].ID = fmt.Sprintf("vector-%d", i+1)
+	}
+
+	var b bytes.Buffer
+	if err := (jsonFormatter{}).FormatAnalysis(&b, analysis); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "schemaVersion": 1,
+  "subjects": [
+    {
+      "domain": "aws",
+      "kind": "EC2Instance",
+      "id": "i-app",
+      "role": "source"
+    },
+    {
+      "domain": "aws",
+      "kind": "EC2Instance",
+      "id": "i-db",
+      "role": "destination"
+    }
+  ],
+  "resources": [
+    {
+      "domain": "aws",
+      "kind": "EC2Instance",
+      "id": "i-app"
+    },
+    {
+      "domain": "aws",
+      "kind": "EC2Instance",
+      "id": "i-db"
+    },
+    {
+      "domain": "aws",
+      "kind": "ElasticNetworkInterface",
+      "id": "eni-app"
+    },
+    {
+      "domain": "aws",
+      "kind": "ElasticNetworkInterface",
+      "id": "eni-db"
+    },
+    {
+      "domain": "aws",
+      "kind": "NetworkACL",
+      "id": "acl-10-0-0-0-16"
+    },
+    {
+      "domain": "aws",
+      "kind": "RouteTable",
+      "id": "rtb-10-0-0-0-16"
+    },
+    {
+      "domain": "aws",
+      "kind": "SecurityGroup",
+      "id": "sg-app"
+    },
+    {
+      "domain": "aws",
+      "kind": "SecurityGroup",
+      "id": "sg-db"
+    },
+    {
+      "domain": "aws",
+      "kind": "SecurityGroupReference",
+      "id": "sg-app"
+    },
+    {
+      "domain": "aws",
+      "kind": "Subnet",
+      "id": "subnet-1"
+    },
+    {
+      "domain": "aws",
+      "kind": "VPC",
+      "id": "vpc-10-0-0-0-16"
+    }
+  ],
+  "networkVectors": [
+    {
+      "id": "vector-1",
+      "source": {
+        "ipAddress": "10.0.1.10",
+        "lineage": [
+          {
+            "domain": "aws",
+            "kind": "ElasticNetworkInterface",
+            "id": "eni-app"
+          },
+          {
+            "domain": "aws",
+            "kind": "EC2Instance",
+            "id": "i-app"
+          }
+        ],
+        "factors": [
+          {
+            "kind": "SecurityGroupRules",
+            "resource": {
+              "domain": "aws",
+              "kind": "ElasticNetworkInterface",
+              "id": "eni-app"
+            },
+            "traffic": "[all traffic]",
+            "returnTraffic": "[all traffic]",
+            "components": [
+              {
+                "resource": {
+                  "domain": "aws",
+                  "kind": "SecurityGroup",
+                  "id": "sg-app"
+                },
+                "description": "outbound rule #1 for IP CIDR block 0.0.0.0/0",
+                "traffic": "[all traffic]"
+              }
+            ]
+          },
+          {
+            "kind": "InstanceState",
+            "resource": {
+              "domain": "aws",
+              "kind": "EC2Instance",
+              "id": "i-app"
+            },
+            "traffic": "[all traffic]",
+            "returnTraffic": "[all traffic]"
+          }
+        ]
+      },
+      "destination": {
+        "ipAddress": "10.0.1.11",
+        "lineage": [
+          {
+            "domain": "aws",
+            "kind": "ElasticNetworkInterface",
+            "id": "eni-db"
+          },
+          {
+            "domain": "aws",
+            "kind": "EC2Instance",
+            "id": "i-db"
+          }
+        ],
+        "factors": [
+          {
+            "kind": "SecurityGroupRules",
+            "resource": {
+              "domain": "aws",
+              "kind": "ElasticNetworkInterface",
+              "id": "eni-db"
+            },
+            "traffic": {
+              "TCP": [
+                "5432"
+              ]
+            },
+            "returnTraffic": "[all traffic]",
+            "components": [
+              {
+                "resource": {
+                  "domain": "aws",
+                  "kind": "SecurityGroup",
+                  "id": "sg-db"
+                },
+                "description": "inbound rule #1 for security group sg-app",
+                "traffic": {
+                  "TCP": [
+                    "5432"
+                  ]
+                }
+              }
+            ]
+          },
+          {
+            "kind": "InstanceState",
+            "resource": {
+              "domain": "aws",
+              "kind": "EC2Instance",
+              "id": "i-db"
+            },
+            "traffic": "[all traffic]",
+            "returnTraffic": "[all traffic]"
+          }
+        ]
+      },
+      "traffic": {
+        "TCP": [
+          "5432"
+        ]
+      },
+      "returnTraffic": "[all traffic]"
+    }
+  ]
+}
+`
+	if b.String() != expected {
+		reach.DiffErrorf(t, "JSON", expected, b.String())
+	}
+}
+
+func TestYAMLMatchesJSON(t *testing.T) {
+	analysis := analyzeFake(t)
+
+	var jsonOutput, yamlOutput bytes.Buffer
+	if err := (jsonFormatter{}).FormatAnalysis(&jsonOutput, analysis); err != nil {
+		t.Fatal(err)
+	}
+	if err := (yamlFormatter{}).FormatAnalysis(&yamlOutput, analysis); err != nil {
+		t.Fatal(err)
+	}
+
+	var fromJSON, fromYAML interface{}
+	if err := json.Unmarshal(jsonOutput.Bytes(), &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(yamlOutput.Bytes(), &fromYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	// compare by way of JSON, since the YAML decoder produces differently typed numbers and maps
+	a, _ := json.Marshal(fromJSON)
+	b, err := json.Marshal(fromYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(a) != string(b) {
+		reach.DiffErrorf(t, "YAML content", string(a), string(b))
+	}
+}
+
+func analyzeFake(t *testing.T) *reach.Analysis {
+	t.Helper()
+
+	postgres, err := set.NewPortSetFromRange(5432, 5432)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := fake.NewVPC("10.0.0.0/16").
+		Subnet("subnet-1", "10.0.1.0/24").
+		SecurityGroup("sg-app",
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		SecurityGroup("sg-db",
+			fake.Inbound(reach.NewTrafficContentForPorts(reach.ProtocolTCP, postgres), fake.SecurityGroup("sg-app")),
+		).
+		Instance("app", "subnet-1", "sg-app").
+		Instance("db", "subnet-1", "sg-db").
+		Provider()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	source, err := aws.NewEC2InstanceSubject("i-app", reach.SubjectRoleSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination, err := aws.NewEC2InstanceSubject("i-db", reach.SubjectRoleDestination)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis, err := analyzer.New(analyzer.WithProvider(provider)).Analyze(source, destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return analysis
+}
@@ -0,0 +1,26 @@
+package output
+
+import (
+	"io"
+
+	"github.com/luhring/reach/reach/policy"
+)
+
+// junitFormatter outputs a policy report as JUnit XML, for CI systems that show test results.
+type junitFormatter struct{}
+
+// sarifFormatter outputs a policy report as a SARIF log, for CI systems that show code scanning results.
+type sarifFormatter struct{}
+
+func init() {
+	Register("junit", junitFormatter{})
+	Register("sarif", sarifFormatter{})
+}
+
+func (junitFormatter) FormatReport(w io.Writer, report *policy.Report) error {
+	return report.WriteJUnit(w)
+}
+
+func (sarifFormatter) FormatReport(w io.Writer, report *policy.Report) error {
+	return report.WriteSARIF(w)
+}
@@ -0,0 +1,50 @@
+package output
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/luhring/reach/reach"
+)
+
+// tableFormatter outputs a text table of the analysis's network vectors, or of the matrix.
+type tableFormatter struct{}
+
+// csvFormatter outputs the same rows as tableFormatter, as CSV.
+type csvFormatter struct{}
+
+func init() {
+	Register("table", tableFormatter{})
+	Register("csv", csvFormatter{})
+}
+
+func (tableFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	return writeTable(w, vectorRows(analysis))
+}
+
+func (tableFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	_, err := io.WriteString(w, matrix.Table())
+	return err
+}
+
+func (csvFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	return csv.NewWriter(w).WriteAll(vectorRows(analysis))
+}
+
+func (csvFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return csv.NewWriter(w).WriteAll(matrix.Rows())
+}
+
+func writeTable(w io.Writer, rows [][]string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	for _, row := range rows {
+		if _, err := io.WriteString(tw, strings.Join(row, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
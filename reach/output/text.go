@@ -0,0 +1,89 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/explainer"
+	"github.com/luhring/reach/reach/policy"
+)
+
+const mergedResultsWarning = "WARNING: Reach detected more than one network path between the source and destination. Reach calls these paths \"network vectors\". The analysis result shown above is the merging of all network vectors' analysis results. The impact that infrastructure configuration has on actual network reachability might vary based on the way hosts are configured to use their network interfaces, and Reach is unable to access any configuration internal to a host. To see the network reachability across individual network vectors, run the command again with '--format vectors'.\n"
+
+const restrictedVectorReturnTrafficWarning = "WARNING: One or more of the analyzed network vectors has restrictions on network traffic allowed to return from the destination to the source. For details, run the command again with '--format vectors'.\n"
+
+// textFormatter is the default format: a brief, human-readable summary of the traffic allowed, with warnings about anything the summary doesn't capture.
+type textFormatter struct{}
+
+func init() {
+	Register("text", textFormatter{})
+}
+
+func (textFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	source, destination := subjectIDs(analysis)
+	output := fmt.Sprintf("source: %s\ndestination: %s\n\n", source, destination)
+	output += trafficQueryNote(analysis.TrafficQuery)
+
+	mergedTraffic, err := analysis.MergedTraffic()
+	if err != nil {
+		return err
+	}
+
+	output += "network traffic allowed from source to destination:\n"
+	output += mergedTraffic.ColorStringWithSymbols()
+
+	if len(analysis.NetworkVectors) > 1 { // handling this case with care; this view isn't optimized for multi-vector output!
+		output += "\n" + mergedResultsWarning
+
+		for _, v := range analysis.NetworkVectors {
+			if !v.ReturnTraffic.All() {
+				output += "\n" + restrictedVectorReturnTrafficWarning
+				break
+			}
+		}
+	} else {
+		mergedReturnTraffic, err := analysis.MergedReturnTraffic()
+		if err != nil {
+			return err
+		}
+
+		restrictedProtocols := mergedTraffic.ProtocolsWithRestrictedReturnPath(mergedReturnTraffic)
+		if len(restrictedProtocols) > 0 {
+			found, warnings := explainer.WarningsFromRestrictedReturnPath(restrictedProtocols)
+			if found {
+				output += "\n" + warnings + "\n"
+			}
+		}
+	}
+
+	_, err = io.WriteString(w, output)
+	return err
+}
+
+func (textFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	output := trafficQueryNote(matrix.TrafficQuery)
+	output += "network traffic allowed from each source (row) to each destination (column):\n\n"
+	output += matrix.Table()
+
+	_, err := io.WriteString(w, output)
+	return err
+}
+
+func (textFormatter) FormatDiff(w io.Writer, diff *reach.AnalysisDiff) error {
+	_, err := io.WriteString(w, diff.String())
+	return err
+}
+
+func (textFormatter) FormatReport(w io.Writer, report *policy.Report) error {
+	return report.WriteText(w)
+}
+
+// trafficQueryNote notes which traffic the analysis is limited to, if any.
+func trafficQueryNote(query *reach.TrafficContent) string {
+	if query == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("considering only: %s\n\n", query.Summary())
+}
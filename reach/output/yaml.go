@@ -0,0 +1,61 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luhring/reach/reach"
+)
+
+// yamlFormatter outputs the same content as jsonFormatter, as YAML.
+type yamlFormatter struct{}
+
+func init() {
+	Register("yaml", yamlFormatter{})
+}
+
+func (yamlFormatter) FormatAnalysis(w io.Writer, analysis *reach.Analysis) error {
+	return writeYAML(w, analysis)
+}
+
+func (yamlFormatter) FormatMatrix(w io.Writer, matrix *reach.Matrix) error {
+	return writeYAML(w, matrix)
+}
+
+func (yamlFormatter) FormatDiff(w io.Writer, diff *reach.AnalysisDiff) error {
+	return writeYAML(w, diff)
+}
+
+// writeYAML converts v to YAML by way of its JSON representation, so that types with custom JSON marshaling (e.g. reach.TrafficContent) look the same in both formats, and fields keep their order.
+func writeYAML(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	useBlockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// useBlockStyle clears the flow style (e.g. "{...}") and the double quotes that come from parsing JSON, so the node is encoded as conventional YAML.
+func useBlockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		useBlockStyle(child)
+	}
+}
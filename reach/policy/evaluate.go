@@ -0,0 +1,102 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/analyzer"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// Evaluate checks each of the policy's expectations against the AWS resources from the given provider, and returns a report of the results. An expectation that can't be evaluated (e.g. because its source or destination doesn't match anything) is reported as an error rather than stopping the evaluation of the remaining expectations.
+func Evaluate(policy *Policy, provider aws.ResourceProvider) *Report {
+	report := &Report{
+		Policy: policy,
+	}
+
+	for _, expectation := range policy.Expectations {
+		result := Result{
+			Expectation: expectation,
+		}
+
+		violations, err := evaluateExpectation(expectation, provider)
+		if err != nil {
+			result.Error = err
+		} else {
+			result.Violations = violations
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report
+}
+
+func evaluateExpectation(expectation Expectation, provider aws.ResourceProvider) ([]string, error) {
+	sources, err := aws.NewSubjects(expectation.From, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := aws.NewSubjects(expectation.To, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []analyzer.Option{analyzer.WithProvider(provider)}
+	if expectation.trafficQuery != nil {
+		options = append(options, analyzer.WithTrafficQuery(*expectation.trafficQuery))
+	}
+
+	matrix, err := analyzer.New(options...).AnalyzeMatrix(sources, destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(matrix.Cells) == 0 {
+		return nil, fmt.Errorf("'%s' and '%s' don't have any distinct source and destination to analyze", expectation.From, expectation.To)
+	}
+
+	var violations []string
+
+	for _, cell := range matrix.Cells {
+		if violation := cellViolation(expectation, cell, provider); violation != "" {
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations, nil
+}
+
+// cellViolation describes how the cell's analysis violates the expectation, or returns an empty string if it doesn't.
+func cellViolation(expectation Expectation, cell reach.MatrixCell, provider aws.ResourceProvider) string {
+	source, destination := aws.SubjectName(cell.Source, provider), aws.SubjectName(cell.Destination, provider)
+
+	if expectation.Expect == ExpectNotReachable {
+		if cell.Analysis.PassesAssertNotReachable() {
+			return ""
+		}
+
+		return fmt.Sprintf("%s can reach %s with %s", source, destination, cell.Traffic.Summary())
+	}
+
+	if cell.Analysis.PassesAssertReachable() {
+		return ""
+	}
+
+	if cell.Traffic.None() {
+		return fmt.Sprintf("%s can't reach %s", source, destination)
+	}
+
+	if query := expectation.trafficQuery; query != nil {
+		if query.All() && !cell.Traffic.All() {
+			return fmt.Sprintf("%s can't reach %s with all traffic (only %s is allowed)", source, destination, cell.Traffic.Summary())
+		}
+
+		if missing, err := query.Subtract(cell.Traffic); err == nil && !missing.None() {
+			return fmt.Sprintf("%s can't reach %s with %s (only %s is allowed)", source, destination, missing.Summary(), cell.Traffic.Summary())
+		}
+	}
+
+	return fmt.Sprintf("%s can reach %s with %s, but the return traffic is obstructed", source, destination, cell.Traffic.Summary())
+}
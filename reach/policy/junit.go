@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// The JUnit XML format, as understood by most CI systems.
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as JUnit XML to the given writer, with one test suite for the policy file and one test case for each expectation.
+func (r Report) WriteJUnit(w io.Writer) error {
+	passed, failed, errored := r.Counts()
+
+	suite := junitTestSuite{
+		Name:     r.Policy.Path,
+		Tests:    passed + failed + errored,
+		Failures: failed,
+		Errors:   errored,
+	}
+
+	for _, result := range r.Results {
+		testCase := junitTestCase{
+			Name:      result.Expectation.Title(),
+			ClassName: r.Policy.Path,
+		}
+
+		switch {
+		case result.Error != nil:
+			testCase.Error = &junitProblem{
+				Message: result.Error.Error(),
+			}
+		case len(result.Violations) > 0:
+			testCase.Failure = &junitProblem{
+				Message: result.Violations[0],
+				Text:    strings.Join(result.Violations, "\n"),
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{TestSuites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,150 @@
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/luhring/reach/reach"
+)
+
+// An Expect value states whether the traffic described by an expectation must or must not be allowed.
+type Expect string
+
+// The supported Expect values, named after the root command's assertion flags.
+const (
+	ExpectReachable    Expect = "reachable"
+	ExpectNotReachable Expect = "not-reachable"
+)
+
+// A Policy is a list of expectations about network reachability, typically read from a YAML file that's checked in CI.
+//
+// For example:
+//
+//	expectations:
+//	  - name: web servers can reach the database over Postgres
+//	    from: tag:Role=web
+//	    to: tag:Role=db
+//	    traffic: tcp/5432
+//	    expect: reachable
+//	  - from: tag:Role=web
+//	    to: tag:Role=db
+//	    traffic: tcp/22
+//	    expect: not-reachable
+type Policy struct {
+	Path         string
+	Expectations []Expectation
+}
+
+// An Expectation states whether the given traffic must or must not be allowed from each subject matched by the From identifier to each subject matched by the To identifier. The identifiers can be anything accepted by aws.NewSubjects. If Traffic is empty, all traffic is considered.
+type Expectation struct {
+	Name    string `yaml:"name"`
+	From    string `yaml:"from"`
+	To      string `yaml:"to"`
+	Traffic string `yaml:"traffic"`
+	Expect  Expect `yaml:"expect"`
+
+	Line         int                   `yaml:"-"` // the line in the policy file where the expectation begins
+	trafficQuery *reach.TrafficContent // parsed from Traffic
+}
+
+// Read parses and validates a policy from the given reader. The path is used only to refer to the policy file in reports.
+func Read(r io.Reader, path string) (*Policy, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Expectations []Expectation `yaml:"expectations"`
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("policy file '%s' is empty", path)
+		}
+		return nil, fmt.Errorf("unable to read policy file '%s': %v", path, err)
+	}
+
+	if len(document.Expectations) == 0 {
+		return nil, fmt.Errorf("policy file '%s' doesn't have any expectations", path)
+	}
+
+	// decode the document again as nodes to find the line where each expectation begins
+	var nodes struct {
+		Expectations []yaml.Node `yaml:"expectations"`
+	}
+	if err := yaml.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("unable to read policy file '%s': %v", path, err)
+	}
+
+	policy := &Policy{
+		Path: path,
+	}
+
+	for i, expectation := range document.Expectations {
+		expectation.Line = nodes.Expectations[i].Line
+
+		if err := expectation.validate(); err != nil {
+			return nil, fmt.Errorf("invalid expectation at %s:%d: %v", path, expectation.Line, err)
+		}
+
+		policy.Expectations = append(policy.Expectations, expectation)
+	}
+
+	return policy, nil
+}
+
+// Title returns the expectation's name, or, if it doesn't have one, a description of what it expects.
+func (e Expectation) Title() string {
+	if e.Name != "" {
+		return e.Name
+	}
+
+	traffic := "any traffic"
+	if e.Traffic != "" {
+		traffic = e.Traffic
+	}
+
+	verb := "is allowed"
+	if e.Expect == ExpectNotReachable {
+		verb = "is not allowed"
+	}
+
+	return fmt.Sprintf("%s from %s to %s %s", traffic, e.From, e.To, verb)
+}
+
+func (e *Expectation) validate() error {
+	if e.From == "" {
+		return errors.New("'from' is required")
+	}
+
+	if e.To == "" {
+		return errors.New("'to' is required")
+	}
+
+	switch e.Expect {
+	case ExpectReachable, ExpectNotReachable:
+	case "":
+		return fmt.Errorf("'expect' is required, and must be '%s' or '%s'", ExpectReachable, ExpectNotReachable)
+	default:
+		return fmt.Errorf("unrecognized 'expect' value '%s' (must be '%s' or '%s')", e.Expect, ExpectReachable, ExpectNotReachable)
+	}
+
+	if e.Traffic != "" {
+		query, err := reach.ParseTrafficQuery(e.Traffic)
+		if err != nil {
+			return err
+		}
+		e.trafficQuery = &query
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/aws/fake"
+	"github.com/luhring/reach/reach/set"
+)
+
+const testPolicy = `expectations:
+  - name: web servers can reach the database over Postgres
+    from: tag:Role=web
+    to: tag:Role=db
+    traffic: tcp/5432
+    expect: reachable
+  - from: tag:Role=web
+    to: tag:Role=db
+    traffic: tcp/22
+    expect: not-reachable
+  - name: web servers can reach the database over Postgres and SSH
+    from: web-*
+    to: db
+    traffic: tcp/22,tcp/5432
+    expect: reachable
+  - from: tag:Role=db
+    to: tag:Role=web
+    expect: not-reachable
+  - from: cache-*
+    to: db
+    expect: reachable
+`
+
+func TestEvaluateWithFakeProvider(t *testing.T) {
+	const subnet1 = "subnet-1"
+
+	postgres, err := set.NewPortSetFromRange(5432, 5432)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provider, err := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnet1, "10.0.1.0/24").
+		SecurityGroup("sg-web",
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		SecurityGroup("sg-db",
+			fake.Inbound(reach.NewTrafficContentForPorts(reach.ProtocolTCP, postgres), fake.SecurityGroup("sg-web")),
+		).
+		Instance("web-1", subnet1, "sg-web").
+		Instance("web-2", subnet1, "sg-web").
+		Instance("db", subnet1, "sg-db").
+		InstanceTag("web-1", "Role", "web").
+		InstanceTag("web-2", "Role", "web").
+		InstanceTag("db", "Role", "db").
+		Provider()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Read(strings.NewReader(testPolicy), "policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := Evaluate(p, provider)
+
+	if report.Passed() {
+		t.Error("expected report not to pass")
+	}
+
+	passed, failed, errored := report.Counts()
+	if passed != 3 || failed != 1 || errored != 1 {
+		t.Errorf("expected 3 passed, 1 failed and 1 error, but got %d, %d and %d", passed, failed, errored)
+	}
+
+	expectedViolations := []string{
+		"\"web-1\" (i-web-1) can't reach \"db\" (i-db) with TCP 22 (only TCP 5432 is allowed)",
+		"\"web-2\" (i-web-2) can't reach \"db\" (i-db) with TCP 22 (only TCP 5432 is allowed)",
+	}
+	if violations := strings.Join(report.Results[2].Violations, "\n"); violations != strings.Join(expectedViolations, "\n") {
+		reach.DiffErrorf(t, "violations", strings.Join(expectedViolations, "\n"), violations)
+	}
+
+	if title := report.Results[1].Expectation.Title(); title != "tcp/22 from tag:Role=web to tag:Role=db is not allowed" {
+		t.Errorf("unexpected title for unnamed expectation: %s", title)
+	}
+
+	if line := report.Results[3].Expectation.Line; line != 16 {
+		t.Errorf("expected fourth expectation to begin on line 16, but got %d", line)
+	}
+
+	var junit bytes.Buffer
+	if err := report.WriteJUnit(&junit); err != nil {
+		t.Fatal(err)
+	}
+
+	var suites junitTestSuites
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatal(err)
+	}
+	if suite := suites.TestSuites[0]; suite.Tests != 5 || suite.Failures != 1 || suite.Errors != 1 {
+		t.Errorf("unexpected JUnit test suite: %+v", suite)
+	}
+
+	var sarif bytes.Buffer
+	if err := report.WriteSARIF(&sarif); err != nil {
+		t.Fatal(err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if results := log.Runs[0].Results; len(results) != 5 || results[2].Level != "error" || results[0].Kind != "pass" {
+		t.Errorf("unexpected SARIF results: %+v", results)
+	}
+}
+
+func TestReadInvalidPolicies(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+	}{
+		{"empty", ""},
+		{"no expectations", "expectations: []\n"},
+		{"missing expect", "expectations:\n  - from: a\n    to: b\n"},
+		{"unrecognized expect", "expectations:\n  - from: a\n    to: b\n    expect: maybe\n"},
+		{"missing from", "expectations:\n  - to: b\n    expect: reachable\n"},
+		{"invalid traffic", "expectations:\n  - from: a\n    to: b\n    traffic: tcp/http\n    expect: reachable\n"},
+		{"unknown field", "expectations:\n  - from: a\n    to: b\n    expect: reachable\n    port: 22\n"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Read(strings.NewReader(tc.policy), "policy.yaml"); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestRequiredFlowsWithFakeProvider(t *testing.T) {
+	const subnet1 = "subnet-1"
+
+	allTCP := reach.NewTrafficContentForPorts(reach.ProtocolTCP, set.NewFullPortSet())
+
+	provider, err := fake.NewVPC("10.0.0.0/16").
+		Subnet(subnet1, "10.0.1.0/24").
+		SecurityGroup("sg-web",
+			fake.Outbound(reach.NewTrafficContentForAllTraffic(), fake.CIDR("0.0.0.0/0")),
+		).
+		SecurityGroup("sg-db",
+			fake.Inbound(allTCP, fake.CIDR("10.0.0.0/16")),
+		).
+		Instance("web-1", subnet1, "sg-web").
+		Instance("web-2", subnet1, "sg-web").
+		Instance("db", subnet1, "sg-db").
+		Provider()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const requirements = `expectations:
+  - from: web-*
+    to: db
+    traffic: tcp/5432
+    expect: reachable
+  - from: db
+    to: web-*
+    expect: not-reachable
+`
+
+	p, err := Read(strings.NewReader(requirements), "requirements.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flows, err := RequiredFlows(p, provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, flow := range flows {
+		actual = append(actual, fmt.Sprintf("%s: %s (%d network vectors)", flow.Name, flow.Traffic.Summary(), len(flow.Analysis.NetworkVectors)))
+	}
+
+	expected := []string{
+		`"web-1" (i-web-1) to "db" (i-db): TCP 5432 (1 network vectors)`,
+		`"web-2" (i-web-2) to "db" (i-db): TCP 5432 (1 network vectors)`,
+	}
+
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		reach.DiffErrorf(t, "required flows", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/luhring/reach/reach"
+	"github.com/luhring/reach/reach/analyzer"
+	"github.com/luhring/reach/reach/aws"
+)
+
+// RequiredFlows returns a required flow (see aws.Recommend) for each source and destination matched by each of the policy's "reachable" expectations, along with the analysis of all of the traffic allowed between them. An expectation without traffic requires all traffic. "not-reachable" expectations aren't required flows, so they're skipped.
+func RequiredFlows(policy *Policy, provider aws.ResourceProvider) ([]aws.RequiredFlow, error) {
+	var flows []aws.RequiredFlow
+
+	for _, expectation := range policy.Expectations {
+		if expectation.Expect != ExpectReachable {
+			continue
+		}
+
+		expectationFlows, err := requiredFlows(expectation, provider)
+		if err != nil {
+			return nil, fmt.Errorf("unable to analyze expectation '%s': %v", expectation.Title(), err)
+		}
+
+		flows = append(flows, expectationFlows...)
+	}
+
+	if len(flows) == 0 {
+		return nil, errors.New("policy doesn't have any 'reachable' expectations to use as required flows")
+	}
+
+	return flows, nil
+}
+
+func requiredFlows(expectation Expectation, provider aws.ResourceProvider) ([]aws.RequiredFlow, error) {
+	sources, err := aws.NewSubjects(expectation.From, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := aws.NewSubjects(expectation.To, provider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Analyze all traffic, not just the expectation's, so that each rule's full effect is known.
+	matrix, err := analyzer.New(analyzer.WithProvider(provider)).AnalyzeMatrix(sources, destinations)
+	if err != nil {
+		return nil, err
+	}
+
+	traffic := reach.NewTrafficContentForAllTraffic()
+	if expectation.trafficQuery != nil {
+		traffic = *expectation.trafficQuery
+	}
+
+	var flows []aws.RequiredFlow
+
+	for _, cell := range matrix.Cells {
+		flows = append(flows, aws.RequiredFlow{
+			Name:     fmt.Sprintf("%s to %s", aws.SubjectName(cell.Source, provider), aws.SubjectName(cell.Destination, provider)),
+			Analysis: cell.Analysis,
+			Traffic:  traffic,
+		})
+	}
+
+	return flows, nil
+}
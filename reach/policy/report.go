@@ -0,0 +1,82 @@
+package policy
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/mgutz/ansi"
+)
+
+// A Report holds the results of evaluating each of a policy's expectations.
+type Report struct {
+	Policy  *Policy
+	Results []Result
+}
+
+// A Result is the outcome of evaluating a single expectation. A result with neither violations nor an error means the expectation was met.
+type Result struct {
+	Expectation Expectation
+	Violations  []string // one per source and destination pairing that doesn't meet the expectation
+	Error       error    // set if the expectation couldn't be evaluated
+}
+
+// Passed returns a boolean indicating whether or not the expectation was met.
+func (r Result) Passed() bool {
+	return r.Error == nil && len(r.Violations) == 0
+}
+
+// Passed returns a boolean indicating whether or not every expectation was met.
+func (r Report) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Counts returns the number of expectations that passed, that were violated, and that couldn't be evaluated.
+func (r Report) Counts() (passed, failed, errored int) {
+	for _, result := range r.Results {
+		switch {
+		case result.Error != nil:
+			errored++
+		case len(result.Violations) > 0:
+			failed++
+		default:
+			passed++
+		}
+	}
+
+	return passed, failed, errored
+}
+
+// WriteText writes a human-readable pass/fail report to the given writer.
+func (r Report) WriteText(w io.Writer) error {
+	for _, result := range r.Results {
+		var err error
+
+		switch {
+		case result.Error != nil:
+			_, err = fmt.Fprintf(w, "%s %s\n    %v\n", ansi.Color("ERROR", "red+b"), result.Expectation.Title(), result.Error)
+		case len(result.Violations) > 0:
+			_, err = fmt.Fprintf(w, "%s %s\n", ansi.Color("FAIL", "red+b"), result.Expectation.Title())
+			for _, violation := range result.Violations {
+				if err == nil {
+					_, err = fmt.Fprintf(w, "    %s\n", ansi.Color(violation, "red"))
+				}
+			}
+		default:
+			_, err = fmt.Fprintf(w, "%s %s\n", ansi.Color("PASS", "green+b"), result.Expectation.Title())
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	passed, failed, errored := r.Counts()
+	_, err := fmt.Fprintf(w, "\n%d passed, %d failed, %d errors\n", passed, failed, errored)
+	return err
+}
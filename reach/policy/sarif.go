@@ -0,0 +1,127 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifRuleID  = "reach/expectation"
+)
+
+// The subset of SARIF (Static Analysis Results Interchange Format) used to report on a policy, so that code scanning tools can show results inline on the policy file.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Kind      string          `json:"kind"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// WriteSARIF writes the report as a SARIF log to the given writer, with one result for each expectation, located at the expectation's line in the policy file.
+func (r Report) WriteSARIF(w io.Writer) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "reach",
+				InformationURI: "https://github.com/luhring/reach",
+				Rules: []sarifRule{
+					{
+						ID:               sarifRuleID,
+						ShortDescription: sarifMessage{Text: "network reachability expectation"},
+					},
+				},
+			},
+		},
+		Results: []sarifResult{}, // SARIF requires an array, even if it's empty
+	}
+
+	for _, result := range r.Results {
+		sr := sarifResult{
+			RuleID: sarifRuleID,
+			Locations: []sarifLocation{
+				{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: r.Policy.Path},
+						Region:           sarifRegion{StartLine: result.Expectation.Line},
+					},
+				},
+			},
+		}
+
+		title := result.Expectation.Title()
+
+		switch {
+		case result.Error != nil:
+			sr.Kind, sr.Level = "fail", "error"
+			sr.Message.Text = fmt.Sprintf("%s: unable to evaluate: %v", title, result.Error)
+		case len(result.Violations) > 0:
+			sr.Kind, sr.Level = "fail", "error"
+			sr.Message.Text = fmt.Sprintf("%s: %s", title, strings.Join(result.Violations, "; "))
+		default:
+			sr.Kind, sr.Level = "pass", "none"
+			sr.Message.Text = title
+		}
+
+		run.Results = append(run.Results, sr)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{run},
+	})
+}
@@ -16,6 +16,9 @@ const (
 	ProtocolICMPv6 Protocol = 58
 )
 
+// maxProtocolNumber is the highest IP protocol number, since the protocol field of an IP packet is 8 bits.
+const maxProtocolNumber = 255
+
 // Names of the most common IP protocols.
 const (
 	ProtocolNameAll    = "all"
@@ -61,6 +64,33 @@ func ProtocolName(protocol Protocol) string {
 	}
 }
 
+// protocolFromName returns the IP protocol for a name returned by ProtocolName.
+func protocolFromName(name string) (Protocol, error) {
+	switch name {
+	case ProtocolNameICMPv4:
+		return ProtocolICMPv4, nil
+	case ProtocolNameTCP:
+		return ProtocolTCP, nil
+	case ProtocolNameUDP:
+		return ProtocolUDP, nil
+	case ProtocolNameICMPv6:
+		return ProtocolICMPv6, nil
+	}
+
+	var number int
+	if _, err := fmt.Sscanf(name, "IP protocol %d", &number); err == nil {
+		return Protocol(number), nil
+	}
+
+	for protocol, protocolName := range ipProtocols {
+		if protocolName == name {
+			return protocol, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unrecognized IP protocol name: '%s'", name)
+}
+
 func customProtocolName(protocol Protocol) string {
 	name, exists := ipProtocols[protocol]
 	if exists {
@@ -3,6 +3,7 @@ package reach
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/luhring/reach/reach/set"
@@ -249,7 +250,7 @@ func (pc ProtocolContent) getProtocolName() string {
 	case ProtocolICMPv6:
 		return ProtocolNameICMPv6
 	default:
-		return string(pc.Protocol)
+		return strconv.Itoa(int(pc.Protocol))
 	}
 }
 
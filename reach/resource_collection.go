@@ -1,9 +1,14 @@
 package reach
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+)
 
-// A ResourceCollection is a structure used to store any number of Resources, across potentially multiple "domains" (e.g. AWS, GCP, Azure) and kinds (e.g. EC2 instance, subnet, etc.).
+// A ResourceCollection is a structure used to store any number of Resources, across potentially multiple "domains" (e.g. AWS, GCP, Azure) and kinds (e.g. EC2 instance, subnet, etc.). A ResourceCollection is safe for concurrent use.
 type ResourceCollection struct {
+	mu         sync.RWMutex
 	collection map[string]map[string]map[string]Resource
 }
 
@@ -18,17 +23,18 @@ func NewResourceCollection() *ResourceCollection {
 
 // Put adds a new Resource to the ResourceCollection.
 func (rc *ResourceCollection) Put(ref ResourceReference, resource Resource) {
-	rc.ensureResourcePathExists(ref.Domain, ref.Kind)
-
-	other := NewResourceCollection()
-	other.ensureResourcePathExists(ref.Domain, ref.Kind)
-	other.collection[ref.Domain][ref.Kind][ref.ID] = resource
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
 
-	rc.Merge(other)
+	rc.ensureResourcePathExists(ref.Domain, ref.Kind)
+	rc.collection[ref.Domain][ref.Kind][ref.ID] = resource
 }
 
 // Get retrieves a Resource from the ResourceCollection.
 func (rc *ResourceCollection) Get(ref ResourceReference) *Resource {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
 	if _, exists := rc.collection[ref.Domain]; !exists {
 		return nil
 	}
@@ -43,50 +49,92 @@ func (rc *ResourceCollection) Get(ref ResourceReference) *Resource {
 	return nil
 }
 
+// GetAll retrieves all Resources of the specified domain and kind from the ResourceCollection, ordered by resource ID.
+func (rc *ResourceCollection) GetAll(domain, kind string) []Resource {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	if _, exists := rc.collection[domain]; !exists {
+		return nil
+	}
+
+	resources, exists := rc.collection[domain][kind]
+	if !exists {
+		return nil
+	}
+
+	ids := make([]string, 0, len(resources))
+	for id := range resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	result := make([]Resource, len(ids))
+	for i, id := range ids {
+		result[i] = resources[id]
+	}
+
+	return result
+}
+
 // Merge safely merges two ResourceCollections such that any unique resource from either collection is represented in the merged collection. For any case where both collections contain a resource for a given domain, kind, and resource ID, the "other" (input parameter) resource will overwrite the corresponding resource in the first collection.
+//
+// The resources are copied out of the other collection, so later changes to either collection don't affect the other.
 func (rc *ResourceCollection) Merge(other *ResourceCollection) {
-	for resourceDomain, resourceKinds := range rc.collection { // e.g. for AWS
-		if _, exists := other.collection[resourceDomain]; !exists { // only A has AWS
-			rc.collection[resourceDomain] = resourceKinds
-		} else { // both have AWS
-			rc.ensureResourcePathExists(resourceDomain, "")
-
-			for resourceKind, resources := range rc.collection[resourceDomain] { // e.g. for EC2 instances
-				if _, exists := other.collection[resourceDomain][resourceKind]; !exists { // only A has any EC2 instances
-					rc.collection[resourceDomain][resourceKind] = resources
-				} else { // both have some EC2 instances
-					rc.ensureResourcePathExists(resourceDomain, resourceKind)
-
-					for id, resource := range rc.collection[resourceDomain][resourceKind] { // e.g. for EC2 instance with ID i-abc123def456
-						rc.collection[resourceDomain][resourceKind][id] = resource
-					}
-
-					for id, resource := range other.collection[resourceDomain][resourceKind] {
-						rc.collection[resourceDomain][resourceKind][id] = resource
-					}
-				}
-			}
-
-			for resourceKind, resources := range other.collection[resourceDomain] { // e.g. for security groups
-				if _, exists := rc.collection[resourceDomain][resourceKind]; !exists { // only B has any security groups
-					rc.collection[resourceDomain][resourceKind] = resources
-				}
-			}
-		}
+	if other == nil || other == rc {
+		return
 	}
 
-	for resourceDomain, resourceKinds := range other.collection { // e.g. for GCP
-		if _, exists := rc.collection[resourceDomain]; !exists { // only B has GCP
-			rc.collection[resourceDomain] = resourceKinds
-		}
+	// Copy other's resources before locking rc, so that two collections merging into each other concurrently can't deadlock.
+	entries := other.entries()
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, e := range entries {
+		rc.ensureResourcePathExists(e.ref.Domain, e.ref.Kind)
+		rc.collection[e.ref.Domain][e.ref.Kind][e.ref.ID] = e.resource
 	}
 }
 
 // MarshalJSON returns the JSON representation of the ResourceCollection.
 func (rc *ResourceCollection) MarshalJSON() ([]byte, error) {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
 	return json.Marshal(rc.collection)
 }
 
+type resourceCollectionEntry struct {
+	ref      ResourceReference
+	resource Resource
+}
+
+func (rc *ResourceCollection) entries() []resourceCollectionEntry {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+
+	var result []resourceCollectionEntry
+
+	for domain, kinds := range rc.collection {
+		for kind, resources := range kinds {
+			for id, resource := range resources {
+				result = append(result, resourceCollectionEntry{
+					ref: ResourceReference{
+						Domain: domain,
+						Kind:   kind,
+						ID:     id,
+					},
+					resource: resource,
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// ensureResourcePathExists must be called with rc.mu held for writing.
 func (rc *ResourceCollection) ensureResourcePathExists(domain, kind string) {
 	if domain == "" {
 		return
@@ -0,0 +1,76 @@
+package reach
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestResourceCollectionConcurrentUse(t *testing.T) {
+	const goroutines = 20
+
+	rc := NewResourceCollection()
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			other := NewResourceCollection()
+			other.Put(ResourceReference{Domain: "test", Kind: "b", ID: fmt.Sprint(i)}, Resource{Kind: "b"})
+
+			rc.Put(ResourceReference{Domain: "test", Kind: "a", ID: fmt.Sprint(i)}, Resource{Kind: "a"})
+			rc.Merge(other)
+			other.Merge(rc)
+			_ = rc.GetAll("test", "a")
+		}(i)
+	}
+	wg.Wait()
+
+	for _, kind := range []string{"a", "b"} {
+		if resources := rc.GetAll("test", kind); len(resources) != goroutines {
+			t.Errorf("expected %d resources of kind '%s', but got %d", goroutines, kind, len(resources))
+		}
+	}
+}
+
+func TestResourceCollectionMergeCopiesResources(t *testing.T) {
+	rc := NewResourceCollection()
+	other := NewResourceCollection()
+	other.Put(ResourceReference{Domain: "test", Kind: "a", ID: "1"}, Resource{Kind: "a"})
+
+	rc.Merge(other)
+	rc.Put(ResourceReference{Domain: "test", Kind: "a", ID: "2"}, Resource{Kind: "a"})
+
+	if other.Get(ResourceReference{Domain: "test", Kind: "a", ID: "2"}) != nil {
+		t.Error("expected a resource added after merging to be absent from the merged-in collection")
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	const n = 50
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	err := ForEachConcurrently(n, 4, func(i int) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		seen[i] = true
+
+		if i == 30 || i == 10 {
+			return fmt.Errorf("failed at %d", i)
+		}
+		return nil
+	})
+
+	if len(seen) != n {
+		t.Errorf("expected fn to be called %d times, but got %d", n, len(seen))
+	}
+
+	if err == nil || err.Error() != "failed at 10" {
+		t.Errorf("expected the error from the lowest failing index, but got %v", err)
+	}
+}
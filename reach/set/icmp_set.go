@@ -1,7 +1,9 @@
 package set
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -71,6 +73,24 @@ func NewICMPSetFromICMPTypeCode(icmpType, icmpCode uint8) (ICMPSet, error) {
 	}, nil
 }
 
+// NewICMPSetFromRangeStrings returns a new ICMPSet containing all ICMP type/code combinations described by the input range strings, which use the same format as the output of RangeStrings (e.g. "8", "3/4", or "3/0-5/1").
+func NewICMPSetFromRangeStrings(rangeStrings []string) (ICMPSet, error) {
+	result := NewEmptyICMPSet()
+
+	for _, rangeString := range rangeStrings {
+		first, last, err := parseICMPRangeString(rangeString)
+		if err != nil {
+			return ICMPSet{}, fmt.Errorf("unable to parse ICMP range '%s': %v", rangeString, err)
+		}
+
+		result = result.Merge(ICMPSet{
+			set: newSetFromRange(first, last),
+		})
+	}
+
+	return result, nil
+}
+
 // Complete returns a boolean indicating whether or not the ICMPSet is complete.
 func (s ICMPSet) Complete() bool {
 	return s.set.Complete()
@@ -173,6 +193,211 @@ func (s ICMPSet) RangeStringsV6() []string {
 	return result
 }
 
+// RangeStrings returns a slice of strings, where each string describes a continuous range of ICMP type/code combinations within the ICMPSet using numeric values. A range that covers every code of one or more types is expressed using only the types (e.g. "8" or "3-5"), and any other range is expressed using "type/code" values (e.g. "3/4" or "3/0-3/4"). Unlike RangeStringsV4 and RangeStringsV6, the output can be parsed back into an ICMPSet using NewICMPSetFromRangeStrings.
+func (s ICMPSet) RangeStrings() []string {
+	var result []string
+
+	for _, rangeItem := range s.set.ranges() {
+		first := decodeICMPTypeCode(rangeItem.first)
+		last := decodeICMPTypeCode(rangeItem.last)
+
+		if first.icmpCode == minimumICMPCode && last.icmpCode == maximumICMPCode {
+			result = append(result, Range{uint16(first.icmpType), uint16(last.icmpType)}.String())
+			continue
+		}
+
+		if first == last {
+			result = append(result, first.numericString())
+			continue
+		}
+
+		result = append(result, fmt.Sprintf("%s-%s", first.numericString(), last.numericString()))
+	}
+
+	return result
+}
+
+// LabeledRangeStringsV4 returns the same strings as RangeStringsV4, except that a range starting or ending with an ICMPv4 type whose name is shared with another type (e.g. "reserved") is described with numeric values, as in RangeStrings. Unlike the output of RangeStringsV4, the output can always be parsed back into an ICMPSet using NewICMPSetFromLabeledRangeStringsV4.
+func (s ICMPSet) LabeledRangeStringsV4() []string {
+	return s.labeledRangeStrings(GetICMPv4TypeName, ICMPSet.RangeStringsV4)
+}
+
+// LabeledRangeStringsV6 returns the same strings as RangeStringsV6, except that a range starting or ending with an ICMPv6 type whose name is shared with another type (e.g. "private experimentation") is described with numeric values, as in RangeStrings. Unlike the output of RangeStringsV6, the output can always be parsed back into an ICMPSet using NewICMPSetFromLabeledRangeStringsV6.
+func (s ICMPSet) LabeledRangeStringsV6() []string {
+	return s.labeledRangeStrings(GetICMPv6TypeName, ICMPSet.RangeStringsV6)
+}
+
+func (s ICMPSet) labeledRangeStrings(typeName func(uint8) string, rangeStrings func(ICMPSet) []string) []string {
+	if s.Complete() {
+		return rangeStrings(s)
+	}
+
+	var result []string
+
+	for _, rangeItem := range s.set.ranges() {
+		single := ICMPSet{
+			set: newSetFromRange(rangeItem.first, rangeItem.last),
+		}
+
+		first := decodeICMPTypeCode(rangeItem.first)
+		last := decodeICMPTypeCode(rangeItem.last)
+
+		if uniquelyNamedICMPType(first.icmpType, typeName) && uniquelyNamedICMPType(last.icmpType, typeName) {
+			result = append(result, rangeStrings(single)...)
+		} else {
+			result = append(result, single.RangeStrings()...)
+		}
+	}
+
+	return result
+}
+
+// NewICMPSetFromLabeledRangeStringsV4 returns a new ICMPSet containing all ICMPv4 type/code combinations described by the input range strings, which use the format of either LabeledRangeStringsV4 (e.g. "ICMPv4 type \"echo request\" (all traffic)") or RangeStrings (e.g. "8"). A range string can also be just the name of a type (e.g. "echo-request"), for all of the type's codes.
+func NewICMPSetFromLabeledRangeStringsV4(rangeStrings []string) (ICMPSet, error) {
+	return newICMPSetFromLabeledRangeStrings(rangeStrings, "ICMPv4", GetICMPv4TypeName)
+}
+
+// NewICMPSetFromLabeledRangeStringsV6 returns a new ICMPSet containing all ICMPv6 type/code combinations described by the input range strings, which use the format of either LabeledRangeStringsV6 (e.g. "ICMPv6 type \"echo request\" (all traffic)") or RangeStrings (e.g. "128").
+func NewICMPSetFromLabeledRangeStringsV6(rangeStrings []string) (ICMPSet, error) {
+	return newICMPSetFromLabeledRangeStrings(rangeStrings, "ICMPv6", GetICMPv6TypeName)
+}
+
+func newICMPSetFromLabeledRangeStrings(rangeStrings []string, label string, typeName func(uint8) string) (ICMPSet, error) {
+	result := NewEmptyICMPSet()
+
+	for _, rangeString := range rangeStrings {
+		first, last, err := parseICMPRangeString(rangeString)
+		if err != nil {
+			first, last, err = parseLabeledICMPRangeString(rangeString, label, typeName)
+		}
+		if err != nil {
+			return ICMPSet{}, fmt.Errorf("unable to parse %s range '%s': %v", label, rangeString, err)
+		}
+
+		result = result.Merge(ICMPSet{
+			set: newSetFromRange(first, last),
+		})
+	}
+
+	return result, nil
+}
+
+// parseLabeledICMPRangeString parses a range string in the format used by RangeStringsV4 and RangeStringsV6, or the name of a single type.
+func parseLabeledICMPRangeString(s, label string, typeName func(uint8) string) (uint16, uint16, error) {
+	if s == fmt.Sprintf("%s (all traffic)", label) {
+		return encodeICMPTypeCode(minimumICMPType, minimumICMPCode), encodeICMPTypeCode(maximumICMPType, maximumICMPCode), nil
+	}
+
+	typePrefix := fmt.Sprintf("%s type \"", label)
+	const typeSuffix = "\" (all traffic)"
+	if strings.HasPrefix(s, typePrefix) && strings.HasSuffix(s, typeSuffix) {
+		icmpType, err := icmpTypeFromUniqueName(strings.TrimSuffix(strings.TrimPrefix(s, typePrefix), typeSuffix), typeName)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return encodeICMPTypeCode(uint(icmpType), minimumICMPCode), encodeICMPTypeCode(uint(icmpType), maximumICMPCode), nil
+	}
+
+	if !strings.Contains(s, " (code ") {
+		icmpType, err := icmpTypeFromUniqueName(s, typeName)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		return encodeICMPTypeCode(uint(icmpType), minimumICMPCode), encodeICMPTypeCode(uint(icmpType), maximumICMPCode), nil
+	}
+
+	bounds := strings.Split(s, " - ")
+	if len(bounds) > 2 {
+		return 0, 0, fmt.Errorf("too many ' - ' separators")
+	}
+
+	first, err := parseLabeledICMPTypeCode(bounds[0], typeName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	last := first
+	if len(bounds) == 2 {
+		last, err = parseLabeledICMPTypeCode(bounds[1], typeName)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if first > last {
+		return 0, 0, fmt.Errorf("range start is greater than range end")
+	}
+
+	return first, last, nil
+}
+
+// parseLabeledICMPTypeCode parses a type/code value in the format used by StringV4 and StringV6 (e.g. "destination unreachable (code 4)").
+func parseLabeledICMPTypeCode(s string, typeName func(uint8) string) (uint16, error) {
+	const codePrefix = " (code "
+	i := strings.LastIndex(s, codePrefix)
+	if i < 0 || !strings.HasSuffix(s, ")") {
+		return 0, fmt.Errorf("expected type name followed by '%scode)'", codePrefix)
+	}
+
+	icmpType, err := icmpTypeFromUniqueName(s[:i], typeName)
+	if err != nil {
+		return 0, err
+	}
+
+	icmpCode, err := strconv.ParseUint(strings.TrimSuffix(s[i+len(codePrefix):], ")"), 10, 8)
+	if err != nil {
+		return 0, err
+	}
+
+	return encodeICMPTypeCode(uint(icmpType), uint(icmpCode)), nil
+}
+
+// icmpTypeFromUniqueName returns the ICMP type whose name (as returned by typeName) matches the given name, as long as no other type shares the name. Names are matched ignoring case, punctuation and spaces, along with any parenthesized suffix, so "echo-request" matches "echo request" and "router-solicitation" matches "router solicitation (NDP)".
+func icmpTypeFromUniqueName(name string, typeName func(uint8) string) (uint8, error) {
+	var matches []uint8
+
+	normalized := normalizeICMPTypeName(name)
+
+	for t := minimumICMPType; t <= maximumICMPType; t++ {
+		if normalized != "" && normalizeICMPTypeName(typeName(uint8(t))) == normalized {
+			matches = append(matches, uint8(t))
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, fmt.Errorf("unrecognized ICMP type name: '%s'", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return 0, fmt.Errorf("ICMP type name '%s' is ambiguous, since it's used for types %v", name, matches)
+	}
+}
+
+func uniquelyNamedICMPType(icmpType uint8, typeName func(uint8) string) bool {
+	_, err := icmpTypeFromUniqueName(typeName(icmpType), typeName)
+	return err == nil
+}
+
+// normalizeICMPTypeName lowercases the name and removes everything other than letters and digits, along with any parenthesized suffix (e.g. "router solicitation (NDP)" becomes "routersolicitation").
+func normalizeICMPTypeName(name string) string {
+	if i := strings.Index(name, "("); i > 0 {
+		name = name[:i]
+	}
+
+	var b strings.Builder
+
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
 // StringV4 returns the string representation of the ICMPSet, assuming that the set describes ICMPv4 content.
 func (s ICMPSet) StringV4() string {
 	if s.Empty() {
@@ -210,6 +435,91 @@ func (s ICMPSet) Subtract(other ICMPSet) ICMPSet {
 	}
 }
 
+// MarshalJSON returns the JSON representation of the ICMPSet, which is the list of its numeric range strings (see RangeStrings).
+func (s ICMPSet) MarshalJSON() ([]byte, error) {
+	rangeStrings := s.RangeStrings()
+	if rangeStrings == nil {
+		rangeStrings = []string{}
+	}
+
+	return json.Marshal(rangeStrings)
+}
+
+// UnmarshalJSON parses the JSON representation of an ICMPSet, as produced by MarshalJSON.
+func (s *ICMPSet) UnmarshalJSON(data []byte) error {
+	var rangeStrings []string
+	if err := json.Unmarshal(data, &rangeStrings); err != nil {
+		return fmt.Errorf("unable to parse ICMP set: %v", err)
+	}
+
+	result, err := NewICMPSetFromRangeStrings(rangeStrings)
+	if err != nil {
+		return err
+	}
+
+	*s = result
+	return nil
+}
+
+// MarshalText returns the text representation of the ICMPSet, which is its numeric range strings separated by commas (e.g. "0,3/4,8"). An empty ICMPSet is represented by an empty string.
+func (s ICMPSet) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.RangeStrings(), ",")), nil
+}
+
+// UnmarshalText parses the text representation of an ICMPSet, as produced by MarshalText.
+func (s *ICMPSet) UnmarshalText(text []byte) error {
+	result, err := NewICMPSetFromRangeStrings(splitRangeStrings(string(text)))
+	if err != nil {
+		return err
+	}
+
+	*s = result
+	return nil
+}
+
+// parseICMPRangeString parses a single range string in the format produced by RangeStrings, returning the first and last encoded type/code values of the range.
+func parseICMPRangeString(s string) (uint16, uint16, error) {
+	var typesOnly, typeCodes bool
+
+	first, last, err := parseRangeString(s, func(value string) (uint16, error) {
+		typeCode := strings.Split(value, "/")
+		if len(typeCode) > 2 {
+			return 0, fmt.Errorf("too many '/' separators")
+		}
+
+		icmpType, err := strconv.ParseUint(typeCode[0], 10, 8)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(typeCode) == 1 {
+			typesOnly = true
+			return encodeICMPTypeCode(uint(icmpType), minimumICMPCode), nil
+		}
+
+		icmpCode, err := strconv.ParseUint(typeCode[1], 10, 8)
+		if err != nil {
+			return 0, err
+		}
+
+		typeCodes = true
+		return encodeICMPTypeCode(uint(icmpType), uint(icmpCode)), nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if typesOnly && typeCodes {
+		return 0, 0, fmt.Errorf("cannot mix types with type/code values")
+	}
+
+	if typesOnly {
+		last |= maximumICMPCode // include every code of the last type
+	}
+
+	return first, last, nil
+}
+
 func validateICMPType(icmpType uint8) error {
 	if icmpType < minimumICMPType || icmpType > maximumICMPType {
 		return fmt.Errorf(
@@ -0,0 +1,309 @@
+package set
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestICMPSetRangeStrings(t *testing.T) {
+	mustICMPSetFromType := func(icmpType uint8) ICMPSet {
+		s, err := NewICMPSetFromICMPType(icmpType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	mustICMPSetFromTypeCode := func(icmpType, icmpCode uint8) ICMPSet {
+		s, err := NewICMPSetFromICMPTypeCode(icmpType, icmpCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	cases := []struct {
+		name     string
+		s        ICMPSet
+		expected []string
+	}{
+		{
+			"empty set",
+			NewEmptyICMPSet(),
+			nil,
+		},
+		{
+			"full set",
+			NewFullICMPSet(),
+			[]string{"0-255"},
+		},
+		{
+			"single type",
+			mustICMPSetFromType(8),
+			[]string{"8"},
+		},
+		{
+			"adjacent types",
+			mustICMPSetFromType(3).Merge(mustICMPSetFromType(4)),
+			[]string{"3-4"},
+		},
+		{
+			"single type and code",
+			mustICMPSetFromTypeCode(3, 4),
+			[]string{"3/4"},
+		},
+		{
+			"adjacent codes",
+			mustICMPSetFromTypeCode(3, 4).Merge(mustICMPSetFromTypeCode(3, 5)),
+			[]string{"3/4-3/5"},
+		},
+		{
+			"mixed ranges",
+			mustICMPSetFromType(0).Merge(mustICMPSetFromTypeCode(11, 1)),
+			[]string{"0", "11/1"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.s.RangeStrings()
+			if !reflect.DeepEqual(tc.expected, actual) {
+				t.Errorf("expected %v but got %v", tc.expected, actual)
+			}
+
+			parsed, err := NewICMPSetFromRangeStrings(actual)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !parsed.set.equals(tc.s.set) {
+				t.Errorf("expected parsed set to equal original set, but got %v", parsed.RangeStrings())
+			}
+		})
+	}
+}
+
+func TestICMPSetLabeledRangeStrings(t *testing.T) {
+	mustICMPSetFromType := func(icmpType uint8) ICMPSet {
+		s, err := NewICMPSetFromICMPType(icmpType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	mustICMPSetFromTypeCode := func(icmpType, icmpCode uint8) ICMPSet {
+		s, err := NewICMPSetFromICMPTypeCode(icmpType, icmpCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+
+	cases := []struct {
+		name       string
+		s          ICMPSet
+		expectedV4 []string
+		expectedV6 []string
+	}{
+		{
+			"full set",
+			NewFullICMPSet(),
+			[]string{"ICMPv4 (all traffic)"},
+			[]string{"ICMPv6 (all traffic)"},
+		},
+		{
+			"single named type",
+			mustICMPSetFromType(3),
+			[]string{`ICMPv4 type "destination unreachable" (all traffic)`},
+			[]string{`ICMPv6 type "time exceeded" (all traffic)`},
+		},
+		{
+			"range of codes",
+			mustICMPSetFromTypeCode(3, 4).Merge(mustICMPSetFromTypeCode(3, 5)),
+			[]string{"destination unreachable (code 4) - destination unreachable (code 5)"},
+			[]string{"time exceeded (code 4) - time exceeded (code 5)"},
+		},
+		{
+			"unnamed type",
+			mustICMPSetFromType(200),
+			[]string{`ICMPv4 type "(unnamed ICMPv4 type: 200)" (all traffic)`},
+			[]string{`ICMPv6 type "(unnamed ICMPv6 type: 200)" (all traffic)`},
+		},
+		{
+			"types with shared names",
+			mustICMPSetFromType(7).Merge(mustICMPSetFromType(30)).Merge(mustICMPSetFromType(100)),
+			[]string{"7", "30", `ICMPv4 type "(unnamed ICMPv4 type: 100)" (all traffic)`},
+			[]string{`ICMPv6 type "(unnamed ICMPv6 type: 7)" (all traffic)`, `ICMPv6 type "(unnamed ICMPv6 type: 30)" (all traffic)`, "100"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			versions := []struct {
+				name         string
+				rangeStrings func(ICMPSet) []string
+				parse        func([]string) (ICMPSet, error)
+				expected     []string
+			}{
+				{"ICMPv4", ICMPSet.LabeledRangeStringsV4, NewICMPSetFromLabeledRangeStringsV4, tc.expectedV4},
+				{"ICMPv6", ICMPSet.LabeledRangeStringsV6, NewICMPSetFromLabeledRangeStringsV6, tc.expectedV6},
+			}
+
+			for _, v := range versions {
+				actual := v.rangeStrings(tc.s)
+				if !reflect.DeepEqual(v.expected, actual) {
+					t.Errorf("%s: expected %v but got %v", v.name, v.expected, actual)
+				}
+
+				parsed, err := v.parse(actual)
+				if err != nil {
+					t.Fatalf("%s: %v", v.name, err)
+				}
+
+				if !parsed.set.equals(tc.s.set) {
+					t.Errorf("%s: expected parsed set to equal original set, but got %v", v.name, parsed.RangeStrings())
+				}
+			}
+		})
+	}
+}
+
+func TestNewICMPSetFromLabeledRangeStringsV4Invalid(t *testing.T) {
+	cases := []string{
+		"",
+		"ICMPv6 (all traffic)",
+		`ICMPv4 type "reserved" (all traffic)`,
+		`ICMPv4 type "no such type" (all traffic)`,
+		"information request",
+		"packet-too-big",
+		"echo request (code 256)",
+		"echo request (code 1) - echo reply (code 0)",
+		"echo request (code 0) - echo request (code 1) - echo request (code 2)",
+	}
+
+	for _, rangeString := range cases {
+		t.Run(rangeString, func(t *testing.T) {
+			if _, err := NewICMPSetFromLabeledRangeStringsV4([]string{rangeString}); err == nil {
+				t.Errorf("expected error for range string '%s'", rangeString)
+			}
+		})
+	}
+}
+
+func TestNewICMPSetFromRangeStringsInvalid(t *testing.T) {
+	cases := []string{
+		"",
+		"256",
+		"3/256",
+		"5-3",
+		"3-4/1",
+		"3/1/2",
+		"echo",
+	}
+
+	for _, rangeString := range cases {
+		t.Run(rangeString, func(t *testing.T) {
+			if _, err := NewICMPSetFromRangeStrings([]string{rangeString}); err == nil {
+				t.Errorf("expected error for range string '%s'", rangeString)
+			}
+		})
+	}
+}
+
+func TestICMPSetJSONAndTextRoundTrip(t *testing.T) {
+	echoRequest, err := NewICMPSetFromICMPType(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	portUnreachable, err := NewICMPSetFromICMPTypeCode(3, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		s            ICMPSet
+		expectedJSON string
+		expectedText string
+	}{
+		{"empty set", NewEmptyICMPSet(), `[]`, ""},
+		{"full set", NewFullICMPSet(), `["0-255"]`, "0-255"},
+		{"mixed ranges", echoRequest.Merge(portUnreachable), `["3/3","8"]`, "3/3,8"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.expectedJSON {
+				t.Errorf("expected JSON %s but got %s", tc.expectedJSON, b)
+			}
+
+			var fromJSON ICMPSet
+			if err := json.Unmarshal(b, &fromJSON); err != nil {
+				t.Fatal(err)
+			}
+			if !fromJSON.set.equals(tc.s.set) {
+				t.Errorf("expected set parsed from JSON to equal original set, but got %v", fromJSON.RangeStrings())
+			}
+
+			text, err := tc.s.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(text) != tc.expectedText {
+				t.Errorf("expected text '%s' but got '%s'", tc.expectedText, text)
+			}
+
+			var fromText ICMPSet
+			if err := fromText.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+			if !fromText.set.equals(tc.s.set) {
+				t.Errorf("expected set parsed from text to equal original set, but got %v", fromText.RangeStrings())
+			}
+		})
+	}
+}
+
+func TestNewICMPSetFromLabeledRangeStringsTypeNames(t *testing.T) {
+	cases := []struct {
+		name     string
+		v6       bool
+		expected uint8
+	}{
+		{"echo-request", false, 8},
+		{"Echo Request", false, 8},
+		{"destination_unreachable", false, 3},
+		{"parameter problem: bad IP header", false, 12},
+		{"echo-request", true, 128},
+		{"router-solicitation", true, 133},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parse := NewICMPSetFromLabeledRangeStringsV4
+			if tc.v6 {
+				parse = NewICMPSetFromLabeledRangeStringsV6
+			}
+
+			actual, err := parse([]string{tc.name})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, err := NewICMPSetFromICMPType(tc.expected)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !actual.set.equals(expected.set) {
+				t.Errorf("expected all codes of type %d but got %v", tc.expected, actual.RangeStrings())
+			}
+		})
+	}
+}
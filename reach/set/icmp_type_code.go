@@ -75,6 +75,10 @@ func (i ICMPTypeCode) StringV6() string {
 	return fmt.Sprintf("%s (code %d)", typeName, i.icmpCode)
 }
 
+func (i ICMPTypeCode) numericString() string {
+	return fmt.Sprintf("%d/%d", i.icmpType, i.icmpCode)
+}
+
 // GetICMPv4TypeName returns the ICMPv4 name for the given ICMP type value.
 func GetICMPv4TypeName(icmpType uint8) string {
 	typeName, exists := icmpv4TypeNames[icmpType]
@@ -3,6 +3,8 @@ package set
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -44,6 +46,27 @@ func NewPortSetFromRange(lowPort, highPort uint16) (PortSet, error) {
 	}, nil
 }
 
+// NewPortSetFromRangeStrings returns a new PortSet containing all ports described by the input range strings, which use the same format as the output of RangeStrings (e.g. "22" or "8000-8100").
+func NewPortSetFromRangeStrings(rangeStrings []string) (PortSet, error) {
+	result := NewEmptyPortSet()
+
+	for _, rangeString := range rangeStrings {
+		low, high, err := parseRangeString(rangeString, parsePort)
+		if err != nil {
+			return PortSet{}, fmt.Errorf("unable to parse port range '%s': %v", rangeString, err)
+		}
+
+		portSet, err := NewPortSetFromRange(low, high)
+		if err != nil {
+			return PortSet{}, err
+		}
+
+		result = result.Merge(portSet)
+	}
+
+	return result, nil
+}
+
 // Complete returns a boolean indicating whether or not the PortSet is complete.
 func (s PortSet) Complete() bool {
 	return s.set.Complete()
@@ -85,9 +108,55 @@ func (s PortSet) String() string {
 	return s.set.String()
 }
 
-// MarshalJSON returns the JSON representation of the PortSet.
+// MarshalJSON returns the JSON representation of the PortSet, which is the list of its range strings (see RangeStrings).
 func (s PortSet) MarshalJSON() ([]byte, error) {
-	return json.Marshal(s)
+	rangeStrings := s.RangeStrings()
+	if rangeStrings == nil {
+		rangeStrings = []string{}
+	}
+
+	return json.Marshal(rangeStrings)
+}
+
+// UnmarshalJSON parses the JSON representation of a PortSet, as produced by MarshalJSON.
+func (s *PortSet) UnmarshalJSON(data []byte) error {
+	var rangeStrings []string
+	if err := json.Unmarshal(data, &rangeStrings); err != nil {
+		return fmt.Errorf("unable to parse port set: %v", err)
+	}
+
+	result, err := NewPortSetFromRangeStrings(rangeStrings)
+	if err != nil {
+		return err
+	}
+
+	*s = result
+	return nil
+}
+
+// MarshalText returns the text representation of the PortSet, which is its range strings separated by commas (e.g. "22,443,8000-8100"). An empty PortSet is represented by an empty string.
+func (s PortSet) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.RangeStrings(), ",")), nil
+}
+
+// UnmarshalText parses the text representation of a PortSet, as produced by MarshalText.
+func (s *PortSet) UnmarshalText(text []byte) error {
+	result, err := NewPortSetFromRangeStrings(splitRangeStrings(string(text)))
+	if err != nil {
+		return err
+	}
+
+	*s = result
+	return nil
+}
+
+func parsePort(s string) (uint16, error) {
+	port, err := strconv.ParseUint(s, 10, 16)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(port), nil
 }
 
 func validatePort(port uint16) error {
@@ -0,0 +1,76 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPortSetJSONAndTextRoundTrip(t *testing.T) {
+	ssh, err := NewPortSetFromRange(22, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := NewPortSetFromRange(8000, 8100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		s            PortSet
+		expectedJSON string
+		expectedText string
+	}{
+		{"empty set", NewEmptyPortSet(), `[]`, ""},
+		{"full set", NewFullPortSet(), `["0-65535"]`, "0-65535"},
+		{"multiple ranges", ssh.Merge(custom), `["22","8000-8100"]`, "22,8000-8100"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := json.Marshal(tc.s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tc.expectedJSON {
+				t.Errorf("expected JSON %s but got %s", tc.expectedJSON, b)
+			}
+
+			var fromJSON PortSet
+			if err := json.Unmarshal(b, &fromJSON); err != nil {
+				t.Fatal(err)
+			}
+			if !fromJSON.set.equals(tc.s.set) {
+				t.Errorf("expected set parsed from JSON to equal original set, but got %v", fromJSON.RangeStrings())
+			}
+
+			text, err := tc.s.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(text) != tc.expectedText {
+				t.Errorf("expected text '%s' but got '%s'", tc.expectedText, text)
+			}
+
+			var fromText PortSet
+			if err := fromText.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+			if !fromText.set.equals(tc.s.set) {
+				t.Errorf("expected set parsed from text to equal original set, but got %v", fromText.RangeStrings())
+			}
+		})
+	}
+}
+
+func TestPortSetUnmarshalInvalid(t *testing.T) {
+	var s PortSet
+
+	if err := json.Unmarshal([]byte(`["22","http"]`), &s); err == nil {
+		t.Error("expected an error for a non-numeric port")
+	}
+
+	if err := s.UnmarshalText([]byte("70000")); err == nil {
+		t.Error("expected an error for an out-of-range port")
+	}
+}
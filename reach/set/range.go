@@ -3,6 +3,7 @@ package set
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // Range defines a continuous, inclusive range of values.
@@ -11,6 +12,47 @@ type Range struct {
 	last  uint16
 }
 
+// parseRangeString parses a string in the format "first" or "first-last", using parseValue to interpret each value.
+func parseRangeString(s string, parseValue func(string) (uint16, error)) (uint16, uint16, error) {
+	bounds := strings.Split(strings.TrimSpace(s), "-")
+	if len(bounds) > 2 {
+		return 0, 0, fmt.Errorf("too many '-' separators")
+	}
+
+	first, err := parseValue(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(bounds) == 1 {
+		return first, first, nil
+	}
+
+	last, err := parseValue(strings.TrimSpace(bounds[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if first > last {
+		return 0, 0, fmt.Errorf("range start must not be greater than range end")
+	}
+
+	return first, last, nil
+}
+
+// splitRangeStrings splits a comma-separated list of range strings, ignoring blank items.
+func splitRangeStrings(s string) []string {
+	var result []string
+
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
 // String returns the string representation of the Range.
 func (r Range) String() string {
 	if r.first == r.last {
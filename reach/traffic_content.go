@@ -18,6 +18,8 @@ const (
 	trafficContentIndicatorNone
 	allTrafficString = "all traffic"
 	noTrafficString  = "(none)"
+	allTrafficJSON   = "[all traffic]"
+	noTrafficJSON    = "[no traffic]"
 )
 
 type trafficContentIndicator int
@@ -221,6 +223,11 @@ func (tc *TrafficContent) Intersect(other TrafficContent) (TrafficContent, error
 				return TrafficContent{}, err
 			}
 
+			// e.g. TCP 22 and TCP 5432 have no traffic in common, and an empty protocol shouldn't count as traffic
+			if intersection.empty() {
+				continue
+			}
+
 			result.setProtocolContent(p, intersection)
 		}
 	}
@@ -238,14 +245,29 @@ func (tc *TrafficContent) Subtract(other TrafficContent) (TrafficContent, error)
 		return *tc, nil
 	}
 
+	protocols := tc.Protocols()
+
+	if tc.All() {
+		// all traffic isn't broken down by protocol, so subtract from the full content of every protocol
+		protocols = nil
+		for p := 0; p <= maxProtocolNumber; p++ {
+			protocols = append(protocols, Protocol(p))
+		}
+	}
+
 	result := newTrafficContent()
 
-	for p, pc := range tc.protocols {
-		pcDifference, err := pc.subtract(other.protocol(p))
+	for _, p := range protocols {
+		pcDifference, err := tc.protocol(p).subtract(other.protocol(p))
 		if err != nil {
 			return TrafficContent{}, fmt.Errorf("unable to subtract traffic content: %v", err)
 		}
 
+		// e.g. TCP 5432 minus TCP 5432 leaves no traffic, and an empty protocol shouldn't count as traffic
+		if pcDifference.empty() {
+			continue
+		}
+
 		result.setProtocolContent(p, pcDifference)
 	}
 
@@ -255,11 +277,11 @@ func (tc *TrafficContent) Subtract(other TrafficContent) (TrafficContent, error)
 // MarshalJSON returns the JSON representation of the TrafficContent.
 func (tc TrafficContent) MarshalJSON() ([]byte, error) {
 	if tc.None() {
-		return json.Marshal("[no traffic]")
+		return json.Marshal(noTrafficJSON)
 	}
 
 	if tc.All() {
-		return json.Marshal("[all traffic]")
+		return json.Marshal(allTrafficJSON)
 	}
 
 	result := make(map[string][]string)
@@ -270,15 +292,15 @@ func (tc TrafficContent) MarshalJSON() ([]byte, error) {
 			result[key] = content.Ports.RangeStrings()
 		} else if protocol.UsesICMPTypeCodes() {
 			if protocol == ProtocolICMPv6 {
-				result[key] = content.ICMP.RangeStringsV6()
+				result[key] = content.ICMP.LabeledRangeStringsV6()
 			} else {
-				result[key] = content.ICMP.RangeStringsV4()
+				result[key] = content.ICMP.LabeledRangeStringsV4()
 			}
 		} else {
 			if content.CustomProtocolHasContent != nil && *content.CustomProtocolHasContent {
-				result[key] = []string{"[all traffic]"}
+				result[key] = []string{allTrafficJSON}
 			} else {
-				result[key] = []string{"[no traffic]"}
+				result[key] = []string{noTrafficJSON}
 			}
 		}
 	}
@@ -286,6 +308,76 @@ func (tc TrafficContent) MarshalJSON() ([]byte, error) {
 	return json.Marshal(result)
 }
 
+// UnmarshalJSON parses the JSON representation of a TrafficContent, as produced by MarshalJSON.
+func (tc *TrafficContent) UnmarshalJSON(data []byte) error {
+	var indicator string
+	if err := json.Unmarshal(data, &indicator); err == nil {
+		switch indicator {
+		case allTrafficJSON:
+			*tc = NewTrafficContentForAllTraffic()
+		case noTrafficJSON:
+			*tc = NewTrafficContentForNoTraffic()
+		default:
+			return fmt.Errorf("unable to parse traffic content: unrecognized value '%s'", indicator)
+		}
+
+		return nil
+	}
+
+	var contents map[string][]string
+	if err := json.Unmarshal(data, &contents); err != nil {
+		return fmt.Errorf("unable to parse traffic content: %v", err)
+	}
+
+	result := newTrafficContent()
+
+	for name, rangeStrings := range contents {
+		protocol, err := protocolFromName(name)
+		if err != nil {
+			return fmt.Errorf("unable to parse traffic content: %v", err)
+		}
+
+		if protocol.UsesPorts() || protocol.UsesICMPTypeCodes() {
+			content, err := newProtocolContentFromRangeStrings(protocol, rangeStrings)
+			if err != nil {
+				return fmt.Errorf("unable to parse traffic content: %v", err)
+			}
+
+			result.setProtocolContent(protocol, content)
+		} else {
+			hasContent := len(rangeStrings) == 1 && rangeStrings[0] == allTrafficJSON
+			result.setProtocolContent(protocol, newProtocolContentForCustomProtocol(protocol, hasContent))
+		}
+	}
+
+	*tc = result
+	return nil
+}
+
+// newProtocolContentFromRangeStrings returns the content of a protocol that uses ports or ICMP type/codes, described by range strings in the format used by MarshalJSON (e.g. "8000-8100" or "ICMPv4 type \"echo request\" (all traffic)"). ICMP range strings can also use the numeric format of set.ICMPSet.RangeStrings, or just a type name (e.g. "echo-request").
+func newProtocolContentFromRangeStrings(p Protocol, rangeStrings []string) (ProtocolContent, error) {
+	if p.UsesPorts() {
+		ports, err := set.NewPortSetFromRangeStrings(rangeStrings)
+		if err != nil {
+			return ProtocolContent{}, err
+		}
+
+		return newProtocolContentWithPorts(p, &ports), nil
+	}
+
+	parse := set.NewICMPSetFromLabeledRangeStringsV4
+	if p == ProtocolICMPv6 {
+		parse = set.NewICMPSetFromLabeledRangeStringsV6
+	}
+
+	icmp, err := parse(rangeStrings)
+	if err != nil {
+		return ProtocolContent{}, err
+	}
+
+	return newProtocolContentWithICMP(p, &icmp), nil
+}
+
 // String returns the string representation of the TrafficContent.
 func (tc TrafficContent) String() string {
 	if tc.All() {
@@ -344,6 +436,36 @@ func (tc TrafficContent) String() string {
 	return strings.Join(outputItems, "\n") + "\n"
 }
 
+// Summary returns a single-line representation of the TrafficContent, with each protocol's content separated by a semicolon (e.g. "TCP 22, 443; UDP 53").
+func (tc TrafficContent) Summary() string {
+	if tc.All() {
+		return allTrafficString
+	}
+
+	var contents []*ProtocolContent
+
+	for _, content := range tc.protocols {
+		if !content.empty() {
+			contents = append(contents, content)
+		}
+	}
+
+	if len(contents) == 0 {
+		return noTrafficString
+	}
+
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].Protocol < contents[j].Protocol
+	})
+
+	items := make([]string, len(contents))
+	for i, content := range contents {
+		items[i] = content.String()
+	}
+
+	return strings.Join(items, "; ")
+}
+
 // StringWithSymbols returns the string representation of the TrafficContent, with the added feature of pre-pending each output line with a symbol, intended for display to the user.
 func (tc TrafficContent) StringWithSymbols() string {
 	if tc.All() {
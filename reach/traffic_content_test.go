@@ -0,0 +1,149 @@
+package reach
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+func TestTrafficContentJSONRoundTrip(t *testing.T) {
+	mustMerge := func(contents ...TrafficContent) TrafficContent {
+		tc, err := NewTrafficContentFromMergingMultiple(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tc
+	}
+
+	mustPorts := func(low, high uint16) set.PortSet {
+		ports, err := set.NewPortSetFromRange(low, high)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ports
+	}
+
+	mustICMPTypeCode := func(icmpType, icmpCode uint8) set.ICMPSet {
+		icmp, err := set.NewICMPSetFromICMPTypeCode(icmpType, icmpCode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return icmp
+	}
+
+	cases := []struct {
+		name string
+		tc   TrafficContent
+	}{
+		{
+			"all traffic",
+			NewTrafficContentForAllTraffic(),
+		},
+		{
+			"no traffic",
+			NewTrafficContentForNoTraffic(),
+		},
+		{
+			"TCP ports",
+			mustMerge(
+				NewTrafficContentForPorts(ProtocolTCP, mustPorts(22, 22)),
+				NewTrafficContentForPorts(ProtocolTCP, mustPorts(8000, 8100)),
+			),
+		},
+		{
+			"assorted protocols",
+			mustMerge(
+				NewTrafficContentForPorts(ProtocolUDP, mustPorts(53, 53)),
+				NewTrafficContentForICMP(ProtocolICMPv4, mustICMPTypeCode(3, 4)),
+				NewTrafficContentForICMP(ProtocolICMPv6, set.NewFullICMPSet()),
+				NewTrafficContentForCustomProtocol(50, true),
+				NewTrafficContentForCustomProtocol(143, true),
+			),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := json.Marshal(tc.tc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var decoded TrafficContent
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			if expected, actual := tc.tc.String(), decoded.String(); expected != actual {
+				DiffErrorf(t, "traffic content", expected, actual)
+			}
+		})
+	}
+}
+
+func TestTrafficContentJSONUsesICMPTypeNames(t *testing.T) {
+	echoRequest, err := set.NewICMPSetFromICMPType(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(NewTrafficContentForICMP(ProtocolICMPv4, echoRequest))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"ICMPv4":["ICMPv4 type \"echo request\" (all traffic)"]}`
+	if actual := string(data); actual != expected {
+		DiffErrorf(t, "JSON", expected, actual)
+	}
+}
+
+func TestTrafficContentIntersectWithoutCommonPorts(t *testing.T) {
+	ssh, err := set.NewPortSetFromRange(22, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+	postgres, err := set.NewPortSetFromRange(5432, 5432)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := NewTrafficContentForPorts(ProtocolTCP, ssh)
+	second := NewTrafficContentForPorts(ProtocolTCP, postgres)
+
+	intersection, err := first.Intersect(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !intersection.None() {
+		t.Errorf("expected no traffic, but got: %s", intersection)
+	}
+}
+
+func TestTrafficContentSubtractFromAllTraffic(t *testing.T) {
+	ssh, err := set.NewPortSetFromRange(22, 22)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := NewTrafficContentForAllTraffic()
+
+	difference, err := all.Subtract(NewTrafficContentForPorts(ProtocolTCP, ssh))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if difference.None() || difference.All() {
+		t.Fatalf("expected all traffic except TCP 22, but got: %s", difference.Summary())
+	}
+
+	if tcp := difference.protocol(ProtocolTCP).Ports.String(); tcp != "0-21, 23-65535" {
+		t.Errorf("expected TCP ports other than 22, but got: %s", tcp)
+	}
+
+	if udp := difference.protocol(ProtocolUDP); !udp.complete() {
+		t.Errorf("expected all of UDP, but got: %s", udp)
+	}
+}
@@ -0,0 +1,217 @@
+package reach
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	allTrafficText = "all"
+	noTrafficText  = "none"
+)
+
+// ParseTrafficContent parses the compact text representation of a TrafficContent: a list of protocols separated by semicolons, where each protocol is optionally followed by a colon and a comma-separated list of values, e.g. "tcp:22,443,8000-8100; icmp:echo-request; esp".
+//
+// Protocols are named as in ParseProtocol. For TCP and UDP, the values are port ranges. For ICMP and ICMPv6, the values are type names (e.g. "echo-request"), types (e.g. "8") or type/code ranges (e.g. "3/4" or "3/0-3/4"). A protocol without values includes all of the protocol's traffic, and the value "none" includes none of it. The whole text can also be "all" (or "[all traffic]") for all traffic, or "none" (or "[no traffic]", or an empty string) for no traffic.
+func ParseTrafficContent(text string) (TrafficContent, error) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case allTrafficText, allTrafficString, allTrafficJSON:
+		return NewTrafficContentForAllTraffic(), nil
+	case noTrafficText, noTrafficString, noTrafficJSON, "":
+		return NewTrafficContentForNoTraffic(), nil
+	}
+
+	result := newTrafficContent()
+
+	for _, item := range strings.Split(text, ";") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+
+		if name, _, hasValues := splitProtocolContentText(item); !hasValues {
+			if p, err := ParseProtocol(name); err == nil && p == ProtocolAll {
+				return NewTrafficContentForAllTraffic(), nil
+			}
+		}
+
+		content, err := parseProtocolContentText(item)
+		if err != nil {
+			return TrafficContent{}, fmt.Errorf("unable to parse traffic '%s': %v", strings.TrimSpace(item), err)
+		}
+
+		result, err = result.Merge(trafficContentFromProtocolContent(content))
+		if err != nil {
+			return TrafficContent{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// MarshalText returns the compact text representation of the TrafficContent (see ParseTrafficContent), with protocols in numeric order, e.g. "tcp:22,443; udp:53".
+func (tc TrafficContent) MarshalText() ([]byte, error) {
+	if tc.All() {
+		return []byte(allTrafficText), nil
+	}
+
+	var contents []*ProtocolContent
+
+	for _, content := range tc.protocols {
+		if !content.empty() {
+			contents = append(contents, content)
+		}
+	}
+
+	if len(contents) == 0 {
+		return []byte(noTrafficText), nil
+	}
+
+	sort.Slice(contents, func(i, j int) bool {
+		return contents[i].Protocol < contents[j].Protocol
+	})
+
+	items := make([]string, len(contents))
+	for i, content := range contents {
+		items[i] = content.text()
+	}
+
+	return []byte(strings.Join(items, "; ")), nil
+}
+
+// UnmarshalText parses the compact text representation of a TrafficContent (see ParseTrafficContent).
+func (tc *TrafficContent) UnmarshalText(text []byte) error {
+	result, err := ParseTrafficContent(string(text))
+	if err != nil {
+		return err
+	}
+
+	*tc = result
+	return nil
+}
+
+// MarshalText returns the compact text representation of the ProtocolContent, which is the representation of a single protocol within the text representation of a TrafficContent (e.g. "tcp:22,443" or "esp").
+func (pc ProtocolContent) MarshalText() ([]byte, error) {
+	return []byte(pc.text()), nil
+}
+
+// UnmarshalText parses the compact text representation of a ProtocolContent, as produced by MarshalText.
+func (pc *ProtocolContent) UnmarshalText(text []byte) error {
+	result, err := parseProtocolContentText(string(text))
+	if err != nil {
+		return fmt.Errorf("unable to parse protocol content '%s': %v", text, err)
+	}
+
+	*pc = result
+	return nil
+}
+
+func (pc ProtocolContent) text() string {
+	keyword := protocolKeyword(pc.Protocol)
+
+	switch {
+	case pc.empty():
+		return keyword + ":" + noTrafficText
+	case pc.complete():
+		return keyword
+	case pc.isTCPOrUDP():
+		return keyword + ":" + strings.Join(pc.Ports.RangeStrings(), ",")
+	default:
+		return keyword + ":" + strings.Join(pc.ICMP.RangeStrings(), ",")
+	}
+}
+
+// protocolKeyword returns the name of the protocol as accepted by ParseProtocol (e.g. "tcp" or "esp"), or the protocol's number if it doesn't have a name that can be parsed unambiguously.
+func protocolKeyword(p Protocol) string {
+	switch p {
+	case ProtocolICMPv4:
+		return "icmp"
+	case ProtocolTCP, ProtocolUDP, ProtocolICMPv6:
+		return strings.ToLower(ProtocolName(p))
+	}
+
+	if name, exists := ipProtocols[p]; exists && !strings.ContainsAny(name, " :;,/") {
+		if parsed, err := ParseProtocol(name); err == nil && parsed == p {
+			return strings.ToLower(name)
+		}
+	}
+
+	return strconv.Itoa(int(p))
+}
+
+// splitProtocolContentText splits the text of a single protocol into the lowercase protocol name and its values, if any.
+func splitProtocolContentText(text string) (protocol string, values []string, hasValues bool) {
+	text = strings.TrimSpace(text)
+
+	protocol = text
+	if i := strings.Index(text, ":"); i >= 0 {
+		protocol, hasValues = text[:i], true
+
+		for _, value := range strings.Split(text[i+1:], ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(protocol)), values, hasValues
+}
+
+func parseProtocolContentText(text string) (ProtocolContent, error) {
+	name, values, hasValues := splitProtocolContentText(text)
+
+	p, err := ParseProtocol(name)
+	if err != nil {
+		return ProtocolContent{}, err
+	}
+
+	if p == ProtocolAll {
+		return ProtocolContent{}, fmt.Errorf("'%s' can't be used as a single protocol", name)
+	}
+
+	if hasValues && len(values) == 0 {
+		return ProtocolContent{}, fmt.Errorf("no values specified after ':'")
+	}
+
+	none := len(values) == 1 && strings.EqualFold(values[0], noTrafficText)
+
+	switch {
+	case !hasValues:
+		return fullProtocolContent(p), nil
+	case none:
+		return emptyProtocolContent(p), nil
+	case p.UsesPorts(), p.UsesICMPTypeCodes():
+		return newProtocolContentFromRangeStrings(p, values)
+	default:
+		return ProtocolContent{}, fmt.Errorf("values can only be specified for TCP, UDP, ICMP and ICMPv6, not '%s'", name)
+	}
+}
+
+func fullProtocolContent(p Protocol) ProtocolContent {
+	switch {
+	case p.UsesPorts():
+		return newProtocolContentWithPortsFull(p)
+	case p.UsesICMPTypeCodes():
+		return newProtocolContentWithICMPFull(p)
+	default:
+		return newProtocolContentForCustomProtocolFull(p)
+	}
+}
+
+func emptyProtocolContent(p Protocol) ProtocolContent {
+	switch {
+	case p.UsesPorts():
+		return newProtocolContentWithPortsEmpty(p)
+	case p.UsesICMPTypeCodes():
+		return newProtocolContentWithICMPEmpty(p)
+	default:
+		return newProtocolContentForCustomProtocolEmpty(p)
+	}
+}
+
+func trafficContentFromProtocolContent(content ProtocolContent) TrafficContent {
+	tc := newTrafficContent()
+	tc.setProtocolContent(content.Protocol, content)
+	return tc
+}
@@ -0,0 +1,142 @@
+package reach
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseTrafficContent(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected string
+	}{
+		{"tcp:22,443,8000-8100; icmp:echo-request; esp", "ICMPv4 type \"echo request\" (all traffic); TCP 22, 443, 8000-8100; ESP (all traffic)"},
+		{"udp:53", "UDP 53"},
+		{"TCP : 22 ; tcp:80", "TCP 22, 80"},
+		{"icmpv6:echo-request,packet-too-big", "ICMPv6 type \"packet too big\" (all traffic), ICMPv6 type \"echo request\" (all traffic)"},
+		{"icmp:3/4", "destination unreachable (code 4) - destination unreachable (code 4)"},
+		{"tcp", "TCP 0-65535"},
+		{"tcp:none", "(none)"},
+		{"50", "ESP (all traffic)"},
+		{"all", "all traffic"},
+		{"tcp:22; all", "all traffic"},
+		{"[all traffic]", "all traffic"},
+		{"none", "(none)"},
+		{"", "(none)"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.text, func(t *testing.T) {
+			traffic, err := ParseTrafficContent(tc.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if summary := traffic.Summary(); summary != tc.expected {
+				DiffErrorf(t, "summary", tc.expected, summary)
+			}
+		})
+	}
+}
+
+func TestParseTrafficContentErrors(t *testing.T) {
+	texts := []string{
+		"tcp:",
+		"tcp:http",
+		"tcp:70000",
+		"esp:1",
+		"icmp:not-a-type",
+		"all:22",
+		"not-a-protocol",
+	}
+
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			if _, err := ParseTrafficContent(text); err == nil {
+				t.Errorf("expected an error for traffic '%s'", text)
+			}
+		})
+	}
+}
+
+func TestTrafficContentTextRoundTrip(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected string
+	}{
+		{"esp; udp:53; tcp:8000-8100,22", "tcp:22,8000-8100; udp:53; esp"},
+		{"icmp:echo-request,3/4; icmpv6", "icmp:3/4,8; icmpv6"},
+		{"all", "all"},
+		{"none", "none"},
+		{"ISIS over IPv4", "124"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.text, func(t *testing.T) {
+			traffic, err := ParseTrafficContent(tc.text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			text, err := traffic.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(text) != tc.expected {
+				DiffErrorf(t, "text", tc.expected, string(text))
+			}
+
+			var parsed TrafficContent
+			if err := parsed.UnmarshalText(text); err != nil {
+				t.Fatal(err)
+			}
+
+			if parsed.Summary() != traffic.Summary() {
+				DiffErrorf(t, "summary after round trip", traffic.Summary(), parsed.Summary())
+			}
+		})
+	}
+}
+
+func TestProtocolContentRoundTrip(t *testing.T) {
+	texts := []string{
+		"tcp:22,443",
+		"udp:none",
+		"icmp:8",
+		"icmpv6",
+		"esp",
+		"esp:none",
+	}
+
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			var pc ProtocolContent
+			if err := pc.UnmarshalText([]byte(text)); err != nil {
+				t.Fatal(err)
+			}
+
+			output, err := pc.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(output) != text {
+				DiffErrorf(t, "text", text, string(output))
+			}
+
+			b, err := json.Marshal(pc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var parsed ProtocolContent
+			if err := json.Unmarshal(b, &parsed); err != nil {
+				t.Fatal(err)
+			}
+
+			if parsed.String() != pc.String() {
+				DiffErrorf(t, "protocol content after JSON round trip", pc.String(), parsed.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package reach
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+// ParseProtocol returns the IP protocol for the given name or number. Names are case-insensitive, and can be "all", "tcp", "udp", "icmp" (or "icmpv4"), "icmpv6", or the IANA keyword for any other IP protocol (e.g. "esp").
+func ParseProtocol(name string) (Protocol, error) {
+	switch strings.ToLower(name) {
+	case ProtocolNameAll, "-1":
+		return ProtocolAll, nil
+	case "icmp", "icmpv4":
+		return ProtocolICMPv4, nil
+	case "tcp":
+		return ProtocolTCP, nil
+	case "udp":
+		return ProtocolUDP, nil
+	case "icmpv6":
+		return ProtocolICMPv6, nil
+	}
+
+	if number, err := strconv.ParseUint(name, 10, 8); err == nil {
+		return Protocol(number), nil
+	}
+
+	for protocol, protocolName := range ipProtocols {
+		if strings.EqualFold(protocolName, name) {
+			return protocol, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unrecognized IP protocol: '%s'", name)
+}
+
+// NewTrafficQuery returns the traffic content for the given protocol (see ParseProtocol) and port ranges (e.g. "5432" or "8000-8100"). Ports can only be specified for TCP and UDP. If no ports are specified, the traffic content includes all of the protocol's traffic.
+func NewTrafficQuery(protocol string, ports []string) (TrafficContent, error) {
+	p, err := ParseProtocol(protocol)
+	if err != nil {
+		return TrafficContent{}, err
+	}
+
+	if len(ports) > 0 && !p.UsesPorts() {
+		return TrafficContent{}, fmt.Errorf("ports can only be specified for TCP and UDP, not '%s'", protocol)
+	}
+
+	switch {
+	case p == ProtocolAll:
+		return NewTrafficContentForAllTraffic(), nil
+	case p.UsesPorts():
+		portSet := set.NewFullPortSet()
+
+		if len(ports) > 0 {
+			portSet, err = set.NewPortSetFromRangeStrings(ports)
+			if err != nil {
+				return TrafficContent{}, err
+			}
+		}
+
+		return NewTrafficContentForPorts(p, portSet), nil
+	case p.UsesICMPTypeCodes():
+		return NewTrafficContentForICMP(p, set.NewFullICMPSet()), nil
+	default:
+		return NewTrafficContentForCustomProtocol(p, true), nil
+	}
+}
+
+// ParseTrafficQuery returns the traffic content for a comma-separated list of protocols, each optionally followed by a slash and a port range, e.g. "tcp/443,udp/53,tcp/8000-8100,icmp". The query can also use the compact syntax of ParseTrafficContent (e.g. "tcp:22,443; icmp:echo-request").
+func ParseTrafficQuery(query string) (TrafficContent, error) {
+	if strings.ContainsAny(query, ":;") {
+		tc, err := ParseTrafficContent(query)
+		if err != nil {
+			return TrafficContent{}, err
+		}
+
+		if tc.None() {
+			return TrafficContent{}, fmt.Errorf("traffic query '%s' doesn't specify any traffic", query)
+		}
+
+		return tc, nil
+	}
+
+	var contents []TrafficContent
+
+	for _, item := range strings.Split(query, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		protocol, ports := item, []string(nil)
+		if i := strings.Index(item, "/"); i >= 0 {
+			protocol, ports = item[:i], []string{item[i+1:]}
+		}
+
+		tc, err := NewTrafficQuery(protocol, ports)
+		if err != nil {
+			return TrafficContent{}, fmt.Errorf("unable to use traffic '%s': %v", item, err)
+		}
+
+		contents = append(contents, tc)
+	}
+
+	if len(contents) == 0 {
+		return TrafficContent{}, fmt.Errorf("traffic query '%s' doesn't specify any traffic", query)
+	}
+
+	return NewTrafficContentFromMergingMultiple(contents)
+}
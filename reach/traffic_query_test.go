@@ -0,0 +1,98 @@
+package reach
+
+import (
+	"testing"
+)
+
+func TestParseTrafficQuery(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected string
+	}{
+		{"tcp/5432", "TCP 5432"},
+		{"TCP/443, udp/53", "TCP 443; UDP 53"},
+		{"tcp/22,tcp/8000-8100", "TCP 22, 8000-8100"},
+		{"tcp", "TCP 0-65535"},
+		{"icmp", "ICMPv4 (all traffic)"},
+		{"esp,6/80", "TCP 80; ESP (all traffic)"},
+		{"all", "all traffic"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			traffic, err := ParseTrafficQuery(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if summary := traffic.Summary(); summary != tc.expected {
+				DiffErrorf(t, "summary", tc.expected, summary)
+			}
+		})
+	}
+}
+
+func TestParseTrafficQueryErrors(t *testing.T) {
+	queries := []string{
+		"",
+		"tcp/",
+		"tcp/70000",
+		"icmp/8",
+		"not-a-protocol",
+	}
+
+	for _, query := range queries {
+		t.Run(query, func(t *testing.T) {
+			if _, err := ParseTrafficQuery(query); err == nil {
+				t.Errorf("expected an error for traffic query '%s'", query)
+			}
+		})
+	}
+}
+
+func TestAnalysisAssertionsWithTrafficQuery(t *testing.T) {
+	traffic := func(query string) *TrafficContent {
+		t.Helper()
+
+		tc, err := ParseTrafficQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &tc
+	}
+	none := NewTrafficContentForNoTraffic()
+
+	cases := []struct {
+		name                 string
+		query                string
+		forwardTraffic       *TrafficContent
+		returnTraffic        *TrafficContent
+		expectedReachable    bool
+		expectedNotReachable bool
+	}{
+		{"all queried traffic reaches", "tcp/5432", traffic("tcp/5432"), traffic("tcp"), true, false},
+		{"no queried traffic reaches", "tcp/22", &none, traffic("tcp"), false, true},
+		{"some queried traffic reaches", "tcp/22,tcp/5432", traffic("tcp/5432"), traffic("tcp"), false, false},
+		{"return path obstructed", "tcp/5432", traffic("tcp/5432"), traffic("tcp/1024-65535"), false, false},
+		{"query for all traffic", "all", traffic("tcp"), traffic("all"), false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			analysis := Analysis{
+				NetworkVectors: []NetworkVector{
+					{Traffic: tc.forwardTraffic, ReturnTraffic: tc.returnTraffic},
+				},
+				TrafficQuery: traffic(tc.query),
+			}
+
+			if passes := analysis.PassesAssertReachable(); passes != tc.expectedReachable {
+				t.Errorf("expected assert reachable to be %t, but got %t", tc.expectedReachable, passes)
+			}
+
+			if passes := analysis.PassesAssertNotReachable(); passes != tc.expectedNotReachable {
+				t.Errorf("expected assert not reachable to be %t, but got %t", tc.expectedNotReachable, passes)
+			}
+		})
+	}
+}
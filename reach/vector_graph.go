@@ -0,0 +1,225 @@
+package reach
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A VectorGraph is a diagram of the network vectors of one or more analyses. Each network vector becomes a path from the source's resources (e.g. an EC2 instance and its network interface) to the source's IP address, through each of the vector's factors, to the destination's IP address and resources. Each factor is an edge labeled with the traffic the factor allows (limited to the analysis's traffic query, if it has one), and factors that block all of that traffic are highlighted.
+type VectorGraph struct {
+	Nodes []VectorGraphNode
+	Edges []VectorGraphEdge
+}
+
+// A VectorGraphNode is a resource, an IP address, or a junction between two factors.
+type VectorGraphNode struct {
+	ID       string
+	Label    string
+	Junction bool
+}
+
+// A VectorGraphEdge connects two nodes. Edges between factors have a label, and are blocking if the factor allows no traffic.
+type VectorGraphEdge struct {
+	From     string
+	To       string
+	Label    string
+	Blocking bool
+}
+
+// A namer is a resource that has a display name (e.g. an AWS EC2 instance).
+type namer interface {
+	Name() string
+}
+
+// NewVectorGraph creates a VectorGraph of the network vectors of the given analyses. Resources that appear in more than one network vector are shown as a single node.
+func NewVectorGraph(analyses ...*Analysis) *VectorGraph {
+	g := &VectorGraph{}
+	nodeIDs := make(map[string]string)
+
+	node := func(key, label string, junction bool) string {
+		if id, exists := nodeIDs[key]; exists {
+			return id
+		}
+
+		id := fmt.Sprintf("n%d", len(g.Nodes))
+		nodeIDs[key] = id
+		g.Nodes = append(g.Nodes, VectorGraphNode{ID: id, Label: label, Junction: junction})
+		return id
+	}
+
+	edge := func(from, to, label string, blocking bool) {
+		for _, existing := range g.Edges {
+			if existing.From == from && existing.To == to && existing.Label == label {
+				return
+			}
+		}
+
+		g.Edges = append(g.Edges, VectorGraphEdge{From: from, To: to, Label: label, Blocking: blocking})
+	}
+
+	vectorCount := 0
+
+	for _, analysis := range analyses {
+		resourceNode := func(ref ResourceReference) string {
+			label := fmt.Sprintf("%s %s", ref.Kind, ref.ID)
+			if resource := analysis.Resources.Get(ref); resource != nil {
+				if n, ok := resource.Properties.(namer); ok {
+					label = fmt.Sprintf("%s %s", ref.Kind, n.Name())
+				}
+			}
+
+			return node(fmt.Sprintf("%s/%s/%s", ref.Domain, ref.Kind, ref.ID), label, false)
+		}
+
+		for _, v := range analysis.NetworkVectors {
+			var path []string
+
+			// source: outermost resource first
+			for i := len(v.Source.Lineage) - 1; i >= 0; i-- {
+				path = append(path, resourceNode(v.Source.Lineage[i]))
+			}
+			sourceAddress := node("point/"+v.Source.String(), v.Source.AddressString(), false)
+
+			for i := 0; i < len(path); i++ {
+				next := sourceAddress
+				if i+1 < len(path) {
+					next = path[i+1]
+				}
+				edge(path[i], next, "", false)
+			}
+
+			destinationAddress := node("point/"+v.Destination.String(), v.Destination.AddressString(), false)
+
+			factors := append(append([]Factor{}, v.Source.Factors...), v.Destination.Factors...)
+			previous := sourceAddress
+
+			for i, factor := range factors {
+				next := destinationAddress
+				if i+1 < len(factors) {
+					next = node(fmt.Sprintf("junction/%d/%d", vectorCount, i), "", true)
+				}
+
+				traffic := factor.Traffic
+				if analysis.TrafficQuery != nil {
+					if scoped, err := traffic.Intersect(*analysis.TrafficQuery); err == nil {
+						traffic = scoped
+					}
+				}
+
+				edge(previous, next, factorEdgeLabel(factor, traffic), traffic.None())
+				previous = next
+			}
+
+			if len(factors) == 0 {
+				edge(sourceAddress, destinationAddress, "", false)
+			}
+
+			// destination: innermost resource first
+			previous = destinationAddress
+			for _, ref := range v.Destination.Lineage {
+				next := resourceNode(ref)
+				edge(previous, next, "", false)
+				previous = next
+			}
+
+			vectorCount++
+		}
+	}
+
+	return g
+}
+
+// factorEdgeLabel describes the factor and the given traffic, which is the traffic the factor allows that's considered by the analysis.
+func factorEdgeLabel(factor Factor, traffic TrafficContent) string {
+	label := fmt.Sprintf("%s (%s): %s", factor.Kind, factor.Resource.ID, traffic.Summary())
+
+	if factor.ReturnTraffic.None() {
+		label += "; return traffic: (none)"
+	}
+
+	return label
+}
+
+// DOT returns the graph in the Graphviz DOT language. Blocking edges are red.
+func (g VectorGraph) DOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph reach {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, n := range g.Nodes {
+		if n.Junction {
+			fmt.Fprintf(&b, "  %s [shape=point];\n", n.ID)
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s [label=%s];\n", n.ID, dotQuote(n.Label))
+	}
+
+	for _, e := range g.Edges {
+		var attributes []string
+
+		if e.Label != "" {
+			attributes = append(attributes, "label="+dotQuote(e.Label))
+		}
+
+		if e.Blocking {
+			attributes = append(attributes, "color=red", "fontcolor=red", "penwidth=2")
+		}
+
+		if len(attributes) == 0 {
+			fmt.Fprintf(&b, "  %s -> %s;\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s -> %s [%s];\n", e.From, e.To, strings.Join(attributes, ", "))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart. Blocking edges are red.
+func (g VectorGraph) Mermaid() string {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	for _, n := range g.Nodes {
+		if n.Junction {
+			fmt.Fprintf(&b, "  %s(( ))\n", n.ID)
+			continue
+		}
+
+		fmt.Fprintf(&b, "  %s[%s]\n", n.ID, mermaidQuote(n.Label))
+	}
+
+	var blocking []string
+
+	for i, e := range g.Edges {
+		if e.Label == "" {
+			fmt.Fprintf(&b, "  %s --> %s\n", e.From, e.To)
+		} else {
+			fmt.Fprintf(&b, "  %s -->|%s| %s\n", e.From, mermaidQuote(e.Label), e.To)
+		}
+
+		if e.Blocking {
+			blocking = append(blocking, fmt.Sprint(i))
+		}
+	}
+
+	if len(blocking) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:red,stroke-width:2px,color:red\n", strings.Join(blocking, ","))
+	}
+
+	return b.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.NewReplacer(`"`, "#quot;", "\n", " ").Replace(s) + `"`
+}
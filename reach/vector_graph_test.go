@@ -0,0 +1,160 @@
+package reach
+
+import (
+	"net"
+	"testing"
+
+	"github.com/luhring/reach/reach/set"
+)
+
+type namedResource string
+
+func (n namedResource) Name() string {
+	return string(n)
+}
+
+func TestNewVectorGraph(t *testing.T) {
+	ports, err := set.NewPortSetFromRange(443, 443)
+	if err != nil {
+		t.Fatal(err)
+	}
+	https := NewTrafficContentForPorts(ProtocolTCP, ports)
+
+	instance := ResourceReference{Domain: "AWS", Kind: "EC2Instance", ID: "i-web"}
+	eni := ResourceReference{Domain: "AWS", Kind: "ElasticNetworkInterface", ID: "eni-web"}
+
+	resources := NewResourceCollection()
+	resources.Put(instance, Resource{Kind: instance.Kind, Properties: namedResource(`web "primary"`)})
+
+	source := NetworkPoint{
+		IPAddress: net.ParseIP("10.0.1.10"),
+		Lineage:   []ResourceReference{eni, instance},
+	}
+
+	vector := func(destinationIP string, factor Factor) NetworkVector {
+		return NetworkVector{
+			Source:      source,
+			Destination: NetworkPoint{IPAddress: net.ParseIP(destinationIP), Factors: []Factor{factor}},
+		}
+	}
+
+	analysis := &Analysis{
+		Resources: resources,
+		NetworkVectors: []NetworkVector{
+			vector("10.0.1.20", Factor{
+				Kind:          "SecurityGroupRules",
+				Resource:      ResourceReference{Domain: "AWS", Kind: "SecurityGroup", ID: "sg-db"},
+				Traffic:       https,
+				ReturnTraffic: NewTrafficContentForAllTraffic(),
+			}),
+			vector("10.0.1.30", Factor{
+				Kind:          "SecurityGroupRules",
+				Resource:      ResourceReference{Domain: "AWS", Kind: "SecurityGroup", ID: "sg-cache"},
+				Traffic:       NewTrafficContentForNoTraffic(),
+				ReturnTraffic: NewTrafficContentForNoTraffic(),
+			}),
+		},
+	}
+
+	expectedMermaid := `flowchart LR
+  n0["EC2Instance web #quot;primary#quot;"]
+  n1["ElasticNetworkInterface eni-web"]
+  n2["10.0.1.10"]
+  n3["10.0.1.20"]
+  n4["10.0.1.30"]
+  n0 --> n1
+  n1 --> n2
+  n2 -->|"SecurityGroupRules (sg-db): TCP 443"| n3
+  n2 -->|"SecurityGroupRules (sg-cache): (none); return traffic: (none)"| n4
+  linkStyle 3 stroke:red,stroke-width:2px,color:red
+`
+
+	expectedDOT := `digraph reach {
+  rankdir=LR;
+  node [shape=box];
+  n0 [label="EC2Instance web \"primary\""];
+  n1 [label="ElasticNetworkInterface eni-web"];
+  n2 [label="10.0.1.10"];
+  n3 [label="10.0.1.20"];
+  n4 [label="10.0.1.30"];
+  n0 -> n1;
+  n1 -> n2;
+  n2 -> n3 [label="SecurityGroupRules (sg-db): TCP 443"];
+  n2 -> n4 [label="SecurityGroupRules (sg-cache): (none); return traffic: (none)", color=red, fontcolor=red, penwidth=2];
+}
+`
+
+	cases := []struct {
+		name     string
+		analyses []*Analysis
+	}{
+		{
+			"single analysis",
+			[]*Analysis{analysis},
+		},
+		{
+			"same analysis twice",
+			[]*Analysis{analysis, analysis},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			graph := NewVectorGraph(tc.analyses...)
+
+			if mermaid := graph.Mermaid(); mermaid != expectedMermaid {
+				DiffErrorf(t, "Mermaid", expectedMermaid, mermaid)
+			}
+
+			if dot := graph.DOT(); dot != expectedDOT {
+				DiffErrorf(t, "DOT", expectedDOT, dot)
+			}
+		})
+	}
+}
+
+func TestVectorGraphQuoting(t *testing.T) {
+	cases := []struct {
+		name            string
+		label           string
+		expectedDOT     string
+		expectedMermaid string
+	}{
+		{
+			"plain",
+			"EC2Instance web",
+			`"EC2Instance web"`,
+			`"EC2Instance web"`,
+		},
+		{
+			"double quotes",
+			`EC2Instance "web"`,
+			`"EC2Instance \"web\""`,
+			`"EC2Instance #quot;web#quot;"`,
+		},
+		{
+			"backslash",
+			`C:\web`,
+			`"C:\\web"`,
+			`"C:\web"`,
+		},
+		{
+			"newline",
+			"web\nserver",
+			`"web\nserver"`,
+			`"web server"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := dotQuote(tc.label); actual != tc.expectedDOT {
+				DiffErrorf(t, "DOT", tc.expectedDOT, actual)
+			}
+
+			if actual := mermaidQuote(tc.label); actual != tc.expectedMermaid {
+				DiffErrorf(t, "Mermaid", tc.expectedMermaid, actual)
+			}
+		})
+	}
+}